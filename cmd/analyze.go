@@ -9,6 +9,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lakshaymaurya-felt/purewin/internal/analyze"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,7 @@ func init() {
 	analyzeCmd.Flags().Int("depth", 0, "Maximum directory depth to display")
 	analyzeCmd.Flags().String("min-size", "", "Minimum size to display (e.g., 100MB)")
 	analyzeCmd.Flags().StringSlice("exclude", nil, "Directories to exclude from scan")
+	addGentleFlag(analyzeCmd, false)
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) {
@@ -56,11 +58,21 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	minSizeStr, _ := cmd.Flags().GetString("min-size")
 	minSize := parseMinSize(minSizeStr)
 
+	// Gentle mode settings come from config; scan without it if config
+	// can't be loaded.
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = &config.Config{}
+	}
+	limiter, restorePriority := startGentle(cmd, cfg)
+	defer restorePriority()
+
 	// Try loading from cache first.
 	root, err := analyze.LoadCache(target)
 	if err != nil {
 		// No valid cache — run a fresh scan with a progress spinner.
 		scanner := analyze.NewScanner(8, exclude)
+		scanner.SetLimiter(limiter)
 
 		done := make(chan struct{})
 		go func() {
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/budget"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// ─── Circuit Breaker ─────────────────────────────────────────────────────────
+
+// safetyLimits parses the per-run budgets from config.
+func safetyLimits(cfg *config.Config) (budget.Limits, error) {
+	lim := budget.Limits{
+		MaxItems:    cfg.Safety.MaxItemsPerRun,
+		MaxDirShare: cfg.Safety.MaxDirShare,
+	}
+	if s := cfg.Safety.MaxBytesPerRun; s != "" {
+		n, err := parseSize(s)
+		if err != nil {
+			return lim, fmt.Errorf("invalid safety.max_bytes_per_run %q", s)
+		}
+		lim.MaxBytes = n
+	}
+	if err := lim.Validate(); err != nil {
+		return lim, fmt.Errorf("invalid safety config: %w", err)
+	}
+	return lim, nil
+}
+
+// mustSafetyLimits parses the per-run budgets, exiting on a bad config so a
+// mistyped budget never silently turns the breaker off.
+func mustSafetyLimits(cfg *config.Config) budget.Limits {
+	lim, err := safetyLimits(cfg)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return lim
+}
+
+// runBreaker wires the circuit breaker into one deleting run. A nil
+// *runBreaker allows everything.
+type runBreaker struct {
+	b           *budget.Breaker
+	sizes       map[string]int64
+	interactive bool
+	logger      *core.Logger
+
+	// pause and resume, if set, stop and restart a spinner around prompts.
+	pause  func()
+	resume func()
+}
+
+// newRunBreaker creates the breaker for a run over items. Roots may be nil
+// to skip the outside-roots check; with no roots and no limits it returns
+// nil. When interactive, a trip asks whether to continue; otherwise it
+// aborts the run.
+func newRunBreaker(lim budget.Limits, roots []string, items []report.Item, interactive bool, logger *core.Logger) *runBreaker {
+	if len(roots) == 0 && lim == (budget.Limits{}) {
+		return nil
+	}
+	sizes := make(map[string]int64, len(items))
+	for _, item := range items {
+		sizes[item.Path] = item.Size
+	}
+	rb := &runBreaker{
+		b: budget.New(budget.Options{
+			Limits: lim,
+			Roots:  roots,
+			Home:   os.Getenv("USERPROFILE"),
+			DirSize: func(dir string) int64 {
+				size, _, _ := report.StatPath(dir)
+				return size
+			},
+		}),
+		sizes:       sizes,
+		interactive: interactive,
+		logger:      logger,
+	}
+	rb.log("BUDGET", "limits "+formatLimits(lim))
+	return rb
+}
+
+// before checks path against the breaker before it is deleted. It is meant
+// for purewin.DeleteOptions.Before and guard.Reclaimer.Allow.
+func (rb *runBreaker) before(path string) error {
+	if rb == nil {
+		return nil
+	}
+	return rb.guard(func() *budget.Trip { return rb.b.Check(path, rb.sizes[path]) })
+}
+
+// beforeExtra checks an extra target's cleanup, estimated at size bytes,
+// against the run's byte and item budgets before it runs.
+func (rb *runBreaker) beforeExtra(name string, size int64) error {
+	if rb == nil {
+		return nil
+	}
+	return rb.guard(func() *budget.Trip { return rb.b.CheckSize(name, size) })
+}
+
+// guard runs check until it passes, asking whether to override each trip
+// when interactive; it returns the trip that ended the run.
+func (rb *runBreaker) guard(check func() *budget.Trip) error {
+	for {
+		trip := check()
+		if trip == nil {
+			return nil
+		}
+		rb.log("BREAKER_TRIP", trip.Error())
+		if !rb.interactive || !rb.confirm(trip) {
+			rb.log("BREAKER_ABORT", trip.Path)
+			return trip
+		}
+		rb.b.Override(trip)
+		rb.log("BREAKER_OVERRIDE", trip.Reason+" "+trip.Path)
+	}
+}
+
+// record counts a completed deletion.
+func (rb *runBreaker) record(path string, freed int64) {
+	if rb != nil {
+		rb.b.Record(path, freed)
+	}
+}
+
+// recordExtra counts a completed extra target cleanup.
+func (rb *runBreaker) recordExtra(freed int64) {
+	if rb != nil {
+		rb.b.RecordSize(freed)
+	}
+}
+
+// finish writes the final budget state to the op log.
+func (rb *runBreaker) finish() {
+	if rb == nil {
+		return
+	}
+	st := rb.b.State()
+	rb.log("BUDGET", fmt.Sprintf("used %s in %d items, %d trips (limits %s)",
+		core.FormatSize(st.Bytes), st.Items, st.Trips, formatLimits(st.Limits)))
+}
+
+// confirm explains a trip and asks whether to continue.
+func (rb *runBreaker) confirm(trip *budget.Trip) bool {
+	if rb.pause != nil {
+		rb.pause()
+	}
+	if rb.resume != nil {
+		defer rb.resume()
+	}
+
+	st := rb.b.State()
+	fmt.Println()
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  Circuit breaker: %s", ui.IconWarning, describeTrip(trip, st))))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Next: %s", trip.Path)))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  So far: %s in %d items", core.FormatSize(st.Bytes), st.Items)))
+
+	prompt := "  Continue past this limit for the rest of the run?"
+	if trip.Dir != "" {
+		prompt = fmt.Sprintf("  Allow deletions under %s for the rest of the run?", trip.Dir)
+	}
+	ok, err := ui.Confirm(prompt)
+	return err == nil && ok
+}
+
+// log writes an event to the op log, if one is open.
+func (rb *runBreaker) log(op, detail string) {
+	if rb.logger != nil {
+		rb.logger.LogEvent(op, detail)
+	}
+}
+
+// describeTrip renders a trip for people.
+func describeTrip(t *budget.Trip, st budget.State) string {
+	switch t.Reason {
+	case budget.ReasonBytes:
+		return fmt.Sprintf("the run would pass its %s budget", core.FormatSize(st.Limits.MaxBytes))
+	case budget.ReasonItems:
+		return fmt.Sprintf("the run would pass its %d-item budget", st.Limits.MaxItems)
+	case budget.ReasonDirShare:
+		return fmt.Sprintf("the run would delete more than %.0f%% of %s", st.Limits.MaxDirShare*100, t.Dir)
+	case budget.ReasonOutside:
+		return "this path is outside every expected clean target"
+	}
+	return t.Detail
+}
+
+// formatLimits renders budget limits for the op log.
+func formatLimits(lim budget.Limits) string {
+	bytes, items, share := "none", "none", "none"
+	if lim.MaxBytes > 0 {
+		bytes = core.FormatSize(lim.MaxBytes)
+	}
+	if lim.MaxItems > 0 {
+		items = fmt.Sprint(lim.MaxItems)
+	}
+	if lim.MaxDirShare > 0 {
+		share = fmt.Sprintf("%.0f%%", lim.MaxDirShare*100)
+	}
+	return fmt.Sprintf("bytes=%s items=%s dir_share=%s", bytes, items, share)
+}
+
+// printBreakerStop reports a run the breaker ended early.
+func printBreakerStop(err error) {
+	fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+		"  %s  Stopped: %v", ui.IconError, err)))
+	fmt.Println(ui.MutedStyle().Render(
+		"  → Check your whitelist and the safety budgets in config.json."))
+}
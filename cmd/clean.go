@@ -1,19 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/clean"
 	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/container"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
 	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
 )
 
@@ -31,7 +37,16 @@ func init() {
 	cleanCmd.Flags().Bool("user", false, "Clean user caches only")
 	cleanCmd.Flags().Bool("system", false, "Clean system caches only (requires admin)")
 	cleanCmd.Flags().Bool("browser", false, "Clean browser caches only")
+	cleanCmd.Flags().Bool("electron", false, "Clean Electron app caches only (Teams, Slack, Discord, …)")
 	cleanCmd.Flags().Bool("dev", false, "Clean developer tool caches only")
+	cleanCmd.Flags().String("profile", "", "Clean the categories of a named profile (safe, standard)")
+	addPlanFlags(cleanCmd)
+	addDeepFlag(cleanCmd)
+	addShredFlags(cleanCmd)
+	addGentleFlag(cleanCmd, false)
+	addRunningFlag(cleanCmd)
+	addGoalFlags(cleanCmd)
+	addMaxRiskFlag(cleanCmd)
 }
 
 // ─── Main Entry Point ────────────────────────────────────────────────────────
@@ -53,28 +68,83 @@ func runClean(cmd *cobra.Command, args []string) {
 	// Debug mode.
 	debugMode := debug || cfg.DebugMode
 
-	// Load whitelist.
-	wlPath := filepath.Join(cfg.ConfigDir, "whitelist.txt")
-	wl, wlErr := whitelist.Load(wlPath)
-	if wlErr != nil {
-		// Only warn if the error is not "file not exists" (no whitelist configured is fine).
-		if !errors.Is(wlErr, os.ErrNotExist) {
-			fmt.Println(ui.WarningStyle().Render(
-				fmt.Sprintf("  %s Could not load whitelist: %v", ui.IconWarning, wlErr)))
-		}
-		wl = nil
+	// Saving a plan or predicting failures is a dry run.
+	planOut, _ := cmd.Flags().GetString("plan-out")
+	if planOut != "" || deepDryRun {
+		dryRun = true
 	}
 
-	// Parse category flags.
-	allFlag, _ := cmd.Flags().GetBool("all")
-	userFlag, _ := cmd.Flags().GetBool("user")
-	systemFlag, _ := cmd.Flags().GetBool("system")
-	browserFlag, _ := cmd.Flags().GetBool("browser")
-	devFlag, _ := cmd.Flags().GetBool("dev")
+	// Fleet and admin policies beat local flags and config.
+	fr := loadFleet(cfg)
+	lock := mustLoadAdminLock()
+	dryRun = lock.forceDryRun(fr.forceDryRun(dryRun))
+	limits := mustSafetyLimits(cfg)
+	until, untilErr := pruneUntil(cfg)
+	if untilErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, untilErr)))
+		os.Exit(1)
+	}
+	clean.PruneUntil = until
+	riskCap, riskErr := maxRisk(cmd, cfg, fr)
+	if riskErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, riskErr)))
+		os.Exit(1)
+	}
+	limiter, restorePriority := startGentle(cmd, cfg)
+	defer restorePriority()
 
-	// Default to all if no category specified.
-	if !allFlag && !userFlag && !systemFlag && !browserFlag && !devFlag {
-		allFlag = true
+	// Load whitelist.
+	wl := fr.applyWhitelist(loadWhitelist(cfg))
+
+	// Run a saved plan instead of scanning.
+	if planPath, _ := cmd.Flags().GetString("plan"); planPath != "" {
+		cleanRoots := clean.ExpectedRoots()
+		planRun{
+			command: "clean",
+			path:    planPath,
+			lock:    lock,
+			fleet:   fr,
+			confine: cleanPlanRoot(cfg, cleanRoots),
+			allow: func(item report.Item) bool {
+				return lock.allowCategory(item.Category) && lock.allowTarget(item.Target) &&
+					purewin.RiskWithin(purewin.TargetRisk(item.Target), riskCap) &&
+					(wl == nil || !wl.IsWhitelisted(item.Path))
+			},
+			roots:  append(cleanRoots, goalRoots(cfg)...),
+			limits: limits,
+			pace:   pacer(limiter),
+			shred: func(categoryOf map[string]string) *purewin.ShredOptions {
+				return shredOptions(cmd, cfg, categoryOf)
+			},
+			trackCategories: true,
+		}.run(cfg)
+		return
+	}
+
+	// Resolve which categories to scan from --profile and category flags.
+	scope, profileName, scopeErr := cleanScopeFromFlags(cmd, fr)
+	if scopeErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, scopeErr)))
+		os.Exit(1)
+	}
+	scope, scopeErr = lock.restrictScope(cmd, scope)
+	if scopeErr != nil {
+		printRefusal(scopeErr)
+	}
+	ifRunning, runningErr := runningMode(cmd)
+	if runningErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, runningErr)))
+		os.Exit(1)
+	}
+	target, hasGoal, goalErr := goalFromFlags(cmd)
+	if goalErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, goalErr)))
+		os.Exit(1)
 	}
 
 	isAdmin := core.IsElevated()
@@ -87,135 +157,110 @@ func runClean(cmd *cobra.Command, args []string) {
 		fmt.Println(ui.WarningStyle().Render(
 			fmt.Sprintf("  %s  DRY RUN MODE — no files will be deleted", ui.IconWarning)))
 	}
-	if !isAdmin && (allFlag || systemFlag) {
+	if !isAdmin && scope.system {
 		fmt.Println(ui.WarningStyle().Render(
 			fmt.Sprintf("  %s  Not running as admin — system items will be skipped", ui.IconWarning)))
 	}
 	fmt.Println()
 
+	// ── Hooks ────────────────────────────────────────────────────────────
+	hs := newCmdSession(cfg, "clean")
+	defer hs.close()
+	hs.useFleet(fr, profileName)
+	hs.mustRun(hooks.PreScan, nil)
+
 	// ── Scan Phase ───────────────────────────────────────────────────────
-	spinner := ui.NewInlineSpinner()
-	spinner.Start("Scanning for cleanable files...")
-
-	var allResults []clean.ScanResult
-
-	// User caches: use config targets via ScanAll.
-	if allFlag || userFlag {
-		userTargets := config.GetTargetsByCategory("user")
-		userResults := clean.ScanAll(userTargets, wl, isAdmin)
-		allResults = append(allResults, userResults...)
-
-		// Scan non-system drives (D:, E:, etc.) for temp/junk files.
-		driveItems := clean.ScanNonSystemDrives(wl)
-		if len(driveItems) > 0 {
-			driveGroups := groupItemsByDescription(driveItems)
-			for name, items := range driveGroups {
-				allResults = append(allResults, clean.ItemsToResult(name, items))
-			}
-		}
+	scanOpts := purewin.ScanOptions{
+		Categories:       scope.categories(),
+		Whitelist:        wl,
+		SkipAdminTargets: !isAdmin,
+		MaxRisk:          riskCap,
+		ExcludeTargets:   lock.excludedTargets(),
+		Extras:           true,
+		SkipRunning:      true,
+		Pace:             pacer(limiter),
 	}
-
-	// Browser caches: use specialized multi-profile scanner.
-	if allFlag || browserFlag {
-		browserItems := clean.ScanBrowserCaches(wl)
-		if len(browserItems) > 0 {
-			browserGroups := groupItemsByDescription(browserItems)
-			for name, items := range browserGroups {
-				allResults = append(allResults, clean.ItemsToResult(name, items))
-			}
-		}
+	// A dry run only reports what running apps would keep from cleaning.
+	if !dryRun {
+		settleRunning(ifRunning, scanOpts)
 	}
 
-	// Developer caches: use specialized scanner for safety.
-	if allFlag || devFlag {
-		devItems := clean.ScanDevCaches(wl)
-		if len(devItems) > 0 {
-			devGroups := groupItemsByDescription(devItems)
-			for name, items := range devGroups {
-				allResults = append(allResults, clean.ItemsToResult(name, items))
-			}
+	var scan *purewin.ScanResult
+	if hasGoal {
+		if scan = scanGoal(cfg, target, scanOpts); scan == nil {
+			hs.finish(dryRun, 0, 0, 0)
+			return
 		}
-	}
-
-	// System caches: use config targets via ScanAll (admin-gated).
-	if allFlag || systemFlag {
-		systemTargets := config.GetTargetsByCategory("system")
-		systemResults := clean.ScanAll(systemTargets, wl, isAdmin)
-		allResults = append(allResults, systemResults...)
-
-		// Memory dumps (separate scan).
-		dumpItems := clean.ScanMemoryDumps()
-		if len(dumpItems) > 0 {
-			allResults = append(allResults, clean.ItemsToResult("MemoryDumps", dumpItems))
+	} else {
+		spinner := ui.NewInlineSpinner()
+		spinner.Start("Scanning for cleanable files...")
+		scanOpts.Progress = func(files, bytes int64) {
+			spinner.UpdateMessage(fmt.Sprintf("Scanning for cleanable files... %s in %d files",
+				core.FormatSize(bytes), files))
 		}
 
-		// WER user-level reports (no admin needed).
-		werItems := clean.ScanWERUserReports(wl)
-		if len(werItems) > 0 {
-			allResults = append(allResults, clean.ItemsToResult("WER User Reports", werItems))
+		var scanErr error
+		scan, scanErr = purewin.Scan(context.Background(), scanOpts)
+		if scanErr != nil {
+			spinner.StopWithError(fmt.Sprintf("Scan failed: %v", scanErr))
+			os.Exit(1)
 		}
+		spinner.Stop("Scan complete")
 	}
 
-	// Recycle Bin (user category, via Shell API).
-	var recycleBinSize int64
-	if allFlag || userFlag {
-		recycleBinSize, _ = clean.ScanRecycleBin()
-	}
-
-	// Go module cache size.
-	var goModSize int64
-	if allFlag || devFlag {
-		goModSize = clean.GoModCacheSize()
-	}
+	scan = lock.fitClean(scan)
 
-	// Windows.old size.
-	var windowsOldSize int64
-	if (allFlag || systemFlag) && isAdmin {
-		windowsOldSize = clean.WindowsOldSize()
+	scanItems := reportItems(scan)
+	if dryRun && deepDryRun {
+		scanItems = predictFailures(scanItems)
 	}
 
-	spinner.Stop("Scan complete")
+	_ = hs.run(hooks.PostScan, report.NewPlan("clean", dryRun,
+		cleanPlanItems(scanItems, scan.Extras)))
 
 	// ── Calculate Totals ─────────────────────────────────────────────────
-	totalSize := clean.TotalSizeAll(allResults) + recycleBinSize + goModSize + windowsOldSize
-	totalItems := clean.TotalItemCount(allResults)
+	totalSize := scan.TotalSize + scan.ExtrasSize()
+	totalItems := scan.ItemCount
 
 	if totalSize == 0 {
+		message := "System is clean! Nothing to remove."
+		if len(scan.Busy) > 0 {
+			displayCleanResults(scan)
+			message = "Nothing else to remove."
+		}
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(
-			fmt.Sprintf("  %s  System is clean! Nothing to remove.", ui.IconSuccess)))
+			fmt.Sprintf("  %s  %s", ui.IconSuccess, message)))
 		fmt.Println()
+		hs.finish(dryRun, 0, 0, 0)
 		return
 	}
 
 	// ── Display Results ──────────────────────────────────────────────────
-	displayCleanResults(allResults, recycleBinSize, goModSize, windowsOldSize)
+	displayCleanResults(scan)
 
 	fmt.Println(ui.Divider(55))
 	fmt.Printf("  %-35s %s  %s\n",
 		ui.BoldStyle().Render("Total"),
 		ui.FormatSize(totalSize),
-		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totalItems)),
+		ui.MutedStyle().Render(countLabel(scan.Items())),
 	)
+	if len(scan.AboveRisk) > 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  %d targets above %s risk were left out — raise --max-risk to include them",
+			len(scan.AboveRisk), riskCap)))
+	}
 	fmt.Println()
 
 	// ── Dry Run: Export and Exit ─────────────────────────────────────────
 	if dryRun {
 		drc := core.NewDryRunContext()
-		for _, r := range allResults {
-			for _, item := range r.Items {
-				drc.Add(item.Path, item.Size, item.Category)
+		for _, item := range cleanPlanItems(scanItems, scan.Extras) {
+			drc.AddProbed(item.Path, item.Size, item.Category, item.Predicted, item.ReadOnly)
+			if item.Predicted == "" {
+				hs.categories.add(item.Category, item.Size)
 			}
 		}
-		if recycleBinSize > 0 {
-			drc.Add("Recycle Bin (Shell API)", recycleBinSize, "user")
-		}
-		if goModSize > 0 {
-			drc.Add("Go module cache", goModSize, "dev")
-		}
-		if windowsOldSize > 0 {
-			drc.Add(`C:\Windows.old`, windowsOldSize, "system")
-		}
 
 		drc.PrintSummary()
 
@@ -227,7 +272,27 @@ func runClean(cmd *cobra.Command, args []string) {
 			fmt.Println(ui.MutedStyle().Render(
 				fmt.Sprintf("  Report saved to %s", exportPath)))
 		}
+		if planOut != "" {
+			savePlan(planOut, "clean", scanItems)
+			if len(scan.Extras) > 0 {
+				names := make([]string, 0, len(scan.Extras))
+				for _, e := range scan.Extras {
+					names = append(names, e.Name)
+				}
+				verb := "is"
+				if len(names) > 1 {
+					verb = "are"
+				}
+				fmt.Println(ui.MutedStyle().Render(
+					fmt.Sprintf("  %s %s not part of saved plans", strings.Join(names, ", "), verb)))
+			}
+		}
 		fmt.Println()
+		if deepDryRun {
+			totalSize = drc.TotalSize()
+			_, totalItems = predictedTotals(scanItems)
+		}
+		hs.finish(true, totalSize, totalItems, 0)
 		return
 	}
 
@@ -240,6 +305,8 @@ func runClean(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	hs.mustRun(hooks.PreDelete, nil)
+
 	// ── Initialize Logger ────────────────────────────────────────────────
 	logger, logErr := core.NewLogger(cfg.LogFile)
 	if logErr != nil {
@@ -254,102 +321,108 @@ func runClean(cmd *cobra.Command, args []string) {
 	}
 
 	// ── Execute Cleanup ──────────────────────────────────────────────────
+	itemCategory := make(map[string]string, scan.ItemCount)
+	for _, item := range scan.Items() {
+		itemCategory[item.Path] = item.Category
+	}
+
+	// Browser and temp files are overwritten first with --shred.
+	shred := shredOptions(cmd, cfg, itemCategory)
+	startShred(shred, scan.Paths(), logger)
+
 	cleanSpinner := ui.NewInlineSpinner()
 	cleanSpinner.Start("Cleaning...")
 
-	var totalFreed int64
-	var totalCleaned int
-	var errCount int
-
 	// Delete all scanned items via SafeDelete.
-	for _, r := range allResults {
-		for _, item := range r.Items {
-			cleanSpinner.UpdateMessage(
-				fmt.Sprintf("Cleaning %s...", filepath.Base(item.Path)))
-
-			freed, delErr := core.SafeDelete(item.Path, false)
-			if delErr != nil {
-				errCount++
-				if debugMode {
-					fmt.Printf("\n  %s %v\n", ui.IconError, delErr)
-				}
-				if logger != nil {
-					logger.Log("DELETE", item.Path, 0, delErr)
-				}
-				continue
-			}
-
-			totalFreed += freed
-			totalCleaned++
-			if logger != nil {
-				logger.Log("DELETE", item.Path, freed, nil)
-			}
+	planned := reportItems(scan)
+	breakerRoots := clean.ExpectedRoots()
+	if hasGoal {
+		breakerRoots = append(breakerRoots, goalRoots(cfg)...)
+	}
+	rb := newRunBreaker(limits, breakerRoots, planned, ui.IsTerminal(), logger)
+	if rb != nil {
+		rb.pause = func() { cleanSpinner.Stop("Paused by circuit breaker") }
+		rb.resume = func() {
+			cleanSpinner = ui.NewInlineSpinner()
+			cleanSpinner.Start("Cleaning...")
 		}
 	}
-
-	// Empty Recycle Bin.
-	if recycleBinSize > 0 {
-		cleanSpinner.UpdateMessage("Emptying Recycle Bin...")
-		if rbErr := clean.EmptyRecycleBin(false); rbErr != nil {
-			errCount++
-			if logger != nil {
-				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", 0, rbErr)
+	deleted, stopErr := purewin.DeleteItems(context.Background(), scan.Items(), purewin.DeleteOptions{
+		Before: rb.before,
+		Shred:  shred,
+		Pace:   pacer(limiter),
+		OnItem: func(r purewin.ItemResult) {
+			cleanSpinner.UpdateMessage(
+				fmt.Sprintf("Cleaning %s...", filepath.Base(r.Path)))
+			if r.Err != nil && debugMode {
+				fmt.Printf("\n  %s %v\n", ui.IconError, r.Err)
 			}
-		} else {
-			totalFreed += recycleBinSize
-			totalCleaned++
-			if logger != nil {
-				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", recycleBinSize, nil)
+			if r.Err == nil {
+				hs.categories.add(itemCategory[r.Path], r.Freed)
+				rb.record(r.Path, r.Freed)
 			}
-		}
-	}
-
-	// Go module cache.
-	if goModSize > 0 {
-		cleanSpinner.UpdateMessage("Cleaning Go module cache...")
-		freed, goErr := clean.CleanGoModCache(false)
-		if goErr != nil {
-			errCount++
 			if logger != nil {
-				logger.Log("GO_CLEAN_MODCACHE", "go mod cache", 0, goErr)
+				logger.Log(deleteOp(shred, r.Path), r.Path, r.Freed, r.Err)
 			}
+		},
+	})
+
+	totalFreed := deleted.Freed
+	totalCleaned := deleted.Deleted
+	var skips skipSummary
+	skips.addResults(deleted)
+
+	// Extras clean through their own tools. They count against the same
+	// budgets, each as one item of its estimated size, and a run stopped
+	// by the breaker skips the rest.
+	for _, e := range scan.Extras {
+		if stopErr != nil {
+			break
+		}
+		if stopErr = rb.beforeExtra(e.Name, e.Size); stopErr != nil {
+			break
+		}
+		// Extras whose data cannot be recovered ask for confirmation first.
+		confirm := e.NeedsConfirm()
+		if confirm {
+			cleanSpinner.Stop("Pausing for confirmation...")
 		} else {
-			totalFreed += freed
-			totalCleaned++
-			if logger != nil {
-				logger.Log("GO_CLEAN_MODCACHE", "go mod cache", freed, nil)
-			}
+			cleanSpinner.UpdateMessage(fmt.Sprintf("Cleaning %s...", e.Name))
 		}
-	}
-
-	// Windows.old (requires DangerConfirm inside CleanWindowsOld).
-	if windowsOldSize > 0 {
-		cleanSpinner.Stop("Pausing for confirmation...")
 
-		freed, woErr := clean.CleanWindowsOld(false)
-		if woErr != nil {
-			errCount++
-			if logger != nil {
-				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, 0, woErr)
-			}
+		freed, extraErr := purewin.CleanExtra(context.Background(), e, false, dangerConfirm)
+		if extraErr == nil {
+			rb.recordExtra(freed)
+		}
+		if extraErr != nil {
+			skips.add(e.Name, extraErr)
 		} else if freed > 0 {
 			totalFreed += freed
 			totalCleaned++
-			if logger != nil {
-				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, freed, nil)
-			}
+			hs.categories.add(e.Category, freed)
+		}
+		if logger != nil {
+			logger.Log("CLEAN_EXTRA", e.Name, freed, extraErr)
 		}
 
-		// Restart spinner for remaining work.
-		cleanSpinner = ui.NewInlineSpinner()
-		cleanSpinner.Start("Finishing cleanup...")
+		if confirm {
+			// Restart spinner for remaining work.
+			cleanSpinner = ui.NewInlineSpinner()
+			cleanSpinner.Start("Finishing cleanup...")
+		}
 	}
 
-	cleanSpinner.Stop("Cleanup complete")
+	if stopErr != nil {
+		cleanSpinner.StopWithError("Cleanup stopped")
+		printBreakerStop(stopErr)
+	} else {
+		cleanSpinner.Stop("Cleanup complete")
+	}
 
 	// Log session summary.
+	rb.finish()
 	if logger != nil {
-		logger.LogSummary(totalFreed, totalCleaned, errCount)
+		logger.LogSummary(totalFreed, totalCleaned, skips.count())
 	}
 
 	// ── Completion Banner ────────────────────────────────────────────────
@@ -365,22 +438,209 @@ func runClean(cmd *cobra.Command, args []string) {
 		fmt.Sprintf("  %s  Freed %s across %d items",
 			ui.IconSuccess, core.FormatSize(totalFreed), totalCleaned)))
 
-	if errCount > 0 {
-		fmt.Println(ui.WarningStyle().Render(
-			fmt.Sprintf("  %s  %d items skipped (locked or access denied)",
-				ui.IconWarning, errCount)))
-	}
+	skips.print("items", debugMode)
+	resolveLocked(cfg, "clean", &skips)
 	fmt.Println()
+
+	hs.finish(false, totalFreed, totalCleaned, skips.count())
+}
+
+// ─── Scan Helpers ────────────────────────────────────────────────────────────
+
+// cleanScope records which high-level categories a clean run covers.
+type cleanScope struct {
+	user     bool
+	browser  bool
+	electron bool
+	dev      bool
+	system   bool
+}
+
+// scopeFromCategories builds a cleanScope from category names.
+func scopeFromCategories(categories []string) cleanScope {
+	var s cleanScope
+	for _, c := range categories {
+		switch c {
+		case "user":
+			s.user = true
+		case "browser":
+			s.browser = true
+		case "electron":
+			s.electron = true
+		case "dev":
+			s.dev = true
+		case "system":
+			s.system = true
+		}
+	}
+	return s
+}
+
+// cleanScopeFromFlags resolves the clean scope and profile name from
+// --profile and the category flags. Category flags are additive on top of a
+// profile; with neither, every category is scanned. A fleet policy may
+// restrict both.
+func cleanScopeFromFlags(cmd *cobra.Command, fr *fleetRun) (cleanScope, string, error) {
+	var scope cleanScope
+
+	allFlag, _ := cmd.Flags().GetBool("all")
+	userFlag, _ := cmd.Flags().GetBool("user")
+	systemFlag, _ := cmd.Flags().GetBool("system")
+	browserFlag, _ := cmd.Flags().GetBool("browser")
+	electronFlag, _ := cmd.Flags().GetBool("electron")
+	devFlag, _ := cmd.Flags().GetBool("dev")
+
+	profileName, _ := cmd.Flags().GetString("profile")
+	profileName, err := fr.checkProfile(profileName,
+		allFlag || userFlag || systemFlag || browserFlag || electronFlag || devFlag)
+	if err != nil {
+		return scope, "", err
+	}
+	if profileName != "" {
+		profile, err := purewin.GetProfile(profileName)
+		if err != nil {
+			return scope, "", err
+		}
+		scope = scopeFromCategories(profile.Categories)
+	}
+
+	scope.user = scope.user || userFlag || allFlag
+	scope.browser = scope.browser || browserFlag || allFlag
+	scope.electron = scope.electron || electronFlag || allFlag
+	scope.dev = scope.dev || devFlag || allFlag
+	scope.system = scope.system || systemFlag || allFlag
+
+	// Default to all if no category specified.
+	if scope == (cleanScope{}) {
+		scope = cleanScope{user: true, browser: true, electron: true, dev: true, system: true}
+	}
+	return scope, profileName, nil
+}
+
+// categories returns the category names in scope.
+func (s cleanScope) categories() []string {
+	var cats []string
+	if s.user {
+		cats = append(cats, purewin.CategoryUser)
+	}
+	if s.browser {
+		cats = append(cats, purewin.CategoryBrowser)
+	}
+	if s.electron {
+		cats = append(cats, purewin.CategoryElectron)
+	}
+	if s.dev {
+		cats = append(cats, purewin.CategoryDev)
+	}
+	if s.system {
+		cats = append(cats, purewin.CategorySystem)
+	}
+	return cats
+}
+
+// pruneUntil returns the age filter for container prunes from config:
+// a duration or an RFC 3339 timestamp, defaulting to 24h.
+func pruneUntil(cfg *config.Config) (string, error) {
+	until := cfg.Containers.PruneUntil
+	if until == "" {
+		return container.DefaultUntil, nil
+	}
+	if _, err := time.ParseDuration(until); err == nil {
+		return until, nil
+	}
+	if _, err := time.Parse(time.RFC3339, until); err == nil {
+		return until, nil
+	}
+	return "", fmt.Errorf("invalid containers.prune_until %q (want a duration such as 24h or an RFC 3339 time)", until)
+}
+
+// cleanPlanItems appends the separately sized extras to the scanned items,
+// giving the plan items for the post-scan hook.
+func cleanPlanItems(items []report.Item, extras []purewin.Extra) []report.Item {
+	items = append([]report.Item(nil), items...)
+	for _, e := range extras {
+		items = append(items, report.Item{Path: e.Description, Size: e.Size, Category: e.Category, Target: e.Name})
+	}
+	return items
+}
+
+// countLabel describes how much items hold: their files, or the items
+// themselves when some (project artifacts) were not counted file by file.
+func countLabel(items []purewin.Item) string {
+	files := 0
+	for _, item := range items {
+		if item.Files == 0 {
+			return fmt.Sprintf("(%d items)", len(items))
+		}
+		files += item.Files
+	}
+	return fmt.Sprintf("(%d files)", files)
+}
+
+// reportItems converts scanned items into report items.
+func reportItems(scan *purewin.ScanResult) []report.Item {
+	items := make([]report.Item, 0, scan.ItemCount)
+	for _, item := range scan.Items() {
+		items = append(items, report.Item{
+			Path:     item.Path,
+			Size:     item.Size,
+			Category: item.Category,
+			Target:   item.Target,
+			Root:     item.Root,
+		})
+	}
+	return items
+}
+
+// deletePaths removes paths through the SDK, waiting on pace (if set)
+// before each removal.
+func deletePaths(paths []string, dryRun bool, pace purewin.Pacer) *purewin.DeleteResult {
+	res, _ := purewin.Delete(context.Background(), paths, purewin.DeleteOptions{DryRun: dryRun, Pace: pace})
+	return res
+}
+
+// dangerConfirm asks the user an irreversible-action question, reporting
+// false on any error.
+func dangerConfirm(question string) bool {
+	ok, err := ui.DangerConfirm(question)
+	return err == nil && ok
+}
+
+// loadWhitelist loads the user's whitelist from the config directory.
+// A missing file is not an error; other failures are reported as a warning
+// and cleanup continues without a whitelist.
+func loadWhitelist(cfg *config.Config) *whitelist.Whitelist {
+	wlPath := filepath.Join(cfg.ConfigDir, "whitelist.txt")
+	wl, wlErr := whitelist.Load(wlPath)
+	if wlErr != nil {
+		// Only warn if the error is not "file not exists" (no whitelist configured is fine).
+		if !errors.Is(wlErr, os.ErrNotExist) {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s Could not load whitelist: %v", ui.IconWarning, wlErr)))
+		}
+		return nil
+	}
+	return wl
 }
 
 // ─── Display Helpers ─────────────────────────────────────────────────────────
 
-// displayCleanResults prints scan results grouped by high-level category.
-func displayCleanResults(
-	results []clean.ScanResult,
-	recycleBinSize, goModSize, windowsOldSize int64,
-) {
-	groups := clean.GroupByCategory(results)
+// displayCleanResults prints scan results grouped by high-level category,
+// each category's targets followed by its extras and then the targets
+// skipped because their application is running.
+func displayCleanResults(scan *purewin.ScanResult) {
+	groups := make(map[string][]purewin.Target)
+	for _, t := range scan.Targets {
+		groups[t.Category] = append(groups[t.Category], t)
+	}
+	extras := make(map[string][]purewin.Extra)
+	for _, e := range scan.Extras {
+		extras[e.Category] = append(extras[e.Category], e)
+	}
+	busy := make(map[string][]purewin.Busy)
+	for _, b := range scan.Busy {
+		busy[b.Category] = append(busy[b.Category], b)
+	}
 
 	type categoryDef struct {
 		key   string
@@ -390,6 +650,7 @@ func displayCleanResults(
 	categories := []categoryDef{
 		{"user", "User Caches"},
 		{"browser", "Browser Caches"},
+		{"electron", "App Caches"},
 		{"dev", "Developer Tools"},
 		{"system", "System"},
 	}
@@ -397,84 +658,49 @@ func displayCleanResults(
 	fmt.Println()
 
 	for _, cat := range categories {
-		groupResults, hasGroup := groups[cat.key]
-
-		// Check if this category has extra line items to show.
-		hasExtra := false
-		switch cat.key {
-		case "user":
-			hasExtra = recycleBinSize > 0
-		case "dev":
-			hasExtra = goModSize > 0 || clean.IsDockerAvailable()
-		case "system":
-			hasExtra = windowsOldSize > 0
-		}
-
-		if !hasGroup && !hasExtra {
+		groupResults := groups[cat.key]
+		groupExtras := extras[cat.key]
+		groupBusy := busy[cat.key]
+		if len(groupResults) == 0 && len(groupExtras) == 0 && len(groupBusy) == 0 {
 			continue
 		}
 
 		// Category header.
 		fmt.Println(ui.SectionHeader(cat.label, 55))
 
-		// Sort results within category for stable output.
-		if hasGroup {
-			sort.Slice(groupResults, func(i, j int) bool {
-				return groupResults[i].Category < groupResults[j].Category
-			})
-
-			for _, r := range groupResults {
-				fmt.Printf("    %-31s  %10s  %s\n",
-					r.Category,
-					ui.FormatSize(r.TotalSize),
-					ui.MutedStyle().Render(fmt.Sprintf("(%d items)", r.ItemCount)),
-				)
-			}
+		// Targets arrive sorted by name within each category.
+		for _, t := range groupResults {
+			fmt.Printf("    %-31s  %10s  %s  %s\n",
+				t.Name,
+				ui.FormatSize(t.TotalSize),
+				ui.RiskTag(t.Risk),
+				ui.MutedStyle().Render(countLabel(t.Items)),
+			)
 		}
 
-		// Extra line items per category.
-		switch cat.key {
-		case "user":
-			if recycleBinSize > 0 {
-				fmt.Printf("    %-31s  %10s\n",
-					"Recycle Bin",
-					ui.FormatSize(recycleBinSize),
-				)
-			}
-		case "dev":
-			if goModSize > 0 {
-				fmt.Printf("    %-31s  %10s  %s\n",
-					"Go module cache",
-					ui.FormatSize(goModSize),
-					ui.MutedStyle().Render("(go clean -modcache)"),
-				)
-			}
-			if clean.IsDockerAvailable() {
-				fmt.Printf("    %-31s  %10s  %s\n",
-					"Docker build cache",
-					ui.MutedStyle().Render("   ?"),
-					ui.MutedStyle().Render("(docker builder prune)"),
-				)
-			}
-		case "system":
-			if windowsOldSize > 0 {
-				fmt.Printf("    %-31s  %10s  %s\n",
-					"Windows.old",
-					ui.FormatSize(windowsOldSize),
-					ui.WarningStyle().Render("(requires confirmation)"),
-				)
+		// Extras show what cleans them; high-risk ones are highlighted.
+		for _, e := range groupExtras {
+			note := ui.MutedStyle().Render(e.Description)
+			if e.Risk == purewin.RiskHigh {
+				note = ui.WarningStyle().Render(e.Description)
 			}
+			fmt.Printf("    %-31s  %10s  %s  %s\n",
+				e.Name,
+				ui.FormatSize(e.Size),
+				ui.RiskTag(e.Risk),
+				note,
+			)
 		}
 
-		fmt.Println()
-	}
-}
+		// Targets of running apps were not scanned.
+		for _, b := range groupBusy {
+			fmt.Printf("    %-31s  %10s  %s\n",
+				b.Name,
+				"skipped",
+				ui.WarningStyle().Render(b.Reason()),
+			)
+		}
 
-// groupItemsByDescription groups CleanItems by their Description field.
-func groupItemsByDescription(items []clean.CleanItem) map[string][]clean.CleanItem {
-	groups := make(map[string][]clean.CleanItem)
-	for _, item := range items {
-		groups[item.Description] = append(groups[item.Description], item)
+		fmt.Println()
 	}
-	return groups
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Deep Dry Run ────────────────────────────────────────────────────────────
+
+// addDeepFlag registers --deep on a deleting command.
+func addDeepFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&deepDryRun, "deep", false,
+		"Predict locked and access-denied items by test-opening them (implies --dry-run)")
+}
+
+// predictFailures runs a deep dry run over items, marking each with the
+// failure deleting it would hit and whether it holds read-only files.
+// Sizes are re-measured, so predicted successes count only what would
+// really be freed.
+func predictFailures(items []report.Item) []report.Item {
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Checking that each item can be deleted...")
+
+	targets := make([]purewin.Item, len(items))
+	for i, it := range items {
+		targets[i] = purewin.Item{Path: it.Path, Root: it.Root}
+	}
+	out := make([]report.Item, len(items))
+	copy(out, items)
+	i := 0
+	_, _ = purewin.DeleteItems(context.Background(), targets, purewin.DeleteOptions{
+		DryRun:     true,
+		DeepDryRun: true,
+		OnItem: func(r purewin.ItemResult) {
+			out[i].ReadOnly = r.ReadOnly
+			if r.Err != nil {
+				out[i].Predicted = core.SkipReason(r.Err)
+			} else {
+				out[i].Size = r.Freed
+			}
+			i++
+		},
+	})
+
+	spinner.Stop("Check complete")
+	return out
+}
+
+// predictedTotals sums the items a deep dry run expects to delete.
+func predictedTotals(items []report.Item) (freed int64, count int) {
+	for _, it := range items {
+		if it.Predicted == "" {
+			freed += it.Size
+			count++
+		}
+	}
+	return freed, count
+}
+
+// printPredictions reports the items a deep dry run expects to fail, by
+// reason, and how many hold read-only files.
+func printPredictions(items []report.Item, noun string, verbose bool) {
+	skips := skipSummary{predicted: true}
+	readOnly := 0
+	for _, it := range items {
+		if it.Predicted != "" {
+			skips.addReason(it.Path, it.Predicted)
+		}
+		if it.ReadOnly {
+			readOnly++
+		}
+	}
+	skips.print(noun, verbose)
+	if readOnly > 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  %d %s contain read-only files; the attribute is cleared before deleting.", readOnly, noun)))
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/fleet"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// fleetPolicyCache is the local copy of the fleet policy, used when the
+// share is unreachable.
+const fleetPolicyCache = "fleet-policy.json"
+
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Work with fleet policy reports",
+	Long: `Commands for machines managed by a shared fleet policy.
+
+A machine joins a fleet by setting fleet.policy in config.json to a policy
+file on a share (or local path). The policy can restrict profiles, force
+whitelist entries, cap the risk level and require dry-run. Each clean or
+guard run then drops a JSON report into the policy's reports directory.`,
+}
+
+var fleetSummarizeCmd = &cobra.Command{
+	Use:   "summarize <dir>",
+	Short: "Aggregate fleet reports into per-machine and per-category totals",
+	Example: `  pw fleet summarize \\fileserver\purewin\reports
+  pw fleet summarize D:\reports --key-file fleet.key --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runFleetSummarize,
+}
+
+func init() {
+	fleetSummarizeCmd.Flags().String("key-file", "", "Only count reports signed with this key")
+	fleetSummarizeCmd.Flags().Bool("json", false, "Output the summary as JSON")
+	fleetCmd.AddCommand(fleetSummarizeCmd)
+}
+
+// ─── pw fleet summarize ──────────────────────────────────────────────────────
+
+func runFleetSummarize(cmd *cobra.Command, args []string) {
+	keyFile, _ := cmd.Flags().GetString("key-file")
+	jsonMode, _ := cmd.Flags().GetBool("json")
+
+	var key []byte
+	if keyFile != "" {
+		k, err := fleet.LoadKey(keyFile)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		key = k
+	}
+
+	sum, err := fleet.Summarize(args[0], key)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if jsonMode {
+		data, _ := json.MarshalIndent(sum, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Fleet Summary", 55))
+	fmt.Printf("  %d reports from %d machines, %s freed\n",
+		sum.Reports, len(sum.Machines), ui.FormatSize(sum.Freed))
+	fmt.Println()
+
+	if len(sum.Machines) > 0 {
+		fmt.Println(ui.SectionHeader("Machines", 55))
+		for _, m := range sum.Machines {
+			fmt.Printf("    %-24s  %10s  %s\n",
+				m.Hostname,
+				ui.FormatSize(m.Freed),
+				ui.MutedStyle().Render(fmt.Sprintf("(%d runs, %d dry, %d errors, last %s)",
+					m.Runs, m.DryRuns, m.Errors, m.LastRun.Local().Format("2006-01-02 15:04"))),
+			)
+		}
+		fmt.Println()
+	}
+
+	if len(sum.Categories) > 0 {
+		fmt.Println(ui.SectionHeader("Categories", 55))
+		for _, c := range sum.Categories {
+			fmt.Printf("    %-24s  %10s  %s\n",
+				c.Category,
+				ui.FormatSize(c.Freed),
+				ui.MutedStyle().Render(fmt.Sprintf("(%d items on %d machines)", c.Items, c.Machines)),
+			)
+		}
+		fmt.Println()
+	}
+
+	if len(sum.Rejected) > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  %d reports not counted:", ui.IconWarning, len(sum.Rejected))))
+		for _, r := range sum.Rejected {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    %s: %s", r.File, r.Reason)))
+		}
+		fmt.Println()
+	}
+}
+
+// ─── Policy Enforcement ──────────────────────────────────────────────────────
+
+// fleetRun is the fleet policy in effect for this run.
+type fleetRun struct {
+	policy     *fleet.Policy
+	reportsDir string
+	key        []byte
+}
+
+// loadFleet loads the fleet policy named in config. It returns nil when no
+// policy is configured. An unreadable policy with no cached copy, or an
+// unreadable signing key, stops the command: a managed machine must not
+// fall back to running unrestricted.
+func loadFleet(cfg *config.Config) *fleetRun {
+	if cfg.Fleet.Policy == "" {
+		return nil
+	}
+
+	cachePath := ""
+	if cfg.ConfigDir != "" {
+		cachePath = filepath.Join(cfg.ConfigDir, fleetPolicyCache)
+	}
+	policy, fromCache, err := fleet.LoadPolicyCached(cfg.Fleet.Policy, cachePath)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if fromCache {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  Fleet policy unreachable — using cached copy", ui.IconWarning)))
+	}
+
+	f := &fleetRun{policy: policy, reportsDir: policy.ReportsDir}
+	if cfg.Fleet.ReportsDir != "" {
+		f.reportsDir = cfg.Fleet.ReportsDir
+	}
+	if cfg.Fleet.KeyFile != "" {
+		key, err := fleet.LoadKey(cfg.Fleet.KeyFile)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		f.key = key
+	}
+	return f
+}
+
+// name returns the policy name for display and reports.
+func (f *fleetRun) name() string {
+	if f.policy.Name != "" {
+		return f.policy.Name
+	}
+	return "fleet policy"
+}
+
+// maxRisk returns the policy's risk cap, or "" with no policy.
+func (f *fleetRun) maxRisk() string {
+	if f == nil {
+		return ""
+	}
+	return f.policy.MaxRisk
+}
+
+// checkProfile validates the requested profile against the policy. With
+// allowed profiles set, category flags are refused and no profile selects
+// the first allowed one. It returns the profile to use.
+func (f *fleetRun) checkProfile(profile string, categoryFlags bool) (string, error) {
+	if f == nil || len(f.policy.AllowedProfiles) == 0 {
+		return profile, nil
+	}
+	allowed := strings.Join(f.policy.AllowedProfiles, ", ")
+	if categoryFlags {
+		return "", fmt.Errorf("%s only allows profiles (%s); category flags are disabled", f.name(), allowed)
+	}
+	if profile == "" {
+		return f.policy.AllowedProfiles[0], nil
+	}
+	if !f.policy.AllowsProfile(profile) {
+		return "", fmt.Errorf("%s does not allow profile %q (allowed: %s)", f.name(), profile, allowed)
+	}
+	return profile, nil
+}
+
+// applyWhitelist adds the policy's forced patterns to wl, creating an
+// in-memory whitelist if wl is nil. The user's whitelist file is not
+// modified.
+func (f *fleetRun) applyWhitelist(wl *whitelist.Whitelist) *whitelist.Whitelist {
+	if f == nil || len(f.policy.ForcedWhitelist) == 0 {
+		return wl
+	}
+	if wl == nil {
+		wl = whitelist.New()
+	}
+	for _, pattern := range f.policy.ForcedWhitelist {
+		if err := wl.Add(pattern); err != nil && !strings.Contains(err.Error(), "already exists") {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s  Ignoring forced whitelist entry: %v", ui.IconWarning, err)))
+		}
+	}
+	return wl
+}
+
+// forceDryRun reports whether the policy requires dry-run, printing a
+// notice when it overrides a real run.
+func (f *fleetRun) forceDryRun(dryRun bool) bool {
+	if f == nil || !f.policy.RequireDryRun {
+		return dryRun
+	}
+	if !dryRun {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  %s requires dry-run — nothing will be deleted", ui.IconWarning, f.name())))
+	}
+	return true
+}
+
+// writeReport drops a (signed, if a key is configured) report for the
+// session into the reports directory.
+func (f *fleetRun) writeReport(profile string, s report.Session) error {
+	if f.reportsDir == "" {
+		return nil
+	}
+	r := fleet.NewReport(f.policy.Name, profile, s)
+	if f.key != nil {
+		if err := r.Sign(f.key); err != nil {
+			return err
+		}
+	}
+	_, err := fleet.WriteReport(f.reportsDir, r)
+	return err
+}
+
+// ─── Category Totals ─────────────────────────────────────────────────────────
+
+// categoryTotals accumulates freed bytes per clean category for the
+// session report.
+type categoryTotals map[string]report.CategoryTotal
+
+// add records one freed item in category.
+func (c categoryTotals) add(category string, freed int64) {
+	t := c[category]
+	t.Freed += freed
+	t.Items++
+	c[category] = t
+}
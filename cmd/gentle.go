@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/throttle"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Gentle Mode ─────────────────────────────────────────────────────────────
+
+// addGentleFlag registers --gentle on a command. byDefault documents
+// whether the command is gentle unless told otherwise.
+func addGentleFlag(cmd *cobra.Command, byDefault bool) {
+	cmd.Flags().Bool("gentle", byDefault,
+		"Run at background priority and cap files and bytes per second (see gentle in config)")
+}
+
+// gentleLimits parses the gentle-mode rate caps from config, filling in the
+// defaults for unset ones.
+func gentleLimits(cfg *config.Config) (throttle.Limits, error) {
+	lim := throttle.Limits{
+		FilesPerSec: cfg.Gentle.FilesPerSec,
+		BytesPerSec: throttle.DefaultBytesPerSec,
+	}
+	if lim.FilesPerSec < 0 {
+		return lim, fmt.Errorf("invalid gentle.files_per_sec %d", lim.FilesPerSec)
+	}
+	if lim.FilesPerSec == 0 {
+		lim.FilesPerSec = throttle.DefaultFilesPerSec
+	}
+	if s := cfg.Gentle.BytesPerSec; s != "" {
+		n, err := parseSize(s)
+		if err != nil || n <= 0 {
+			return lim, fmt.Errorf("invalid gentle.bytes_per_sec %q", s)
+		}
+		lim.BytesPerSec = n
+	}
+	return lim, nil
+}
+
+// startGentle enters gentle mode when --gentle (or gentle.always in config,
+// or the command's own default) asks for it: it lowers the process's CPU
+// and IO priority and returns the rate limiter to pace deletes and stats
+// with, plus a function restoring normal priority. Outside gentle mode the
+// limiter is nil and the function does nothing. A bad config exits.
+func startGentle(cmd *cobra.Command, cfg *config.Config) (*throttle.Limiter, func()) {
+	on, _ := cmd.Flags().GetBool("gentle")
+	if !cmd.Flags().Changed("gentle") && cfg.Gentle.Always {
+		on = true
+	}
+	if !on {
+		return nil, func() {}
+	}
+
+	lim, err := gentleLimits(cfg)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	restore, err := throttle.Background()
+	if err != nil && (debug || cfg.DebugMode) {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Could not lower priority: %v", ui.IconWarning, err)))
+	}
+	return throttle.New(lim), restore
+}
+
+// pacer adapts a limiter to a Pace option, keeping a nil limiter nil.
+func pacer(l *throttle.Limiter) purewin.Pacer {
+	if l == nil {
+		return nil
+	}
+	return l
+}
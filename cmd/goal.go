@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/guard"
+	"github.com/lakshaymaurya-felt/purewin/internal/installer"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Free-Space Goals ────────────────────────────────────────────────────────
+
+// freeGoal is a --target-free request: clean until drive has target bytes
+// free.
+type freeGoal struct {
+	drive  string
+	target int64
+}
+
+// addGoalFlags registers --target-free and --drive on clean.
+func addGoalFlags(cmd *cobra.Command) {
+	cmd.Flags().String("target-free", "", "Clean only as much as it takes to reach this much free space (e.g. 30GB)")
+	cmd.Flags().String("drive", "", "Drive for --target-free (default: the system drive)")
+	cmd.MarkFlagsMutuallyExclusive("target-free", "plan")
+}
+
+// goalFromFlags reads --target-free and --drive. ok is false when no goal
+// was asked for.
+func goalFromFlags(cmd *cobra.Command) (g freeGoal, ok bool, err error) {
+	s, _ := cmd.Flags().GetString("target-free")
+	if s == "" {
+		if cmd.Flags().Changed("drive") {
+			return g, false, fmt.Errorf("--drive only applies with --target-free")
+		}
+		return g, false, nil
+	}
+	target, err := parseSize(s)
+	if err != nil || target <= 0 {
+		return g, false, fmt.Errorf("invalid --target-free %q (want a size such as 30GB)", s)
+	}
+
+	drive, _ := cmd.Flags().GetString("drive")
+	if drive == "" {
+		drive = os.Getenv("SystemDrive")
+		if drive == "" {
+			drive = "C:"
+		}
+	}
+	return freeGoal{drive: guard.DriveRoot(drive), target: target}, true, nil
+}
+
+// scanGoal proposes the items to clean for g, printing the drive's free
+// space and how far the proposal gets. opts.MaxRisk caps the candidates
+// like any scan, and project artifacts are searched for under the
+// configured purge paths. It returns nil when the drive already has the space.
+func scanGoal(cfg *config.Config, g freeGoal, opts purewin.ScanOptions) *purewin.ScanResult {
+	free, _, err := guard.FreeSpace(g.drive)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	need := g.target - int64(free)
+	if need <= 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s  %s already has %s free (goal: %s). Nothing to remove.",
+			ui.IconSuccess, g.drive, core.FormatSize(int64(free)), core.FormatSize(g.target))))
+		fmt.Println()
+		return nil
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %s has %s free — looking for %s to reach %s",
+		g.drive, core.FormatSize(int64(free)), core.FormatSize(need), core.FormatSize(g.target))))
+	fmt.Println()
+
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Ranking cleanable files...")
+	opts.Progress = func(files, bytes int64) {
+		spinner.UpdateMessage(fmt.Sprintf("Ranking cleanable files... %s in %d files",
+			core.FormatSize(bytes), files))
+	}
+	res, err := purewin.PlanGoal(context.Background(), purewin.GoalOptions{
+		Scan:         opts,
+		Drive:        g.drive,
+		Need:         need,
+		ProjectPaths: getScanPaths(cfg),
+	})
+	if err != nil {
+		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
+		os.Exit(1)
+	}
+	spinner.Stop(fmt.Sprintf("Proposal frees %s", core.FormatSize(res.Size())))
+
+	if !res.Reached {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  Everything allowed frees only %s — %s short of the goal",
+			ui.IconWarning, core.FormatSize(res.Size()), core.FormatSize(need-res.Size()))))
+	}
+	return res.Proposal
+}
+
+// goalRoots returns the directories --target-free searches for project
+// artifacts and installers: the configured purge paths and the installer
+// sources. They lie outside the clean targets' own roots, so the circuit
+// breaker is told to expect them.
+func goalRoots(cfg *config.Config) []string {
+	return append(getScanPaths(cfg), installer.SourceDirs()...)
+}
+
+// cleanPlanRoot returns the confine func for a clean plan. An item is
+// confined to the clean target root it lies under, or, for the project
+// artifacts and installers of a --target-free proposal, to the project or
+// installer source directory it is found in on disk.
+func cleanPlanRoot(cfg *config.Config, cleanRoots []string) func(report.Item) (string, bool) {
+	scanPaths, sources := getScanPaths(cfg), installer.SourceDirs()
+	return func(item report.Item) (string, bool) {
+		switch item.Target {
+		case purewin.ArtifactsTarget:
+			return artifactRoot(item.Path, scanPaths)
+		case purewin.InstallersTarget:
+			return rootFor(item.Path, sources)
+		}
+		return rootFor(item.Path, cleanRoots)
+	}
+}
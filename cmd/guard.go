@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/adminpolicy"
+	"github.com/lakshaymaurya-felt/purewin/internal/budget"
+	"github.com/lakshaymaurya-felt/purewin/internal/clean"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/guard"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+const (
+	// defaultGuardProfile is the clean profile used when none is configured.
+	defaultGuardProfile = "safe"
+
+	// defaultGuardInterval is the re-check interval when none is configured.
+	defaultGuardInterval = 5 * time.Minute
+)
+
+var guardCmd = &cobra.Command{
+	Use:   "guard",
+	Short: "Clean automatically when free space runs low",
+	Long: `Watch free space on one or more drives and run a clean profile
+non-interactively whenever a drive drops below the threshold, until the
+target free space is restored or the per-run byte budget is spent.
+
+Runs until interrupted, or checks once with --once (suitable for a
+scheduled task). Defaults can be set in the "guard" section of config.json.`,
+	Example: `  pw guard --threshold 10GB --profile safe
+  pw guard --threshold 20GB --target 40GB --drive C: --drive D: --once
+  pw guard --threshold 10GB --max-bytes 5GB --interval 15m`,
+	Run: runGuard,
+}
+
+func init() {
+	guardCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be cleaned without deleting")
+	guardCmd.Flags().String("threshold", "", "Trigger cleanup when free space drops below this size (e.g., 10GB)")
+	guardCmd.Flags().String("target", "", "Free space to restore once triggered (default: twice the threshold)")
+	guardCmd.Flags().String("profile", "", "Clean profile to run (safe, standard)")
+	addMaxRiskFlag(guardCmd)
+	guardCmd.Flags().StringSlice("drive", nil, "Drive to watch (repeatable; default: system drive)")
+	guardCmd.Flags().Duration("interval", 0, "How often to re-check free space (default 5m)")
+	guardCmd.Flags().Bool("once", false, "Check once and exit (for scheduled tasks)")
+	guardCmd.Flags().String("max-bytes", "", "Maximum bytes to delete per triggered cleanup (e.g., 5GB)")
+	addGentleFlag(guardCmd, true)
+}
+
+// guardSettings is the resolved configuration for a guard session.
+type guardSettings struct {
+	drives    []string
+	threshold int64
+	target    int64
+	budget    int64
+	interval  time.Duration
+	profile   purewin.Profile
+	maxRisk   string
+	once      bool
+	fleet     *fleetRun
+	lock      *adminLock
+	safety    budget.Limits
+	pace      purewin.Pacer
+}
+
+// ─── Main Entry Point ────────────────────────────────────────────────────────
+
+func runGuard(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if !cmd.Flags().Changed("dry-run") && cfg.DryRunMode {
+		dryRun = true
+	}
+
+	settings, err := resolveGuardSettings(cmd, cfg.Guard)
+	if err == nil {
+		settings.fleet = loadFleet(cfg)
+		_, err = settings.fleet.checkProfile(settings.profile.Name, false)
+	}
+	if err == nil {
+		settings.maxRisk, err = maxRisk(cmd, cfg, settings.fleet)
+	}
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	settings.lock = mustLoadAdminLock()
+	settings.safety = mustSafetyLimits(cfg)
+
+	// Nobody is at the keyboard for a guard run, so stay out of the way.
+	limiter, restorePriority := startGentle(cmd, cfg)
+	defer restorePriority()
+	settings.pace = pacer(limiter)
+	dryRun = settings.lock.forceDryRun(settings.fleet.forceDryRun(dryRun))
+	settings.budget = settings.lock.capBudget(settings.budget)
+	settings.profile.Categories = settings.lock.allowCategories(settings.profile.Categories)
+	if len(settings.profile.Categories) == 0 {
+		printRefusal(&adminpolicy.Refusal{
+			Source: settings.lock.policy.Source,
+			What:   fmt.Sprintf("Every category of the %s profile", settings.profile.Name),
+		})
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Disk Guard", 55))
+	if dryRun {
+		fmt.Println(ui.WarningStyle().Render("  DRY RUN MODE — no files will be deleted"))
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Drives: %s  Threshold: %s  Target: %s  Profile: %s  Max risk: %s",
+		strings.Join(settings.drives, ", "),
+		core.FormatSize(settings.threshold),
+		core.FormatSize(settings.target),
+		settings.profile.Name,
+		settings.maxRisk)))
+	if settings.budget > 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  Budget: %s per run", core.FormatSize(settings.budget))))
+	}
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for {
+		guardCheck(cfg, settings)
+
+		if settings.once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println(ui.MutedStyle().Render("  Guard stopped."))
+			fmt.Println()
+			return
+		case <-time.After(settings.interval):
+		}
+	}
+}
+
+// resolveGuardSettings merges command-line flags over the config defaults.
+func resolveGuardSettings(cmd *cobra.Command, gc config.GuardConfig) (guardSettings, error) {
+	var s guardSettings
+
+	thresholdStr := gc.Threshold
+	if cmd.Flags().Changed("threshold") {
+		thresholdStr, _ = cmd.Flags().GetString("threshold")
+	}
+	if thresholdStr == "" {
+		return s, fmt.Errorf("--threshold is required (or set guard.threshold in config)")
+	}
+	threshold, err := parseSize(thresholdStr)
+	if err != nil || threshold <= 0 {
+		return s, fmt.Errorf("invalid threshold %q", thresholdStr)
+	}
+	s.threshold = threshold
+
+	targetStr := gc.Target
+	if cmd.Flags().Changed("target") {
+		targetStr, _ = cmd.Flags().GetString("target")
+	}
+	s.target = 2 * threshold
+	if targetStr != "" {
+		target, err := parseSize(targetStr)
+		if err != nil {
+			return s, fmt.Errorf("invalid target %q", targetStr)
+		}
+		if target < threshold {
+			return s, fmt.Errorf("target (%s) must not be below threshold (%s)",
+				core.FormatSize(target), core.FormatSize(threshold))
+		}
+		s.target = target
+	}
+
+	budgetStr := gc.MaxBytesPerRun
+	if cmd.Flags().Changed("max-bytes") {
+		budgetStr, _ = cmd.Flags().GetString("max-bytes")
+	}
+	if budgetStr != "" {
+		budget, err := parseSize(budgetStr)
+		if err != nil {
+			return s, fmt.Errorf("invalid max-bytes %q", budgetStr)
+		}
+		s.budget = budget
+	}
+
+	profileName := gc.Profile
+	if cmd.Flags().Changed("profile") {
+		profileName, _ = cmd.Flags().GetString("profile")
+	}
+	if profileName == "" {
+		profileName = defaultGuardProfile
+	}
+	profile, err := purewin.GetProfile(profileName)
+	if err != nil {
+		return s, err
+	}
+	s.profile = profile
+
+	s.interval = defaultGuardInterval
+	if gc.Interval != "" {
+		d, err := time.ParseDuration(gc.Interval)
+		if err != nil {
+			return s, fmt.Errorf("invalid guard.interval %q in config: %w", gc.Interval, err)
+		}
+		s.interval = d
+	}
+	if cmd.Flags().Changed("interval") {
+		s.interval, _ = cmd.Flags().GetDuration("interval")
+	}
+	if s.interval < time.Second {
+		return s, fmt.Errorf("interval must be at least 1s")
+	}
+
+	drives := gc.Drives
+	if cmd.Flags().Changed("drive") {
+		drives, _ = cmd.Flags().GetStringSlice("drive")
+	}
+	if len(drives) == 0 {
+		systemDrive := os.Getenv("SystemDrive")
+		if systemDrive == "" {
+			systemDrive = "C:"
+		}
+		drives = []string{systemDrive}
+	}
+	for _, d := range drives {
+		s.drives = append(s.drives, guard.DriveRoot(d))
+	}
+
+	s.once, _ = cmd.Flags().GetBool("once")
+	return s, nil
+}
+
+// ─── Check & Reclaim ─────────────────────────────────────────────────────────
+
+// guardCheck reads free space on every watched drive and reclaims space on
+// those below the threshold.
+func guardCheck(cfg *config.Config, s guardSettings) {
+	statuses, err := guard.Check(s.drives, uint64(s.threshold))
+	if err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	}
+
+	stamp := time.Now().Format("15:04:05")
+	for _, st := range statuses {
+		if !st.Below {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  [%s] %s %s free — OK",
+				stamp, st.Drive, core.FormatSize(int64(st.Free)))))
+			continue
+		}
+
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s [%s] %s %s free — below %s, cleaning",
+			ui.IconWarning, stamp, st.Drive,
+			core.FormatSize(int64(st.Free)), core.FormatSize(s.threshold))))
+		guardReclaim(cfg, s, st)
+	}
+}
+
+// guardReclaim runs the configured profile against a single drive,
+// deleting the largest items first until the target is met.
+func guardReclaim(cfg *config.Config, s guardSettings, st guard.DriveStatus) {
+	debugMode := debug || cfg.DebugMode
+	wl := s.fleet.applyWhitelist(loadWhitelist(cfg))
+
+	// A failing pre-hook skips this trigger; the next check tries again.
+	hs := newCmdSession(cfg, "guard")
+	defer hs.close()
+	hs.useFleet(s.fleet, s.profile.Name)
+	if hs.run(hooks.PreScan, nil) != nil {
+		return
+	}
+
+	spinner := ui.NewInlineSpinner()
+	spinner.Start(fmt.Sprintf("Scanning %s profile...", s.profile.Name))
+	scan, scanErr := purewin.Scan(context.Background(), purewin.ScanOptions{
+		Categories:     s.profile.Categories,
+		Whitelist:      wl,
+		MaxRisk:        s.maxRisk,
+		ExcludeTargets: s.lock.excludedTargets(),
+		SkipRunning:    true,
+		Pace:           s.pace,
+	})
+	if scanErr != nil {
+		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", scanErr))
+		return
+	}
+
+	// Only items living on this drive help restore its free space.
+	volume := filepath.VolumeName(st.Drive)
+	var candidates []guard.Candidate
+	itemCategory := make(map[string]string)
+	itemRoot := make(map[string]string)
+	for _, item := range scan.Items() {
+		if strings.EqualFold(filepath.VolumeName(item.Path), volume) {
+			candidates = append(candidates, guard.Candidate{Path: item.Path, Size: item.Size})
+			itemCategory[item.Path] = item.Category
+			itemRoot[item.Path] = item.Root
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Size > candidates[j].Size
+	})
+	spinner.Stop(fmt.Sprintf("Found %d cleanable items on %s", len(candidates), st.Drive))
+
+	planItems := make([]report.Item, 0, len(candidates))
+	for _, c := range candidates {
+		planItems = append(planItems, report.Item{Path: c.Path, Size: c.Size, Category: itemCategory[c.Path]})
+	}
+	_ = hs.run(hooks.PostScan, report.NewPlan("guard", dryRun, planItems))
+
+	if len(candidates) == 0 {
+		hs.finish(dryRun, 0, 0, 0)
+		return
+	}
+
+	if !dryRun && hs.run(hooks.PreDelete, nil) != nil {
+		return
+	}
+
+	var logger *core.Logger
+	if !dryRun {
+		l, logErr := core.NewLogger(cfg.LogFile)
+		if logErr != nil {
+			if debugMode {
+				fmt.Println(ui.WarningStyle().Render(
+					fmt.Sprintf("  %s  Logging unavailable: %v", ui.IconWarning, logErr)))
+			}
+		} else {
+			logger = l
+			defer logger.Close()
+			logger.LogSession(fmt.Sprintf("guard --profile %s (%s)", s.profile.Name, st.Drive))
+			logger.Log("GUARD_TRIGGER", st.Drive, int64(st.Free), nil)
+		}
+	}
+
+	// Nobody is watching a guard run, so the breaker aborts instead of asking.
+	var rb *runBreaker
+	if !dryRun {
+		rb = newRunBreaker(s.safety, clean.ExpectedRoots(), planItems, false, logger)
+	}
+
+	var skips skipSummary
+	reclaimer := &guard.Reclaimer{
+		Target: uint64(s.target),
+		Budget: s.budget,
+		Allow: func(c guard.Candidate) error {
+			return rb.before(c.Path)
+		},
+		Delete: func(path string) (int64, error) {
+			return core.SafeDeleteWith(path, itemRoot[path], dryRun, core.Paced(nil, s.pace))
+		},
+		OnDelete: func(c guard.Candidate, freed int64, delErr error) {
+			if delErr != nil {
+				skips.add(c.Path, delErr)
+				if debugMode {
+					fmt.Printf("  %s %v\n", ui.IconError, delErr)
+				}
+			}
+			if delErr == nil {
+				hs.categories.add(itemCategory[c.Path], freed)
+				rb.record(c.Path, freed)
+			}
+			if logger != nil {
+				logger.Log("DELETE", c.Path, freed, delErr)
+			}
+		},
+	}
+
+	res, err := reclaimer.Run(st.Drive, candidates)
+	rb.finish()
+	if logger != nil {
+		logger.LogSummary(res.Freed, res.Deleted, res.Errors)
+	}
+	hs.finish(dryRun, res.Freed, res.Deleted, res.Errors)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		return
+	}
+
+	verb := "Freed"
+	if dryRun {
+		verb = "Would free"
+	}
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s %s %s (%d items) on %s — %s free now",
+		ui.IconCheck, verb, core.FormatSize(res.Freed), res.Deleted, st.Drive,
+		core.FormatSize(int64(res.FreeAfter)))))
+
+	switch {
+	case res.Stopped != nil:
+		printBreakerStop(res.Stopped)
+	case res.TargetReached:
+	case res.BudgetExhausted || res.Skipped > 0:
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s Per-run budget of %s reached before the %s target",
+			ui.IconWarning, core.FormatSize(s.budget), core.FormatSize(s.target))))
+	case !dryRun:
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s Profile %q ran out of items before the %s target",
+			ui.IconWarning, s.profile.Name, core.FormatSize(s.target))))
+	}
+	skips.print("items", debugMode)
+}
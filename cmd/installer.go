@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -8,9 +9,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
 	"github.com/lakshaymaurya-felt/purewin/internal/installer"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +30,9 @@ func init() {
 	installerCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without deleting")
 	installerCmd.Flags().Int("min-age", 0, "Minimum file age in days")
 	installerCmd.Flags().String("min-size", "", "Minimum file size (e.g., 10MB)")
+	addPlanFlags(installerCmd)
+	addDeepFlag(installerCmd)
+	addGentleFlag(installerCmd, false)
 }
 
 func runInstaller(cmd *cobra.Command, args []string) {
@@ -43,16 +51,63 @@ func runInstaller(cmd *cobra.Command, args []string) {
 		minSize = size
 	}
 
+	// Saving a plan or predicting failures is a dry run.
+	planOut, _ := cmd.Flags().GetString("plan-out")
+	if planOut != "" || deepDryRun {
+		dryRun = true
+	}
+
+	// Hooks are optional; run without them if config can't be loaded.
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = &config.Config{}
+	}
+
+	// Fleet and admin policies beat local flags and config.
+	fr := loadFleet(cfg)
+	lock := mustLoadAdminLock()
+	dryRun = lock.forceDryRun(fr.forceDryRun(dryRun))
+	limiter, restorePriority := startGentle(cmd, cfg)
+	defer restorePriority()
+
+	if planPath, _ := cmd.Flags().GetString("plan"); planPath != "" {
+		sources := installer.SourceDirs()
+		wl := fr.applyWhitelist(loadWhitelist(cfg))
+		planRun{
+			command: "installer",
+			path:    planPath,
+			lock:    lock,
+			fleet:   fr,
+			confine: func(item report.Item) (string, bool) {
+				return rootFor(item.Path, sources)
+			},
+			allow: func(item report.Item) bool {
+				return lock.allowTarget(purewin.InstallersTarget) &&
+					(wl == nil || !wl.IsWhitelisted(item.Path))
+			},
+			pace: pacer(limiter),
+		}.run(cfg)
+		return
+	}
+
 	// Start scanning
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Installer Cleanup", 50))
 	fmt.Println()
 
+	hs := newCmdSession(cfg, "installer")
+	defer hs.close()
+	hs.useFleet(fr, "")
+	hs.mustRun(hooks.PreScan, nil)
+
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Scanning for installer files...")
 
 	// Scan for installers
-	files, err := installer.ScanInstallers(minAge, minSize)
+	files, err := purewin.ScanInstallers(context.Background(), purewin.InstallerScanOptions{
+		MinAge:  time.Duration(minAge) * 24 * time.Hour,
+		MinSize: minSize,
+	})
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
 		os.Exit(1)
@@ -60,6 +115,12 @@ func runInstaller(cmd *cobra.Command, args []string) {
 
 	spinner.Stop(fmt.Sprintf("Found %d installer files", len(files)))
 
+	planItems := make([]report.Item, 0, len(files))
+	for _, f := range files {
+		planItems = append(planItems, report.Item{Path: f.Path, Size: f.Size, Category: f.Source})
+	}
+	_ = hs.run(hooks.PostScan, report.NewPlan("installer", dryRun, planItems))
+
 	if len(files) == 0 {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s No installer files found!", ui.IconCheck)))
@@ -85,7 +146,7 @@ func runInstaller(cmd *cobra.Command, args []string) {
 	}
 
 	// Convert back to installer files
-	selectedFiles := make([]installer.InstallerFile, 0, len(selected))
+	selectedFiles := make([]purewin.Installer, 0, len(selected))
 	for _, item := range selected {
 		// Find the file by path
 		for _, file := range files {
@@ -96,9 +157,14 @@ func runInstaller(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	selectedFiles = fitBudget(lock, selectedFiles, func(f purewin.Installer) int64 { return f.Size })
+
 	// Show summary
 	fmt.Println()
-	totalSize := installer.GetTotalSize(selectedFiles)
+	var totalSize int64
+	for _, file := range selectedFiles {
+		totalSize += file.Size
+	}
 	fmt.Printf("  %s\n", ui.BoldStyle().Render(fmt.Sprintf("Will delete %d files (%s)",
 		len(selectedFiles), core.FormatSize(totalSize))))
 	fmt.Println()
@@ -116,33 +182,69 @@ func runInstaller(cmd *cobra.Command, args []string) {
 			fmt.Println()
 			return
 		}
+		hs.mustRun(hooks.PreDelete, nil)
 	}
 
 	// Delete
 	fmt.Println()
-	freed, count, cleanErr := installer.CleanInstallers(selectedFiles, dryRun)
+	paths := make([]string, 0, len(selectedFiles))
+	for _, file := range selectedFiles {
+		paths = append(paths, file.Path)
+	}
 
+	var freed int64
+	var count int
 	if dryRun {
+		items := make([]report.Item, 0, len(selectedFiles))
+		for _, f := range selectedFiles {
+			items = append(items, report.Item{Path: f.Path, Size: f.Size, Category: f.Source, Target: f.Source})
+		}
+		if deepDryRun {
+			items = predictFailures(items)
+			freed, count = predictedTotals(items)
+		} else {
+			res := deletePaths(paths, true, nil)
+			freed, count = res.Freed, res.Deleted
+		}
+
 		fmt.Println()
 		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] No files were deleted"))
 		fmt.Printf("  Would free: %s from %d files\n", core.FormatSize(freed), count)
+		if deepDryRun {
+			printPredictions(items, "files", debug || cfg.DebugMode)
+		}
+		if planOut != "" {
+			savePlan(planOut, "installer", items)
+		}
 		fmt.Println()
 	} else {
+		res := deletePaths(paths, false, pacer(limiter))
+		freed, count = res.Freed, res.Deleted
+
 		fmt.Println()
-		if cleanErr != nil {
-			fmt.Printf("%s Completed with errors: %v\n", ui.WarningStyle().Render(ui.IconWarning), cleanErr)
+		var skips skipSummary
+		skips.addResults(res)
+		if skips.count() > 0 {
+			fmt.Printf("%s Completed with errors\n", ui.WarningStyle().Render(ui.IconWarning))
 		} else {
 			fmt.Printf("%s Success!\n", ui.SuccessStyle().Render(ui.IconSuccess))
 		}
 		fmt.Printf("  Freed: %s from %d files\n", ui.SuccessStyle().Render(core.FormatSize(freed)), count)
+		skips.print("files", debug || cfg.DebugMode)
+		resolveLocked(cfg, "installer", &skips)
 		fmt.Println()
 	}
+
+	hs.finish(dryRun, freed, count, len(selectedFiles)-count)
 }
 
 // installerFilesToSelectorItems converts installer files to selector items.
-func installerFilesToSelectorItems(files []installer.InstallerFile) []ui.SelectorItem {
+func installerFilesToSelectorItems(files []purewin.Installer) []ui.SelectorItem {
 	// Group by source
-	sourceGroups := installer.GroupBySource(files)
+	sourceGroups := make(map[string][]purewin.Installer)
+	for _, file := range files {
+		sourceGroups[file.Source] = append(sourceGroups[file.Source], file)
+	}
 
 	// Sort sources
 	sources := make([]string, 0, len(sourceGroups))
@@ -170,6 +272,7 @@ func installerFilesToSelectorItems(files []installer.InstallerFile) []ui.Selecto
 				Description: fmt.Sprintf("%s • %s old", file.Path, ageStr),
 				Value:       file.Path,
 				Size:        core.FormatSize(file.Size),
+				Risk:        file.Risk(),
 				Selected:    true,
 				Disabled:    false,
 				Category:    source,
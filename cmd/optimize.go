@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/adminpolicy"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/internal/optimize"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
@@ -33,6 +35,21 @@ type optimizeResult struct {
 	Error   error
 }
 
+// optimizeTask is a single optimization step. Group and ID are the names
+// an administrator policy uses to disable it.
+type optimizeTask struct {
+	group string
+	id    string
+	name  string
+	run   func() error
+}
+
+// Optimize task groups.
+const (
+	optimizeGroupServices    = "services"
+	optimizeGroupMaintenance = "maintenance"
+)
+
 func runOptimize(cmd *cobra.Command, args []string) {
 	servicesOnly, _ := cmd.Flags().GetBool("services")
 	maintenanceOnly, _ := cmd.Flags().GetBool("maintenance")
@@ -44,6 +61,21 @@ func runOptimize(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// The admin policy can force dry-run or disable whole groups.
+	lock := mustLoadAdminLock()
+	dryRun = lock.forceDryRun(dryRun)
+	for group, only := range map[string]bool{
+		optimizeGroupServices:    servicesOnly,
+		optimizeGroupMaintenance: maintenanceOnly,
+	} {
+		if only && !lock.allowTask(group, "") {
+			printRefusal(&adminpolicy.Refusal{
+				Source: lock.policy.Source,
+				What:   fmt.Sprintf("Optimize %s tasks", group),
+			})
+		}
+	}
+
 	// Fail fast: service and maintenance tasks require admin.
 	if !core.IsElevated() && !dryRun {
 		fmt.Println()
@@ -66,71 +98,66 @@ func runOptimize(cmd *cobra.Command, args []string) {
 
 	// ── Services ──
 	if servicesOnly || runAll {
-		results = append(results, runServiceOptimizations()...)
+		results = append(results, runOptimizeTasks("Services", serviceTasks(), lock)...)
 	}
 
 	// ── Maintenance ──
 	if maintenanceOnly || runAll {
-		results = append(results, runMaintenanceOptimizations()...)
+		results = append(results, runOptimizeTasks("Maintenance", maintenanceTasks(), lock)...)
 	}
 
 	// ── Summary ──
 	printOptimizeSummary(results)
 }
 
-// runServiceOptimizations executes service-related optimizations.
-func runServiceOptimizations() []optimizeResult {
-	fmt.Println(ui.SectionHeader("Services", 50))
-	fmt.Println()
-
-	var results []optimizeResult
-
-	// DNS flush.
-	results = append(results, runOptimizeTask("Flush DNS cache", func() error {
-		return optimize.FlushDNS()
-	}))
+// serviceTasks returns the service-related optimizations.
+func serviceTasks() []optimizeTask {
+	tasks := []optimizeTask{
+		{optimizeGroupServices, "flush_dns", "Flush DNS cache", optimize.FlushDNS},
+	}
 
 	// Restart managed services.
 	for _, svc := range optimize.GetManagedServices() {
 		svc := svc // capture for closure
-		results = append(results, runOptimizeTask(
-			fmt.Sprintf("Restart %s", svc.DisplayName),
-			func() error {
+		tasks = append(tasks, optimizeTask{
+			group: optimizeGroupServices,
+			id:    "restart_" + strings.ToLower(svc.Name),
+			name:  fmt.Sprintf("Restart %s", svc.DisplayName),
+			run: func() error {
 				return optimize.RestartService(svc.Name)
 			},
-		))
+		})
 	}
+	return tasks
+}
 
-	fmt.Println()
-	return results
+// maintenanceTasks returns the maintenance optimizations.
+func maintenanceTasks() []optimizeTask {
+	return []optimizeTask{
+		{optimizeGroupMaintenance, "dism", "DISM component cleanup", optimize.RunDISMCleanup},
+		{optimizeGroupMaintenance, "sfc", "System file integrity check", optimize.RunSFCCheck},
+		{optimizeGroupMaintenance, "icon_cache", "Rebuild icon cache", optimize.RebuildIconCache},
+		{optimizeGroupMaintenance, "search_index", "Rebuild search index", optimize.RebuildSearchIndex},
+		{optimizeGroupMaintenance, "event_logs", "Clear event logs", optimize.ClearEventLogs},
+	}
 }
 
-// runMaintenanceOptimizations executes maintenance tasks.
-func runMaintenanceOptimizations() []optimizeResult {
-	fmt.Println(ui.SectionHeader("Maintenance", 50))
+// runOptimizeTasks runs a group of tasks under a section header, skipping
+// any the admin policy disables.
+func runOptimizeTasks(title string, tasks []optimizeTask, lock *adminLock) []optimizeResult {
+	fmt.Println(ui.SectionHeader(title, 50))
 	fmt.Println()
 
 	var results []optimizeResult
-
-	results = append(results, runOptimizeTask("DISM component cleanup", func() error {
-		return optimize.RunDISMCleanup()
-	}))
-
-	results = append(results, runOptimizeTask("System file integrity check", func() error {
-		return optimize.RunSFCCheck()
-	}))
-
-	results = append(results, runOptimizeTask("Rebuild icon cache", func() error {
-		return optimize.RebuildIconCache()
-	}))
-
-	results = append(results, runOptimizeTask("Rebuild search index", func() error {
-		return optimize.RebuildSearchIndex()
-	}))
-
-	results = append(results, runOptimizeTask("Clear event logs", func() error {
-		return optimize.ClearEventLogs()
-	}))
+	for _, task := range tasks {
+		if !lock.allowTask(task.group, task.id) {
+			fmt.Printf("  %s %s\n",
+				ui.MutedStyle().Render(ui.IconCircle),
+				ui.MutedStyle().Render(fmt.Sprintf("%s — disabled by administrator policy", task.name)))
+			continue
+		}
+		results = append(results, runOptimizeTask(task.name, task.run))
+	}
 
 	fmt.Println()
 	return results
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/pending"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// pendingStateFile records the reboot deletions PureWin scheduled.
+const pendingStateFile = "pending-deletes.json"
+
+// maxHolderLookups caps Restart Manager queries per run; each one opens a
+// session, so thousands of locked cache files would take a while.
+const maxHolderLookups = 50
+
+// maxHolderGroupsShown caps how many holding processes are listed.
+const maxHolderGroupsShown = 8
+
+var pendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List or cancel deletions scheduled for the next reboot",
+	Long: `Files that stay locked after a cleanup can be scheduled for deletion at the
+next reboot. These commands show what PureWin scheduled and let you take it
+back before you restart.`,
+}
+
+var pendingListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show files PureWin scheduled for deletion at reboot",
+	Args:  cobra.NoArgs,
+	Run:   runPendingList,
+}
+
+var pendingCancelCmd = &cobra.Command{
+	Use:   "cancel [path...]",
+	Short: "Unschedule reboot deletions (all of them with --all)",
+	Example: `  pw pending cancel C:\Users\me\AppData\Local\Temp\locked.tmp
+  pw pending cancel --all`,
+	Run: runPendingCancel,
+}
+
+func init() {
+	pendingCancelCmd.Flags().Bool("all", false, "Cancel every deletion PureWin scheduled")
+	pendingCmd.AddCommand(pendingListCmd)
+	pendingCmd.AddCommand(pendingCancelCmd)
+}
+
+// pendingSystem returns the platform layer behind the pending commands
+// and locked-file resolution.
+var pendingSystem = pending.NewSystem
+
+// newPendingManager returns the reboot-deletion manager for cfg, making its
+// platform calls through sys.
+func newPendingManager(cfg *config.Config, sys pending.System) *pending.Manager {
+	return &pending.Manager{
+		Sys:  sys,
+		Path: filepath.Join(cfg.ConfigDir, pendingStateFile),
+	}
+}
+
+// ─── pw pending list ─────────────────────────────────────────────────────────
+
+func runPendingList(cmd *cobra.Command, args []string) {
+	cfg := mustLoadConfig()
+	entries, err := newPendingManager(cfg, pendingSystem()).List()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Pending Reboot Deletions", 55))
+	if len(entries) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  Nothing scheduled."))
+		fmt.Println()
+		return
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+		fmt.Printf("    %10s  %s\n", core.FormatSize(e.Size), e.Path)
+		detail := fmt.Sprintf("scheduled by %s on %s", e.Command, e.Scheduled.Local().Format("2006-01-02 15:04"))
+		if len(e.Holders) > 0 {
+			detail += ", held by " + e.Holders[0]
+		}
+		fmt.Println(ui.MutedStyle().Render("                " + detail))
+	}
+	fmt.Println()
+	fmt.Printf("  %d files, %s will be freed at the next reboot\n", len(entries), core.FormatSize(total))
+	fmt.Println()
+}
+
+// ─── pw pending cancel ───────────────────────────────────────────────────────
+
+func runPendingCancel(cmd *cobra.Command, args []string) {
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Give paths to cancel, or --all", ui.IconError)))
+		os.Exit(1)
+	}
+	if err := core.RequireAdmin("pending cancel"); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	cfg := mustLoadConfig()
+	canceled, err := newPendingManager(cfg, pendingSystem()).Cancel(args)
+	for _, e := range canceled {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Canceled %s", ui.IconCheck, e.Path)))
+	}
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if len(canceled) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  Nothing scheduled."))
+	}
+}
+
+// mustLoadConfig loads config or exits.
+func mustLoadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// ─── Locked File Resolution ──────────────────────────────────────────────────
+
+// resolveLocked names the processes holding the files a run could not
+// delete because they were locked, then offers to delete them at the next
+// reboot. Without a terminal it only reports the holders.
+func resolveLocked(cfg *config.Config, command string, skips *skipSummary) {
+	paths := skips.paths[core.ReasonLocked]
+	if len(paths) == 0 {
+		return
+	}
+	files := make([]pending.LockedFile, len(paths))
+	for i, p := range paths {
+		size, _, _ := report.StatPath(p)
+		files[i] = pending.LockedFile{Path: p, Size: size}
+	}
+
+	opts := pending.ResolveOptions{
+		Command:  command,
+		Limit:    maxHolderLookups,
+		Elevated: core.IsElevated(),
+		Report:   printHolders,
+	}
+	if ui.IsTerminal() && cfg.ConfigDir != "" {
+		opts.Confirm = func(files []pending.LockedFile) bool {
+			ok, err := ui.Confirm(fmt.Sprintf("  Delete the %d locked files at the next reboot?", len(files)))
+			return err == nil && ok
+		}
+	}
+	res := newPendingManager(cfg, pendingSystem()).Resolve(files, opts)
+
+	if res.NeedsAdmin {
+		fmt.Println(ui.MutedStyle().Render(
+			"  → Close those programs and re-run, or run as administrator to delete them at reboot."))
+	}
+	if len(res.Scheduled) > 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+			"  %s %d files scheduled — review with: pw pending list", ui.IconCheck, len(res.Scheduled))))
+	}
+	if res.Err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Some files could not be scheduled: %v", ui.IconWarning, res.Err)))
+	}
+}
+
+// printHolders lists the processes holding locked files, largest group
+// first.
+func printHolders(groups []pending.HolderGroup) {
+	fmt.Println(ui.MutedStyle().Render("  Locked files are held by:"))
+	for i, g := range groups {
+		if i == maxHolderGroupsShown {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("     … and %d more processes", len(groups)-i)))
+			break
+		}
+		name := g.Holder.String()
+		if g.Holder == (pending.Holder{}) {
+			name = "unknown process"
+		}
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("     %-32s %d files", name, len(g.Paths))))
+	}
+}
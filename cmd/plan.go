@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/budget"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// maxSkippedShown caps how many drifted items are listed without --debug.
+const maxSkippedShown = 10
+
+// addPlanFlags registers --plan-out and --plan on a deleting command.
+func addPlanFlags(cmd *cobra.Command) {
+	cmd.Flags().String("plan-out", "", "Save the dry-run plan to a JSON file (implies --dry-run)")
+	cmd.Flags().String("plan", "", "Delete exactly the items in a saved plan, skipping any that changed")
+	cmd.MarkFlagsMutuallyExclusive("plan", "plan-out")
+}
+
+// ─── Saving Plans ────────────────────────────────────────────────────────────
+
+// savePlan measures items and writes them as a plan file for command.
+func savePlan(path, command string, items []report.Item) {
+	stamped := report.Stamp(items)
+	p := report.NewPlan(command, true, stamped)
+	p.Hostname, _ = os.Hostname()
+	p.Created = time.Now()
+
+	if err := report.WritePlan(path, p); err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Could not save plan: %v", ui.IconWarning, err)))
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  Plan saved to %s (%d items, %s)", path, p.ItemCount, core.FormatSize(p.TotalSize))))
+	if dropped := len(items) - len(stamped); dropped > 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  %d items vanished while the plan was written and were left out", dropped)))
+	}
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  → Run it with: pw %s --plan %s", command, path)))
+}
+
+// ─── Running Plans ───────────────────────────────────────────────────────────
+
+// planRun executes a saved plan for one command.
+type planRun struct {
+	command string
+	path    string
+	lock    *adminLock
+	fleet   *fleetRun
+
+	// confine returns the root a planned item must be deleted within, or
+	// false when the item lies outside the locations the command cleans.
+	// The root is always recomputed here: the plan file's own Root is
+	// never trusted, since a hand-edited plan could widen it to anything.
+	confine func(report.Item) (root string, ok bool)
+
+	// allow, if set, rejects items the current policies no longer permit.
+	allow func(report.Item) bool
+
+	// roots and limits configure the circuit breaker; nil roots skip the
+	// outside-roots check.
+	roots  []string
+	limits budget.Limits
+
+	// pace, if set, caps the deletion rate.
+	pace purewin.Pacer
+
+	// shred, if set, returns the --shred settings given each planned
+	// item's category, or nil to delete plainly.
+	shred func(categoryOf map[string]string) *purewin.ShredOptions
+
+	// trackCategories records freed bytes per item category in the session.
+	trackCategories bool
+}
+
+// skippedItem is a planned item that was not deleted, with the reason.
+type skippedItem struct {
+	item report.Item
+	err  error
+}
+
+// run verifies every planned item against the disk and deletes those that
+// are unchanged. Items whose size or modification time drifted since the
+// plan was made are skipped, never re-scanned.
+func (pr planRun) run(cfg *config.Config) {
+	p, err := report.ReadPlan(pr.path)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if p.Command != pr.command {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s %s was made by pw %s — run it with: pw %s --plan %s",
+			ui.IconError, pr.path, p.Command, p.Command, pr.path)))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Run Plan", 55))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %s — %d items (%s)",
+		filepath.Base(pr.path), p.ItemCount, core.FormatSize(p.TotalSize))))
+	if !p.Created.IsZero() {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Created %s on %s",
+			p.Created.Local().Format("2006-01-02 15:04"), p.Hostname)))
+	}
+	if dryRun {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  DRY RUN MODE — no files will be deleted", ui.IconWarning)))
+	}
+	fmt.Println()
+
+	hs := newCmdSession(cfg, pr.command)
+	defer hs.close()
+	hs.useFleet(pr.fleet, "")
+
+	// ── Policy and drift checks ─────────────────────────────────────────
+	var outside, refused int
+	candidates := make([]report.Item, 0, len(p.Items))
+	for _, item := range p.Items {
+		root, ok := pr.confine(item)
+		if !ok {
+			outside++
+			continue
+		}
+		item.Root = root
+		if pr.allow != nil && !pr.allow(item) {
+			refused++
+			continue
+		}
+		candidates = append(candidates, item)
+	}
+	if outside > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  %d planned items lie outside the locations pw %s cleans and will be skipped",
+			ui.IconWarning, outside, pr.command)))
+	}
+	if refused > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  %d planned items are no longer allowed by policy and will be skipped",
+			ui.IconWarning, refused)))
+	}
+
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Verifying planned items...")
+	var verified []report.Item
+	var skipped []skippedItem
+	for _, item := range candidates {
+		if err := item.Verify(); err != nil {
+			skipped = append(skipped, skippedItem{item, err})
+			continue
+		}
+		verified = append(verified, item)
+	}
+	spinner.Stop(fmt.Sprintf("%d of %d items unchanged", len(verified), len(candidates)))
+	printSkippedItems(skipped)
+	verified, busy := skipBusyItems(verified)
+
+	verified = fitBudget(pr.lock, verified, func(item report.Item) int64 { return item.Size })
+	_ = hs.run(hooks.PostScan, report.NewPlan(pr.command, dryRun, verified))
+
+	if len(verified) == 0 {
+		fmt.Println()
+		fmt.Println(ui.MutedStyle().Render("  Nothing left to delete."))
+		fmt.Println()
+		hs.finish(dryRun, 0, 0, 0)
+		return
+	}
+
+	var totalSize int64
+	for _, item := range verified {
+		totalSize += item.Size
+	}
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.BoldStyle().Render(fmt.Sprintf("Will delete %d items (%s)",
+		len(verified), core.FormatSize(totalSize))))
+	fmt.Println()
+
+	if dryRun {
+		if pr.trackCategories {
+			for _, item := range verified {
+				hs.categories.add(item.Category, item.Size)
+			}
+		}
+		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] No files were deleted"))
+		fmt.Println()
+		hs.finish(true, totalSize, len(verified), 0)
+		return
+	}
+
+	confirmed, confirmErr := ui.Confirm(
+		fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
+	if confirmErr != nil || !confirmed {
+		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+		fmt.Println()
+		return
+	}
+	hs.mustRun(hooks.PreDelete, nil)
+
+	// ── Delete ──────────────────────────────────────────────────────────
+	logger, logErr := core.NewLogger(cfg.LogFile)
+	if logErr != nil {
+		logger = nil
+	} else {
+		defer logger.Close()
+		logger.LogSession(fmt.Sprintf("%s --plan %s", pr.command, pr.path))
+	}
+
+	items := make([]purewin.Item, len(verified))
+	paths := make([]string, len(verified))
+	itemCategory := make(map[string]string, len(verified))
+	for i, item := range verified {
+		items[i] = purewin.Item{Path: item.Path, Root: item.Root}
+		paths[i] = item.Path
+		itemCategory[item.Path] = item.Category
+	}
+	var shred *purewin.ShredOptions
+	if pr.shred != nil {
+		shred = pr.shred(itemCategory)
+		startShred(shred, paths, logger)
+	}
+
+	delSpinner := ui.NewInlineSpinner()
+	delSpinner.Start("Deleting...")
+	rb := newRunBreaker(pr.limits, pr.roots, verified, ui.IsTerminal(), logger)
+	if rb != nil {
+		rb.pause = func() { delSpinner.Stop("Paused by circuit breaker") }
+		rb.resume = func() {
+			delSpinner = ui.NewInlineSpinner()
+			delSpinner.Start("Deleting...")
+		}
+	}
+	res, stopErr := purewin.DeleteItems(context.Background(), items, purewin.DeleteOptions{
+		Before: rb.before,
+		Shred:  shred,
+		Pace:   pr.pace,
+		OnItem: func(r purewin.ItemResult) {
+			delSpinner.UpdateMessage(fmt.Sprintf("Deleting %s...", filepath.Base(r.Path)))
+			if r.Err == nil {
+				rb.record(r.Path, r.Freed)
+				if pr.trackCategories {
+					hs.categories.add(itemCategory[r.Path], r.Freed)
+				}
+			}
+			if logger != nil {
+				logger.Log(deleteOp(shred, r.Path), r.Path, r.Freed, r.Err)
+			}
+		},
+	})
+	if stopErr != nil {
+		delSpinner.StopWithError("Plan stopped")
+		printBreakerStop(stopErr)
+	} else {
+		delSpinner.Stop("Plan complete")
+	}
+
+	rb.finish()
+	if logger != nil {
+		logger.LogSummary(res.Freed, res.Deleted, len(res.Failed))
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s  Freed %s across %d items",
+		ui.IconSuccess, core.FormatSize(res.Freed), res.Deleted)))
+	var skips skipSummary
+	skips.addResults(res)
+	skips.print("items", debug || cfg.DebugMode)
+	resolveLocked(cfg, pr.command, &skips)
+	if len(skipped) > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  %d items skipped because they changed since the plan was made",
+			ui.IconWarning, len(skipped))))
+	}
+	if busy > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  %d items skipped because their app was running",
+			ui.IconWarning, busy)))
+	}
+	fmt.Println()
+
+	hs.finish(false, res.Freed, res.Deleted, len(res.Failed))
+}
+
+// skipBusyItems drops the items whose target's application is running
+// now, listing those targets, and returns the rest with the number
+// dropped. A plan may run long after it was made, so the scan's own check
+// proves nothing. As in a scan, if the processes cannot be listed nothing
+// is dropped.
+func skipBusyItems(items []report.Item) ([]report.Item, int) {
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.Target
+	}
+	busy, err := purewin.BusyTargets(context.Background(), targets)
+	if err != nil || len(busy) == 0 {
+		return items, 0
+	}
+
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  These apps are running, so their planned items will be skipped:", ui.IconWarning)))
+	skip := make(map[string]bool, len(busy))
+	for _, b := range busy {
+		skip[strings.ToLower(b.Name)] = true
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("     %-24s %s", b.Name, b.Reason())))
+	}
+	var kept []report.Item
+	for _, item := range items {
+		if !skip[strings.ToLower(item.Target)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept, len(items) - len(kept)
+}
+
+// rootFor returns the deepest of roots that path lies under, with any glob
+// pattern in it replaced by the directories path actually passes through.
+// ok is false when path is under none of them.
+func rootFor(path string, roots []string) (root string, ok bool) {
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		return "", false
+	}
+	parts := strings.Split(path, string(filepath.Separator))
+	for _, r := range roots {
+		if r == "" || !budget.Within(path, r) {
+			continue
+		}
+		n := len(strings.Split(filepath.Clean(r), string(filepath.Separator)))
+		if concrete := strings.Join(parts[:n], string(filepath.Separator)); len(concrete) > len(root) {
+			root = concrete
+		}
+	}
+	return root, root != ""
+}
+
+// printSkippedItems lists drifted items, all of them with --debug.
+func printSkippedItems(skipped []skippedItem) {
+	if len(skipped) == 0 {
+		return
+	}
+	var vanished int
+	for _, s := range skipped {
+		if errors.Is(s.err, report.ErrVanished) {
+			vanished++
+		}
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  %d items skipped (%d changed, %d gone)",
+		ui.IconWarning, len(skipped), len(skipped)-vanished, vanished)))
+
+	shown := skipped
+	if !debug && len(shown) > maxSkippedShown {
+		shown = shown[:maxSkippedShown]
+	}
+	for _, s := range shown {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    %s: %v", s.item.Path, s.err)))
+	}
+	if n := len(skipped) - len(shown); n > 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("    ... and %d more (use --debug to list all)", n)))
+	}
+}
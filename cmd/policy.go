@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/adminpolicy"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Admin Policy ────────────────────────────────────────────────────────────
+
+// adminLock is the machine-level admin policy in effect. Unlike the fleet
+// policy, which the user opts into from config.json, it is read from
+// ProgramData on every run and beats both config and flags. A nil
+// *adminLock means no policy file exists; every method allows everything.
+type adminLock struct {
+	policy *adminpolicy.Policy
+	budget int64
+}
+
+// loadAdminLock reads the machine policy. It returns nil when no policy
+// file exists. An unreadable or invalid policy is an error.
+func loadAdminLock() (*adminLock, error) {
+	policy, err := adminpolicy.Load(config.MachinePolicyPath())
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Active() {
+		return nil, nil
+	}
+	l := &adminLock{policy: policy}
+	if policy.MaxBytesPerRun != "" {
+		budget, err := parseSize(policy.MaxBytesPerRun)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_bytes_per_run %q in administrator policy %s",
+				policy.MaxBytesPerRun, policy.Source)
+		}
+		l.budget = budget
+	}
+	return l, nil
+}
+
+// mustLoadAdminLock loads the machine policy and stops the command if it
+// cannot be read: a locked-down machine must not run unrestricted because
+// its policy file is damaged.
+func mustLoadAdminLock() *adminLock {
+	l, err := loadAdminLock()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return l
+}
+
+// printRefusal prints a policy refusal and exits.
+func printRefusal(err error) {
+	fmt.Println()
+	fmt.Println(ui.ErrorStyle().Render(
+		fmt.Sprintf("  %s  %v", ui.IconError, err)))
+	fmt.Println(ui.MutedStyle().Render(
+		"  → Contact your administrator to change this policy."))
+	fmt.Println()
+	os.Exit(1)
+}
+
+// checkAdminCommand refuses to run cmd if the policy disables its
+// top-level command.
+func checkAdminCommand(cmd *cobra.Command) {
+	top := cmd
+	for top.HasParent() && top.Parent() != rootCmd {
+		top = top.Parent()
+	}
+	if top == rootCmd {
+		return
+	}
+	if err := mustLoadAdminLock().checkCommand(top.Name()); err != nil {
+		printRefusal(err)
+	}
+}
+
+// checkCommand returns a refusal if the named command is disabled.
+func (l *adminLock) checkCommand(name string) error {
+	if l == nil {
+		return nil
+	}
+	return l.policy.CheckCommand(name)
+}
+
+// forceDryRun reports whether the run must be a dry run, printing a notice
+// when the policy overrides a real run.
+func (l *adminLock) forceDryRun(dryRun bool) bool {
+	if l == nil || l.policy.DryRun(dryRun) == dryRun {
+		return dryRun
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  Administrator policy requires dry-run — nothing will be deleted", ui.IconWarning)))
+	return true
+}
+
+// restrictScope removes forbidden categories from scope. A forbidden
+// category requested by its own flag (--system, say) is refused outright;
+// one that only came from --all, a profile or the default is dropped with a
+// notice. A scope left empty is refused.
+func (l *adminLock) restrictScope(cmd *cobra.Command, scope cleanScope) (cleanScope, error) {
+	if l == nil {
+		return scope, nil
+	}
+	fields := []struct {
+		name string
+		in   *bool
+	}{
+		{purewin.CategoryUser, &scope.user},
+		{purewin.CategoryBrowser, &scope.browser},
+		{purewin.CategoryElectron, &scope.electron},
+		{purewin.CategoryDev, &scope.dev},
+		{purewin.CategorySystem, &scope.system},
+	}
+	var dropped []string
+	for _, f := range fields {
+		if !*f.in || l.policy.CategoryAllowed(f.name) {
+			continue
+		}
+		if explicit, _ := cmd.Flags().GetBool(f.name); explicit {
+			return scope, &adminpolicy.Refusal{
+				Source: l.policy.Source,
+				What:   fmt.Sprintf("Cleaning the %s category", f.name),
+			}
+		}
+		*f.in = false
+		dropped = append(dropped, f.name)
+	}
+	if scope == (cleanScope{}) {
+		return scope, &adminpolicy.Refusal{Source: l.policy.Source, What: "Cleaning every requested category"}
+	}
+	if len(dropped) > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  Skipping %s — disabled by administrator policy",
+			ui.IconWarning, strings.Join(dropped, ", "))))
+	}
+	return scope, nil
+}
+
+// allowCategory reports whether a clean category may be cleaned.
+func (l *adminLock) allowCategory(category string) bool {
+	return l == nil || l.policy.CategoryAllowed(category)
+}
+
+// allowCategories filters category names down to those the policy allows.
+func (l *adminLock) allowCategories(categories []string) []string {
+	if l == nil {
+		return categories
+	}
+	var allowed []string
+	for _, c := range categories {
+		if l.allowCategory(c) {
+			allowed = append(allowed, c)
+		}
+	}
+	return allowed
+}
+
+// serveCategories resolves the categories for an API scan from explicit
+// categories and a profile; with neither, every category is scanned.
+// Explicitly requested forbidden categories are refused; forbidden profile
+// categories are dropped.
+func (l *adminLock) serveCategories(categories []string, profile string) ([]string, error) {
+	for _, c := range categories {
+		if l != nil && !l.policy.CategoryAllowed(c) {
+			return nil, &adminpolicy.Refusal{
+				Source: l.policy.Source,
+				What:   fmt.Sprintf("Cleaning the %s category", c),
+			}
+		}
+	}
+	all := append([]string(nil), categories...)
+	if profile != "" {
+		p, err := purewin.GetProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, p.Categories...)
+	}
+	if len(all) == 0 {
+		all = purewin.AllCategories()
+	}
+	if l == nil {
+		return all, nil
+	}
+	allowed := l.allowCategories(all)
+	if len(allowed) == 0 {
+		return nil, &adminpolicy.Refusal{Source: l.policy.Source, What: "Cleaning every requested category"}
+	}
+	return allowed, nil
+}
+
+// allowTarget reports whether a clean target may be cleaned.
+func (l *adminLock) allowTarget(name string) bool {
+	return l == nil || l.policy.TargetAllowed(name)
+}
+
+// excludedTargets returns the target names the policy forbids.
+func (l *adminLock) excludedTargets() []string {
+	if l == nil {
+		return nil
+	}
+	return l.policy.ForbiddenTargets
+}
+
+// allowTask reports whether an optimize task may run.
+func (l *adminLock) allowTask(group, id string) bool {
+	return l == nil || l.policy.TaskAllowed(group, id)
+}
+
+// ─── Budget ──────────────────────────────────────────────────────────────────
+
+// capBudget returns the smaller of budget and the policy's per-run budget,
+// treating zero as unlimited.
+func (l *adminLock) capBudget(budget int64) int64 {
+	if l == nil || l.budget <= 0 {
+		return budget
+	}
+	if budget <= 0 || l.budget < budget {
+		return l.budget
+	}
+	return budget
+}
+
+// fit returns the indexes of sizes that fit in the per-run budget, printing
+// a notice when anything is held back.
+func (l *adminLock) fit(sizes []int64) []int {
+	var limit int64
+	if l != nil {
+		limit = l.budget
+	}
+	keep := adminpolicy.Fit(sizes, limit)
+	if len(keep) == len(sizes) {
+		return keep
+	}
+	var total, kept int64
+	for _, s := range sizes {
+		total += s
+	}
+	for _, i := range keep {
+		kept += sizes[i]
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  Administrator policy caps each run at %s — %d items (%s) left for a later run",
+		ui.IconWarning, core.FormatSize(l.budget), len(sizes)-len(keep), core.FormatSize(total-kept))))
+	return keep
+}
+
+// fitBudget trims items to the per-run budget, keeping their order.
+func fitBudget[T any](l *adminLock, items []T, size func(T) int64) []T {
+	if l == nil || l.budget <= 0 {
+		return items
+	}
+	sizes := make([]int64, len(items))
+	for i, item := range items {
+		sizes[i] = size(item)
+	}
+	keep := l.fit(sizes)
+	out := make([]T, 0, len(keep))
+	for _, i := range keep {
+		out = append(out, items[i])
+	}
+	return out
+}
+
+// fitClean trims a clean scan, extras included, to the per-run budget.
+func (l *adminLock) fitClean(scan *purewin.ScanResult) *purewin.ScanResult {
+	if l == nil || l.budget <= 0 {
+		return scan
+	}
+	items := scan.Items()
+	sizes := make([]int64, 0, len(items)+len(scan.Extras))
+	for _, item := range items {
+		sizes = append(sizes, item.Size)
+	}
+	for _, e := range scan.Extras {
+		sizes = append(sizes, e.Size)
+	}
+
+	kept := make(map[int]bool, len(sizes))
+	for _, i := range l.fit(sizes) {
+		kept[i] = true
+	}
+	keepPaths := make(map[string]bool, len(items))
+	for i, item := range items {
+		if kept[i] {
+			keepPaths[item.Path] = true
+		}
+	}
+	out := scan.Filter(func(item purewin.Item) bool { return keepPaths[item.Path] })
+	out.Extras = nil
+	for i, e := range scan.Extras {
+		if kept[len(items)+i] {
+			out.Extras = append(out.Extras, e)
+		}
+	}
+	return out
+}
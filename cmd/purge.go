@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,8 +11,11 @@ import (
 
 	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
 	"github.com/lakshaymaurya-felt/purewin/internal/purge"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +31,9 @@ func init() {
 	purgeCmd.Flags().Bool("paths", false, "Configure project scan directories")
 	purgeCmd.Flags().Int("min-age", 7, "Minimum age in days (recent projects are skipped)")
 	purgeCmd.Flags().String("min-size", "", "Minimum artifact size to show (e.g., 50MB)")
+	addPlanFlags(purgeCmd)
+	addDeepFlag(purgeCmd)
+	addGentleFlag(purgeCmd, false)
 }
 
 func runPurge(cmd *cobra.Command, args []string) {
@@ -44,11 +51,50 @@ func runPurge(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Saving a plan or predicting failures is a dry run.
+	planOut, _ := cmd.Flags().GetString("plan-out")
+	if planOut != "" || deepDryRun {
+		dryRun = true
+	}
+
+	// Fleet and admin policies beat local flags and config.
+	fr := loadFleet(cfg)
+	lock := mustLoadAdminLock()
+	dryRun = lock.forceDryRun(fr.forceDryRun(dryRun))
+	limiter, restorePriority := startGentle(cmd, cfg)
+	defer restorePriority()
+
+	if planPath, _ := cmd.Flags().GetString("plan"); planPath != "" {
+		scanPaths := getScanPaths(cfg)
+		wl := fr.applyWhitelist(loadWhitelist(cfg))
+		planRun{
+			command: "purge",
+			path:    planPath,
+			lock:    lock,
+			fleet:   fr,
+			confine: func(item report.Item) (string, bool) {
+				return artifactRoot(item.Path, scanPaths)
+			},
+			allow: func(item report.Item) bool {
+				typ, _ := purge.ArtifactType(item.Path)
+				return lock.allowTarget(purewin.ArtifactsTarget) && lock.allowTarget(typ) &&
+					(wl == nil || !wl.IsWhitelisted(item.Path))
+			},
+			pace: pacer(limiter),
+		}.run(cfg)
+		return
+	}
+
 	// Start scanning
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Project Purge", 50))
 	fmt.Println()
 
+	hs := newCmdSession(cfg, "purge")
+	defer hs.close()
+	hs.useFleet(fr, "")
+	hs.mustRun(hooks.PreScan, nil)
+
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Scanning for project artifacts...")
 
@@ -62,7 +108,10 @@ func runPurge(cmd *cobra.Command, args []string) {
 	}
 
 	// Scan for artifacts
-	artifacts, err := purge.ScanProjects(scanPaths)
+	artifacts, err := purewin.ScanArtifacts(context.Background(), purewin.ArtifactScanOptions{
+		Paths: scanPaths,
+		Pace:  pacer(limiter),
+	})
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
 		os.Exit(1)
@@ -70,6 +119,12 @@ func runPurge(cmd *cobra.Command, args []string) {
 
 	spinner.Stop(fmt.Sprintf("Found %d artifacts", len(artifacts)))
 
+	planItems := make([]report.Item, 0, len(artifacts))
+	for _, a := range artifacts {
+		planItems = append(planItems, report.Item{Path: a.Path, Size: a.Size, Category: a.Type})
+	}
+	_ = hs.run(hooks.PostScan, report.NewPlan("purge", dryRun, planItems))
+
 	if len(artifacts) == 0 {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s No project artifacts found!", ui.IconCheck)))
@@ -95,17 +150,19 @@ func runPurge(cmd *cobra.Command, args []string) {
 	}
 
 	// Convert back to artifacts
-	selectedArtifacts := make([]purge.ProjectArtifact, 0, len(selected))
+	selectedArtifacts := make([]purewin.Artifact, 0, len(selected))
 	for _, item := range selected {
 		// Find the artifact by path
 		for _, artifact := range artifacts {
-			if artifact.ArtifactPath == item.Value {
+			if artifact.Path == item.Value {
 				selectedArtifacts = append(selectedArtifacts, artifact)
 				break
 			}
 		}
 	}
 
+	selectedArtifacts = fitBudget(lock, selectedArtifacts, func(a purewin.Artifact) int64 { return a.Size })
+
 	// Show summary
 	fmt.Println()
 	totalSize := int64(0)
@@ -130,27 +187,60 @@ func runPurge(cmd *cobra.Command, args []string) {
 			fmt.Println()
 			return
 		}
+		hs.mustRun(hooks.PreDelete, nil)
 	}
 
 	// Delete
 	fmt.Println()
-	freed, count, purgeErr := purge.PurgeArtifacts(selectedArtifacts, dryRun)
+	paths := make([]string, 0, len(selectedArtifacts))
+	for _, artifact := range selectedArtifacts {
+		paths = append(paths, artifact.Path)
+	}
 
+	var freed int64
+	var count int
 	if dryRun {
+		items := make([]report.Item, 0, len(selectedArtifacts))
+		for _, a := range selectedArtifacts {
+			items = append(items, report.Item{Path: a.Path, Size: a.Size, Category: a.Type, Target: a.Type})
+		}
+		if deepDryRun {
+			items = predictFailures(items)
+			freed, count = predictedTotals(items)
+		} else {
+			res := deletePaths(paths, true, nil)
+			freed, count = res.Freed, res.Deleted
+		}
+
 		fmt.Println()
 		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] No files were deleted"))
 		fmt.Printf("  Would free: %s from %d artifacts\n", core.FormatSize(freed), count)
+		if deepDryRun {
+			printPredictions(items, "artifacts", debug || cfg.DebugMode)
+		}
+		if planOut != "" {
+			savePlan(planOut, "purge", items)
+		}
 		fmt.Println()
 	} else {
+		res := deletePaths(paths, false, pacer(limiter))
+		freed, count = res.Freed, res.Deleted
+
 		fmt.Println()
-		if purgeErr != nil {
-			fmt.Printf("%s Completed with errors: %v\n", ui.WarningStyle().Render(ui.IconWarning), purgeErr)
+		var skips skipSummary
+		skips.addResults(res)
+		if skips.count() > 0 {
+			fmt.Printf("%s Completed with errors\n", ui.WarningStyle().Render(ui.IconWarning))
 		} else {
 			fmt.Printf("%s Success!\n", ui.SuccessStyle().Render(ui.IconSuccess))
 		}
 		fmt.Printf("  Freed: %s from %d artifacts\n", ui.SuccessStyle().Render(core.FormatSize(freed)), count)
+		skips.print("artifacts", debug || cfg.DebugMode)
+		resolveLocked(cfg, "purge", &skips)
 		fmt.Println()
 	}
+
+	hs.finish(dryRun, freed, count, len(selectedArtifacts)-count)
 }
 
 // getScanPaths returns the list of paths to scan for projects.
@@ -165,6 +255,19 @@ func getScanPaths(cfg *config.Config) []string {
 	return purge.GetDefaultScanPaths()
 }
 
+// artifactRoot returns the project directory a planned artifact must be
+// deleted within. ok is false unless path lies under one of scanPaths and
+// is still a build artifact directory the scanner would report.
+func artifactRoot(path string, scanPaths []string) (root string, ok bool) {
+	if _, ok := rootFor(path, scanPaths); !ok {
+		return "", false
+	}
+	if _, ok := purge.ArtifactType(path); !ok {
+		return "", false
+	}
+	return filepath.Dir(filepath.Clean(path)), true
+}
+
 // managePurgePaths opens the purge_paths file in the default editor.
 func managePurgePaths(cfg *config.Config) {
 	pathsFile := filepath.Join(cfg.ConfigDir, "purge_paths")
@@ -200,11 +303,11 @@ func managePurgePaths(cfg *config.Config) {
 }
 
 // artifactsToSelectorItems converts artifacts to selector items.
-func artifactsToSelectorItems(artifacts []purge.ProjectArtifact) []ui.SelectorItem {
+func artifactsToSelectorItems(artifacts []purewin.Artifact) []ui.SelectorItem {
 	// Group by artifact type
-	typeGroups := make(map[string][]purge.ProjectArtifact)
+	typeGroups := make(map[string][]purewin.Artifact)
 	for _, artifact := range artifacts {
-		typeGroups[artifact.ArtifactType] = append(typeGroups[artifact.ArtifactType], artifact)
+		typeGroups[artifact.Type] = append(typeGroups[artifact.Type], artifact)
 	}
 
 	// Sort types
@@ -226,7 +329,7 @@ func artifactsToSelectorItems(artifacts []purge.ProjectArtifact) []ui.SelectorIt
 		for _, artifact := range group {
 			// Create label with project name
 			projectName := filepath.Base(artifact.ProjectPath)
-			label := fmt.Sprintf("%s/%s", projectName, artifact.ArtifactType)
+			label := fmt.Sprintf("%s/%s", projectName, artifact.Type)
 
 			// Age
 			age := time.Since(artifact.ModTime)
@@ -234,12 +337,13 @@ func artifactsToSelectorItems(artifacts []purge.ProjectArtifact) []ui.SelectorIt
 
 			item := ui.SelectorItem{
 				Label:       label,
-				Description: fmt.Sprintf("%s • %s old", artifact.ArtifactPath, ageStr),
-				Value:       artifact.ArtifactPath,
+				Description: fmt.Sprintf("%s • %s old", artifact.Path, ageStr),
+				Value:       artifact.Path,
 				Size:        core.FormatSize(artifact.Size),
-				Selected:    !artifact.IsRecent, // Don't select recent artifacts by default
+				Risk:        artifact.Risk(),
+				Selected:    !artifact.Recent, // Don't select recent artifacts by default
 				Disabled:    false,
-				Category:    artifact.ArtifactType,
+				Category:    artifact.Type,
 			}
 
 			items = append(items, item)
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Risk Levels ─────────────────────────────────────────────────────────────
+
+// defaultMaxRisk caps target risk when neither --max-risk nor the config
+// says otherwise, so high-risk targets (Windows.old, memory dumps,
+// container volumes) need an explicit opt-in.
+const defaultMaxRisk = purewin.RiskMedium
+
+// addMaxRiskFlag registers --max-risk on a scanning command.
+func addMaxRiskFlag(cmd *cobra.Command) {
+	cmd.Flags().String("max-risk", "", "Highest target risk to include: low, medium or high (default medium)")
+}
+
+// maxRisk resolves the risk cap for a run: --max-risk when given (cmd may
+// be nil), else max_risk in config, else medium. A fleet policy's cap wins
+// when it is stricter.
+func maxRisk(cmd *cobra.Command, cfg *config.Config, fr *fleetRun) (string, error) {
+	level, source := cfg.MaxRisk, "max_risk in config"
+	if cmd != nil && cmd.Flags().Changed("max-risk") {
+		level, _ = cmd.Flags().GetString("max-risk")
+		source = "--max-risk"
+	}
+	if level == "" {
+		level = defaultMaxRisk
+	}
+	if !purewin.ValidRisk(level) {
+		return "", fmt.Errorf("invalid %s %q (want low, medium or high)", source, level)
+	}
+	level = strings.ToLower(level)
+	if policyCap := fr.maxRisk(); policyCap != "" && !purewin.RiskWithin(level, policyCap) {
+		level = strings.ToLower(policyCap)
+	}
+	return level, nil
+}
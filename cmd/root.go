@@ -16,10 +16,11 @@ import (
 
 var (
 	// Global flags
-	debug    bool
-	dryRun   bool
-	runAdmin bool
-	noColor  bool
+	debug      bool
+	dryRun     bool
+	deepDryRun bool
+	runAdmin   bool
+	noColor    bool
 
 	// Version info populated from main
 	appVersion = "dev"
@@ -82,6 +83,9 @@ func init() {
 			os.Setenv("NO_COLOR", "1")
 		}
 
+		// The machine-level admin policy can disable whole commands.
+		checkAdminCommand(cmd)
+
 		if !runAdmin {
 			return
 		}
@@ -110,6 +114,10 @@ func init() {
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(purgeCmd)
 	rootCmd.AddCommand(installerCmd)
+	rootCmd.AddCommand(guardCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(fleetCmd)
+	rootCmd.AddCommand(pendingCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(removeCmd)
@@ -249,6 +257,9 @@ func printSimpleHelp() {
 	fmt.Println("    /status       Live system health monitor")
 	fmt.Println("    /purge        Clean project build artifacts")
 	fmt.Println("    /installer    Find and remove old installer files")
+	fmt.Println("    /guard        Clean automatically when free space runs low")
+	fmt.Println("    /serve        Run a local API server for other tools")
+	fmt.Println("    /fleet        Aggregate fleet policy reports")
 	fmt.Println("    /update       Check for PureWin updates")
 	fmt.Println("    /version      Show version info")
 	fmt.Println("    /help         Show this help")
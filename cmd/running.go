@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Running Applications ────────────────────────────────────────────────────
+
+// What to do about caches whose application is running (--if-running).
+const (
+	runningSkip   = "skip"
+	runningWait   = "wait"
+	runningPrompt = "prompt"
+)
+
+// How long --if-running wait waits for applications to close, and how
+// often it looks.
+const (
+	runningWaitTimeout = 5 * time.Minute
+	runningPollEvery   = 2 * time.Second
+)
+
+// addRunningFlag registers --if-running on a command.
+func addRunningFlag(cmd *cobra.Command) {
+	cmd.Flags().String("if-running", "",
+		"Caches of running apps: skip, wait for the apps to close, or prompt to close them (default: prompt on a terminal, else skip)")
+}
+
+// runningMode returns the --if-running mode, defaulting to prompt on a
+// terminal and skip otherwise.
+func runningMode(cmd *cobra.Command) (string, error) {
+	mode, _ := cmd.Flags().GetString("if-running")
+	switch mode {
+	case runningSkip, runningWait, runningPrompt:
+		return mode, nil
+	case "":
+		if ui.IsTerminal() {
+			return runningPrompt, nil
+		}
+		return runningSkip, nil
+	}
+	return "", fmt.Errorf("invalid --if-running %q (want skip, wait or prompt)", mode)
+}
+
+// settleRunning gives running applications that own targets in scope a
+// chance to close before the scan: it waits for them or asks the user to
+// close them, per mode. Whatever still runs afterwards is skipped by the
+// scan (ScanOptions.SkipRunning) and listed with its reason.
+func settleRunning(mode string, opts purewin.ScanOptions) {
+	if mode == runningSkip {
+		return
+	}
+	for {
+		busy, err := purewin.RunningTargets(context.Background(), opts)
+		if err != nil {
+			printRunningCheckFailed(err)
+			return
+		}
+		if len(busy) == 0 {
+			return
+		}
+		if mode == runningWait {
+			waitForApps(opts, busy)
+			return
+		}
+
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  These apps are running, so their caches would be skipped:", ui.IconWarning)))
+		for _, b := range busy {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("     %-24s %s", b.Name, b.Reason())))
+		}
+		choice, err := ui.ChooseOption("Close them to clean their caches", []string{
+			"I've closed them, check again",
+			fmt.Sprintf("Wait up to %s for them to close", runningWaitTimeout),
+			"Skip their caches",
+		})
+		fmt.Println()
+		switch {
+		case err != nil, choice < 0, choice == 2:
+			return
+		case choice == 1:
+			mode = runningWait
+		}
+	}
+}
+
+// waitForApps polls until none of the targets' applications run or the
+// wait times out.
+func waitForApps(opts purewin.ScanOptions, busy []purewin.Busy) {
+	spinner := ui.NewInlineSpinner()
+	spinner.Start(fmt.Sprintf("Waiting for %s to close...", busyApps(busy)))
+	deadline := time.Now().Add(runningWaitTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(runningPollEvery)
+		var err error
+		busy, err = purewin.RunningTargets(context.Background(), opts)
+		if err != nil {
+			spinner.StopWithError("Could not check for running apps")
+			printRunningCheckFailed(err)
+			return
+		}
+		if len(busy) == 0 {
+			spinner.Stop("Apps closed")
+			return
+		}
+		spinner.UpdateMessage(fmt.Sprintf("Waiting for %s to close...", busyApps(busy)))
+	}
+	spinner.Stop(fmt.Sprintf("Still running after %s: %s — skipping their caches",
+		runningWaitTimeout, busyApps(busy)))
+}
+
+// printRunningCheckFailed tells the user running applications could not
+// be checked, so their caches may be in use while they are cleaned.
+func printRunningCheckFailed(err error) {
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  Could not check which apps are running (%v) — caches of open apps may be cleaned while in use",
+		ui.IconWarning, err)))
+}
+
+// busyApps lists the running executables behind busy targets, once each.
+func busyApps(busy []purewin.Busy) string {
+	var apps []string
+	seen := make(map[string]bool)
+	for _, b := range busy {
+		for _, p := range b.Processes {
+			if !seen[strings.ToLower(p)] {
+				seen[strings.ToLower(p)] = true
+				apps = append(apps, p)
+			}
+		}
+	}
+	return strings.Join(apps, ", ")
+}
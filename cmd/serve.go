@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/clean"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/server"
+	"github.com/lakshaymaurya-felt/purewin/internal/status"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+const (
+	// defaultServeListen is the default TCP address for pw serve.
+	defaultServeListen = "127.0.0.1:7878"
+
+	// serveTokenFile is the token file name under the config directory.
+	serveTokenFile = "serve.token"
+
+	// envServeToken overrides the API token.
+	envServeToken = "PW_SERVE_TOKEN"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local API server for other tools",
+	Long: `Expose PureWin's scan and clean engine as a local JSON-RPC 2.0 API.
+
+Methods (POST /rpc): scan, plan, execute, status.metrics, analyze.tree,
+history, job.get, job.cancel, job.list, version. Long-running methods return
+a job ID; poll it with job.get or GET /jobs/{id}, or stream progress as
+NDJSON from GET /jobs/{id}/stream.
+
+Every request must send "Authorization: Bearer <token>". The token is taken
+from --token, then PW_SERVE_TOKEN, then serve.token in the config directory
+(generated on first use).`,
+	Example: `  pw serve
+  pw serve --listen 127.0.0.1:9000
+  pw serve --pipe purewin`,
+	Run: runServe,
+}
+
+func init() {
+	serveCmd.Flags().String("listen", defaultServeListen, "TCP address to listen on (empty to disable)")
+	serveCmd.Flags().String("pipe", "", `Also listen on a named pipe (e.g. purewin → \\.\pipe\purewin)`)
+	serveCmd.Flags().String("token", "", "API token (default: PW_SERVE_TOKEN or the generated token file)")
+	serveCmd.Flags().Bool("allow-remote", false, "Allow listening on a non-loopback address")
+}
+
+// ─── Main Entry Point ────────────────────────────────────────────────────────
+
+func runServe(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	listen, _ := cmd.Flags().GetString("listen")
+	pipeName, _ := cmd.Flags().GetString("pipe")
+	allowRemote, _ := cmd.Flags().GetBool("allow-remote")
+	tokenFlag, _ := cmd.Flags().GetString("token")
+
+	if listen == "" && pipeName == "" {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Nothing to listen on: set --listen or --pipe", ui.IconError)))
+		os.Exit(1)
+	}
+	if listen != "" && !allowRemote && !isLoopbackAddr(listen) {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s Refusing to listen on non-loopback address %s (use --allow-remote)",
+			ui.IconError, listen)))
+		os.Exit(1)
+	}
+
+	token, tokenSource, err := resolveServeToken(cfg, tokenFlag)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	srv, err := server.New(server.Options{
+		Token:       token,
+		Engine:      &serveEngine{cfg: cfg, isAdmin: core.IsElevated(), fleet: loadFleet(cfg)},
+		HistoryPath: historyPath(cfg),
+		Version:     appVersion,
+	})
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	var listeners []net.Listener
+	if listen != "" {
+		l, err := net.Listen("tcp", listen)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s Cannot listen on %s: %v", ui.IconError, listen, err)))
+			os.Exit(1)
+		}
+		listeners = append(listeners, l)
+	}
+	if pipeName != "" {
+		l, err := server.ListenPipe(pipeName)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		listeners = append(listeners, l)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("PureWin API", 55))
+	for _, l := range listeners {
+		fmt.Printf("  %s Listening on %s\n", ui.IconArrow, l.Addr())
+	}
+	fmt.Println(ui.MutedStyle().Render("  Token: " + tokenSource))
+	fmt.Println(ui.MutedStyle().Render("  Press Ctrl+C to stop."))
+	fmt.Println()
+
+	errCh := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func(l net.Listener) { errCh <- srv.Serve(l) }(l)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s Server error: %v", ui.IconError, err)))
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+	for _, l := range listeners {
+		_ = l.Close()
+	}
+	fmt.Println(ui.MutedStyle().Render("  Server stopped."))
+	fmt.Println()
+}
+
+// isLoopbackAddr reports whether a host:port address is on a loopback
+// interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// resolveServeToken returns the API token and a description of where it
+// came from. Without a flag or environment variable, the token is read from
+// (or generated into) the token file in the config directory.
+func resolveServeToken(cfg *config.Config, flagToken string) (string, string, error) {
+	if flagToken != "" {
+		return flagToken, "from --token", nil
+	}
+	if env := os.Getenv(envServeToken); env != "" {
+		return env, "from " + envServeToken, nil
+	}
+
+	path := filepath.Join(cfg.ConfigDir, serveTokenFile)
+	if data, err := os.ReadFile(path); err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, path, nil
+		}
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("cannot generate token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+	if err := os.MkdirAll(cfg.ConfigDir, 0o755); err != nil {
+		return "", "", fmt.Errorf("cannot create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", "", fmt.Errorf("cannot write token file: %w", err)
+	}
+	return token, path + " (generated)", nil
+}
+
+// ─── Engine ──────────────────────────────────────────────────────────────────
+
+// serveEngine implements server.Engine on top of the purewin SDK and the
+// status package.
+type serveEngine struct {
+	cfg     *config.Config
+	isAdmin bool
+	fleet   *fleetRun
+}
+
+// Scan runs the clean scanners for the requested profile and categories.
+func (e *serveEngine) Scan(ctx context.Context, p server.ScanParams, progress func(string)) ([]report.Item, error) {
+	profile, err := e.fleet.checkProfile(p.Profile, len(p.Categories) > 0)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := loadAdminLock()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := lock.serveCategories(p.Categories, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	risk, err := maxRisk(nil, e.cfg, e.fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	progress("Scanning for cleanable files...")
+	scan, err := purewin.Scan(ctx, purewin.ScanOptions{
+		Categories:       categories,
+		Whitelist:        e.fleet.applyWhitelist(loadWhitelist(e.cfg)),
+		SkipAdminTargets: !e.isAdmin,
+		MaxRisk:          risk,
+		ExcludeTargets:   lock.excludedTargets(),
+		SkipRunning:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range scan.Busy {
+		progress(fmt.Sprintf("Skipped %s: %s", b.Name, b.Reason()))
+	}
+	progress(fmt.Sprintf("Found %d items (%s)", scan.ItemCount, core.FormatSize(scan.TotalSize)))
+	return reportItems(scan), nil
+}
+
+// Execute deletes plan items via SafeDelete, logging each one.
+func (e *serveEngine) Execute(ctx context.Context, items []report.Item, dryRun bool, progress func(string)) (report.Session, error) {
+	if e.fleet != nil && e.fleet.policy.RequireDryRun {
+		dryRun = true
+	}
+	// Plans come from clients, so the admin policy is applied again here
+	// rather than trusting that they came from Scan.
+	lock, err := loadAdminLock()
+	if err != nil {
+		return report.Session{}, err
+	}
+	if lock != nil {
+		dryRun = lock.policy.DryRun(dryRun)
+		var allowed []report.Item
+		for _, item := range items {
+			if lock.policy.CategoryAllowed(item.Category) {
+				allowed = append(allowed, item)
+			}
+		}
+		items = fitBudget(lock, allowed, func(item report.Item) int64 { return item.Size })
+	}
+	limits, err := safetyLimits(e.cfg)
+	if err != nil {
+		return report.Session{}, err
+	}
+	hs := newCmdSession(e.cfg, "serve")
+	defer hs.close()
+	hs.useFleet(e.fleet, "")
+	if !dryRun {
+		if err := hs.runner.Run(hooks.PreDelete, nil); err != nil {
+			return report.Session{}, err
+		}
+	}
+
+	var logger *core.Logger
+	if !dryRun {
+		if l, err := core.NewLogger(e.cfg.LogFile); err == nil {
+			logger = l
+			defer logger.Close()
+			logger.LogSession("serve execute")
+		}
+	}
+
+	targets := make([]purewin.Item, len(items))
+	itemCategory := make(map[string]string, len(items))
+	for i, item := range items {
+		targets[i] = purewin.Item{Path: item.Path, Root: item.Root}
+		itemCategory[item.Path] = item.Category
+	}
+	// Clients cannot answer a prompt mid-run, so the breaker aborts.
+	var rb *runBreaker
+	if !dryRun {
+		rb = newRunBreaker(limits, clean.ExpectedRoots(), items, false, logger)
+	}
+	done := 0
+	res, ctxErr := purewin.DeleteItems(ctx, targets, purewin.DeleteOptions{
+		DryRun: dryRun,
+		Before: rb.before,
+		OnItem: func(r purewin.ItemResult) {
+			done++
+			progress(fmt.Sprintf("[%d/%d] %s", done, len(targets), r.Path))
+			if r.Err == nil {
+				hs.categories.add(itemCategory[r.Path], r.Freed)
+				rb.record(r.Path, r.Freed)
+			}
+			if logger != nil {
+				logger.Log("DELETE", r.Path, r.Freed, r.Err)
+			}
+		},
+	})
+
+	rb.finish()
+	if logger != nil {
+		logger.LogSummary(res.Freed, res.Deleted, len(res.Failed))
+	}
+	result := hs.finish(dryRun, res.Freed, res.Deleted, len(res.Failed))
+	return result, ctxErr
+}
+
+// Metrics collects a single system metrics snapshot.
+func (e *serveEngine) Metrics(ctx context.Context) (any, error) {
+	return status.CollectMetrics(nil, 0)
+}
+
+// AnalyzeTree scans path and returns its size tree down to depth levels.
+func (e *serveEngine) AnalyzeTree(ctx context.Context, path string, depth int, progress func(string)) (any, error) {
+	return purewin.Analyze(ctx, path, purewin.AnalyzeOptions{
+		Depth: depth,
+		Progress: func(scanned int64) {
+			progress(fmt.Sprintf("Scanned %d entries", scanned))
+		},
+	})
+}
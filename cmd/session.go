@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/hooks"
+	"github.com/lakshaymaurya-felt/purewin/internal/notify"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+)
+
+// cmdSession tracks one command invocation: it runs the configured hooks,
+// writing their output to the operations log, and sends the session result
+// to the configured webhooks (and fleet reports directory) when the command
+// finishes.
+type cmdSession struct {
+	runner      *hooks.Runner
+	notifier    *notify.Notifier
+	logger      *core.Logger
+	historyPath string
+	command     string
+	started     time.Time
+	fleet       *fleetRun
+	profile     string
+	categories  categoryTotals
+}
+
+// newCmdSession prepares hooks and notifications for the given pw command.
+// The op log is only opened when at least one hook applies.
+func newCmdSession(cfg *config.Config, command string) *cmdSession {
+	hs := &cmdSession{
+		runner:     hooks.NewRunner(cfg.Hooks, command, nil),
+		notifier:   newNotifier(cfg),
+		command:    command,
+		started:    time.Now(),
+		categories: make(categoryTotals),
+	}
+	if cfg.ConfigDir != "" {
+		hs.historyPath = historyPath(cfg)
+	}
+	if !hs.runner.Has() {
+		return hs
+	}
+
+	if logger, err := core.NewLogger(cfg.LogFile); err == nil {
+		hs.logger = logger
+		hs.runner = hooks.NewRunner(cfg.Hooks, command, logger)
+	}
+	return hs
+}
+
+// run executes the hooks for a stage. A failing pre-stage hook is reported
+// as an error and returned so the caller can abort; post-stage failures are
+// reported as warnings.
+func (hs *cmdSession) run(stage hooks.Stage, payload any) error {
+	err := hs.runner.Run(stage, payload)
+	if err == nil {
+		return nil
+	}
+
+	var abort *hooks.AbortError
+	if errors.As(err, &abort) {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconError, err)))
+		return err
+	}
+	fmt.Println(ui.WarningStyle().Render(
+		fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	return nil
+}
+
+// mustRun runs a pre-stage hook and exits the process if it aborts.
+func (hs *cmdSession) mustRun(stage hooks.Stage, payload any) {
+	if err := hs.run(stage, payload); err != nil {
+		hs.close()
+		fmt.Println()
+		os.Exit(1)
+	}
+}
+
+// useFleet attaches the fleet policy and the profile in use, so finish
+// drops a fleet report.
+func (hs *cmdSession) useFleet(f *fleetRun, profile string) {
+	hs.fleet = f
+	hs.profile = profile
+}
+
+// finish records the session in the history file, runs the post-session
+// hooks and sends the session result to the configured webhooks and the
+// fleet reports directory.
+func (hs *cmdSession) finish(dryRun bool, freed int64, items, errCount int) report.Session {
+	result := hs.sessionReport(dryRun, freed, items, errCount)
+	if len(hs.categories) > 0 {
+		result.Categories = hs.categories
+	}
+	if hs.historyPath != "" {
+		_ = report.AppendHistory(hs.historyPath, result)
+	}
+	_ = hs.run(hooks.PostSession, result)
+
+	if hs.notifier.Enabled() {
+		if err := hs.notifier.Send(notify.NewSessionEvent(result)); err != nil {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+		}
+	}
+
+	if hs.fleet != nil {
+		if err := hs.fleet.writeReport(hs.profile, result); err != nil {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s  Could not write fleet report: %v", ui.IconWarning, err)))
+		}
+	}
+	return result
+}
+
+// sessionReport builds the result document for this session.
+func (hs *cmdSession) sessionReport(dryRun bool, freed int64, items, errCount int) report.Session {
+	host, _ := os.Hostname()
+	return report.Session{
+		Command:  hs.command,
+		Hostname: host,
+		DryRun:   dryRun,
+		Started:  hs.started,
+		Finished: time.Now(),
+		Freed:    freed,
+		Items:    items,
+		Errors:   errCount,
+	}
+}
+
+// historyPath returns the session history file under the config directory.
+func historyPath(cfg *config.Config) string {
+	return filepath.Join(cfg.ConfigDir, "history.jsonl")
+}
+
+// newNotifier creates the webhook notifier from config, spooling failed
+// deliveries under the config directory by default.
+func newNotifier(cfg *config.Config) *notify.Notifier {
+	spoolDir := ""
+	if cfg.ConfigDir != "" {
+		spoolDir = filepath.Join(cfg.ConfigDir, "spool")
+	}
+	return notify.New(cfg.Notify, spoolDir)
+}
+
+// close releases the op log handle, if one was opened.
+func (hs *cmdSession) close() {
+	if hs.logger != nil {
+		hs.logger.Close()
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Secure Deletion ─────────────────────────────────────────────────────────
+
+// shredCategories are the clean categories whose files may hold
+// credentials or tokens: browser and Electron app data, and the temp
+// files and leftovers in the user category.
+var shredCategories = map[string]bool{
+	"browser":  true,
+	"electron": true,
+	"user":     true,
+}
+
+// addShredFlags registers --shred and --shred-passes on a deleting command.
+func addShredFlags(cmd *cobra.Command) {
+	addShredFlagsFor(cmd, "Overwrite browser, app and temp files before deleting them")
+}
+
+// addShredFlagsFor registers --shred, described by usage, and
+// --shred-passes.
+func addShredFlagsFor(cmd *cobra.Command, usage string) {
+	cmd.Flags().Bool("shred", false, usage)
+	cmd.Flags().Int("shred-passes", 0, "Overwrite passes for --shred (default from config, else 1)")
+}
+
+// shredOptions returns the shred settings from --shred and --shred-passes,
+// falling back to safety.shred_passes in config, or nil when --shred is
+// not set. Given categoryOf, only files in shredCategories are shredded;
+// with a nil categoryOf every file is.
+func shredOptions(cmd *cobra.Command, cfg *config.Config, categoryOf map[string]string) *purewin.ShredOptions {
+	if on, _ := cmd.Flags().GetBool("shred"); !on {
+		return nil
+	}
+	passes := cfg.Safety.ShredPasses
+	if cmd.Flags().Changed("shred-passes") {
+		passes, _ = cmd.Flags().GetInt("shred-passes")
+	}
+	if passes < 1 {
+		passes = 1
+	}
+	opts := &purewin.ShredOptions{Passes: passes}
+	if categoryOf != nil {
+		opts.Only = func(path string) bool {
+			return shredCategories[categoryOf[path]]
+		}
+	}
+	return opts
+}
+
+// startShred warns about TRIM on the volumes holding the paths shred
+// applies to and logs how many will be shredded. It does nothing when
+// shred is nil.
+func startShred(shred *purewin.ShredOptions, paths []string, logger *core.Logger) {
+	if shred == nil {
+		return
+	}
+	var shredPaths []string
+	for _, p := range paths {
+		if shred.Only == nil || shred.Only(p) {
+			shredPaths = append(shredPaths, p)
+		}
+	}
+	warnShredLimits(shredPaths)
+	if logger != nil {
+		logger.LogEvent("SHRED", fmt.Sprintf("passes=%d items=%d", shred.Passes, len(shredPaths)))
+	}
+}
+
+// deleteOp returns the log operation for deleting path: SHRED when shred
+// overwrites it, DELETE otherwise.
+func deleteOp(shred *purewin.ShredOptions, path string) string {
+	if shred != nil && (shred.Only == nil || shred.Only(path)) {
+		return "SHRED"
+	}
+	return "DELETE"
+}
+
+// warnShredLimits tells the user which of the volumes holding paths are on
+// TRIM-enabled devices, where overwriting cannot promise the old data is
+// gone.
+func warnShredLimits(paths []string) {
+	seen := make(map[string]bool)
+	var trimmed []string
+	for _, p := range paths {
+		vol := strings.ToUpper(filepath.VolumeName(p))
+		if vol == "" || seen[vol] {
+			continue
+		}
+		seen[vol] = true
+		if trim, err := purewin.VolumeHasTrim(p); err == nil && trim {
+			trimmed = append(trimmed, vol)
+		}
+	}
+	if len(trimmed) == 0 {
+		return
+	}
+	sort.Strings(trimmed)
+	verb := "is an SSD"
+	if len(trimmed) > 1 {
+		verb = "are SSDs"
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  %s %s with TRIM: shredding overwrites each file's current blocks,",
+		ui.IconWarning, strings.Join(trimmed, ", "), verb)))
+	fmt.Println(ui.MutedStyle().Render(
+		"     but the drive may keep older copies elsewhere. Use BitLocker for full protection."))
+}
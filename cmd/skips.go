@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/ui"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+// ─── Skip Summary ────────────────────────────────────────────────────────────
+
+// skipSummary groups the paths a run could not delete by reason, so a
+// summary can tell harmless locks from a permissions problem.
+type skipSummary struct {
+	paths map[string][]string
+	total int
+
+	// predicted marks failures a deep dry run expects rather than ones
+	// that happened.
+	predicted bool
+}
+
+// add records that path was skipped because of err.
+func (s *skipSummary) add(path string, err error) {
+	s.addReason(path, core.SkipReason(err))
+}
+
+// addReason records that path was skipped for reason, one of
+// core.SkipReasons.
+func (s *skipSummary) addReason(path, reason string) {
+	if s.paths == nil {
+		s.paths = make(map[string][]string)
+	}
+	s.paths[reason] = append(s.paths[reason], path)
+	s.total++
+}
+
+// addResults records every failed item of a delete result.
+func (s *skipSummary) addResults(res *purewin.DeleteResult) {
+	for _, f := range res.Failed {
+		s.add(f.Path, f.Err)
+	}
+}
+
+// count returns the number of skipped paths.
+func (s *skipSummary) count() int {
+	return s.total
+}
+
+// print writes the per-reason counts, listing the paths under each reason
+// when verbose is set.
+func (s *skipSummary) print(noun string, verbose bool) {
+	if s.total == 0 {
+		return
+	}
+	verb, listed := "skipped", "the skipped paths"
+	if s.predicted {
+		verb, listed = "would fail", "them"
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  %d %s %s:", ui.IconWarning, s.total, noun, verb)))
+	for _, reason := range core.SkipReasons {
+		paths := s.paths[reason]
+		if len(paths) == 0 {
+			continue
+		}
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("     %-14s %d", reason, len(paths))))
+		if verbose {
+			for _, p := range paths {
+				fmt.Println(ui.MutedStyle().Render("       " + p))
+			}
+		}
+	}
+	if !verbose {
+		fmt.Println(ui.MutedStyle().Render("  → Run with --debug to list " + listed + "."))
+	}
+}
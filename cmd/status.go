@@ -7,6 +7,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/notify"
 	"github.com/lakshaymaurya-felt/purewin/internal/status"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 	"github.com/spf13/cobra"
@@ -28,6 +30,13 @@ func runStatus(cmd *cobra.Command, args []string) {
 	jsonMode, _ := cmd.Flags().GetBool("json")
 	refreshSecs, _ := cmd.Flags().GetInt("refresh")
 
+	// Alerts are optional; run without them if config can't be loaded.
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = &config.Config{}
+	}
+	notifier := newNotifier(cfg)
+
 	if jsonMode {
 		// Single-shot: collect once, print JSON, exit.
 		metrics, err := status.CollectMetrics(nil, 0)
@@ -37,6 +46,7 @@ func runStatus(cmd *cobra.Command, args []string) {
 		}
 		data, _ := json.MarshalIndent(metrics, "", "  ")
 		fmt.Println(string(data))
+		sendStatusAlerts(notifier, status.CheckAlerts(metrics, cfg.Notify.Alerts))
 		return
 	}
 
@@ -53,14 +63,31 @@ func runStatus(cmd *cobra.Command, args []string) {
 		}
 		data, _ := json.MarshalIndent(metrics, "", "  ")
 		fmt.Println(string(data))
+		sendStatusAlerts(notifier, status.CheckAlerts(metrics, cfg.Notify.Alerts))
 		return
 	}
 
 	interval := time.Duration(refreshSecs) * time.Second
 	model := status.NewStatusModel(interval)
+	if notifier.Enabled() {
+		model = model.WithAlerts(cfg.Notify.Alerts, notifier)
+	}
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// sendStatusAlerts delivers alerts from a single-shot status run. Each
+// tripped alert is sent, since there is no earlier reading to compare with.
+func sendStatusAlerts(n *notify.Notifier, alerts []notify.Alert) {
+	if !n.Enabled() {
+		return
+	}
+	for _, a := range alerts {
+		if err := n.Send(notify.NewAlertEvent(a)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/budget"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
 	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 	"github.com/lakshaymaurya-felt/purewin/internal/uninstall"
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
 )
 
 var uninstallCmd = &cobra.Command{
@@ -24,6 +30,7 @@ func init() {
 	uninstallCmd.Flags().Bool("quiet", false, "Prefer silent uninstall commands")
 	uninstallCmd.Flags().Bool("show-all", false, "Show system components too")
 	uninstallCmd.Flags().String("search", "", "Search for apps by name")
+	addShredFlagsFor(uninstallCmd, "Offer to overwrite and remove what uninstallers leave in each app's install location (never under Program Files or ProgramData)")
 }
 
 func runUninstall(cmd *cobra.Command, args []string) {
@@ -36,10 +43,22 @@ func runUninstall(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 
+	dryRun = mustLoadAdminLock().forceDryRun(dryRun)
+
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	showAll, _ := cmd.Flags().GetBool("show-all")
 	search, _ := cmd.Flags().GetString("search")
 
+	// Session hooks and notifications are optional; run without them if
+	// config can't be loaded.
+	cfg, cfgErr := config.Load()
+	if cfgErr != nil {
+		cfg = &config.Config{}
+	}
+	hs := newCmdSession(cfg, "uninstall")
+	defer hs.close()
+	shred := shredOptions(cmd, cfg, nil)
+
 	// Scan installed apps from the registry.
 	fmt.Println()
 	spin := ui.NewInlineSpinner()
@@ -66,17 +85,25 @@ func runUninstall(cmd *cobra.Command, args []string) {
 
 	// Quick single-app uninstall if --quiet + --search yields exactly one result.
 	if quiet && search != "" && len(apps) == 1 {
-		runSingleUninstall(apps[0], dryRun, quiet)
+		if runSingleUninstall(apps[0], dryRun, quiet) {
+			shredLeftovers(cfg, apps[:1], shred)
+			hs.finish(dryRun, apps[0].EstimatedSize, 1, 0)
+		}
 		return
 	}
 
 	// Batch uninstall flow with selector.
-	if err := uninstall.RunBatchUninstall(apps, dryRun); err != nil {
+	result, err := uninstall.RunBatchUninstall(apps, dryRun)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "\n%s %s\n",
 			ui.ErrorStyle().Render(ui.IconError),
 			ui.ErrorStyle().Render(err.Error()))
 		os.Exit(1)
 	}
+	shredLeftovers(cfg, result.Removed, shred)
+	if result.Uninstalled+result.Failed > 0 {
+		hs.finish(dryRun, result.Freed, result.Uninstalled, result.Failed)
+	}
 }
 
 // filterAppsByName returns apps whose Name contains the search term
@@ -92,17 +119,18 @@ func filterAppsByName(apps []uninstall.InstalledApp, search string) []uninstall.
 	return filtered
 }
 
-// runSingleUninstall handles uninstalling a single app directly.
-func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) {
+// runSingleUninstall handles uninstalling a single app directly. It reports
+// whether the app was uninstalled.
+func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) bool {
 	if dryRun {
 		fmt.Printf("\n  DRY RUN: Would uninstall %s\n", app.Name)
-		return
+		return false
 	}
 
 	confirmed, err := ui.Confirm(fmt.Sprintf("Uninstall %s?", app.Name))
 	if err != nil || !confirmed {
 		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
-		return
+		return false
 	}
 
 	spin := ui.NewInlineSpinner()
@@ -113,4 +141,137 @@ func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) {
 		os.Exit(1)
 	}
 	spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
+	return true
+}
+
+// shredLeftovers offers to shred what the uninstallers of apps left in
+// their install locations. It runs once the uninstallers' processes have
+// all exited (see uninstall.UninstallApp), so only folders that are still
+// there are listed, with their sizes, and nothing is shredded until the
+// user confirms. It does nothing when shred is nil.
+func shredLeftovers(cfg *config.Config, apps []uninstall.InstalledApp, shred *purewin.ShredOptions) {
+	if shred == nil || len(apps) == 0 {
+		return
+	}
+	installed, err := uninstall.GetInstalledApps(true)
+	if err != nil {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s  Could not re-read installed apps (%v) — leftovers were not shredded", ui.IconWarning, err)))
+		return
+	}
+	dirs, protected := leftoverDirs(apps, installed, loadWhitelist(cfg))
+	if protected > 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  %d install locations are under protected folders (Program Files, ProgramData) and were left alone", protected)))
+	}
+	if len(dirs) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.HeaderStyle().Render("  Leftover folders:"))
+	items := make([]purewin.Item, len(dirs))
+	var total int64
+	for i, dir := range dirs {
+		size, _ := core.GetDirSize(dir)
+		total += size
+		fmt.Printf("  %s %s (%s)\n", ui.IconBullet, dir, core.FormatSize(size))
+		items[i] = purewin.Item{Path: dir, Root: dir}
+	}
+	fmt.Println()
+	ok, err := ui.DangerConfirm(fmt.Sprintf(
+		"Shred %d leftover folders (%s)? Shredded files cannot be recovered.", len(dirs), core.FormatSize(total)))
+	if err != nil || !ok {
+		fmt.Println(ui.MutedStyle().Render("  Leftovers kept."))
+		return
+	}
+
+	logger, logErr := core.NewLogger(cfg.LogFile)
+	if logErr != nil {
+		logger = nil
+	} else {
+		defer logger.Close()
+		logger.LogSession("uninstall --shred")
+	}
+	startShred(shred, dirs, logger)
+
+	spin := ui.NewInlineSpinner()
+	spin.Start("Shredding leftovers...")
+	res, _ := purewin.DeleteItems(context.Background(), items, purewin.DeleteOptions{
+		Shred: shred,
+		OnItem: func(r purewin.ItemResult) {
+			if logger != nil {
+				logger.Log(deleteOp(shred, r.Path), r.Path, r.Freed, r.Err)
+			}
+		},
+	})
+	spin.Stop(fmt.Sprintf("Shredded %s of leftovers in %d folders", core.FormatSize(res.Freed), res.Deleted))
+
+	var skips skipSummary
+	skips.addResults(res)
+	skips.print("folders", debug || cfg.DebugMode)
+	if logger != nil {
+		logger.LogSummary(res.Freed, res.Deleted, len(res.Failed))
+	}
+}
+
+// leftoverDirs returns the install locations of removed apps that may be
+// shredded, and how many were skipped only for being protected paths. A
+// location is left alone when it is gone, whitelisted, too close to a
+// drive root to belong to one app, or protected; when the app is still
+// registered (the user canceled its uninstaller, say); and when it equals,
+// contains or lies inside a still-installed app's location, as a shared
+// vendor folder or a game library under a launcher's folder does.
+func leftoverDirs(removed, installed []uninstall.InstalledApp, wl *whitelist.Whitelist) (dirs []string, protected int) {
+	stillInstalled := make(map[string]bool, len(installed))
+	for _, app := range installed {
+		stillInstalled[strings.ToLower(app.Name+"|"+app.Version)] = true
+	}
+	seen := make(map[string]bool)
+	for _, app := range removed {
+		loc := filepath.Clean(app.InstallLocation)
+		if app.InstallLocation == "" || !filepath.IsAbs(loc) || appDirDepth(loc) < 2 || seen[strings.ToLower(loc)] {
+			continue
+		}
+		seen[strings.ToLower(loc)] = true
+		if stillInstalled[strings.ToLower(app.Name+"|"+app.Version)] || sharesLocation(loc, installed) {
+			continue
+		}
+		if info, err := os.Lstat(loc); err != nil || !info.IsDir() {
+			continue
+		}
+		if wl != nil && wl.IsWhitelisted(loc) {
+			continue
+		}
+		if !core.IsSafePath(loc) {
+			protected++
+			continue
+		}
+		dirs = append(dirs, loc)
+	}
+	return dirs, protected
+}
+
+// sharesLocation reports whether loc equals, contains or lies inside the
+// install location of any of apps.
+func sharesLocation(loc string, apps []uninstall.InstalledApp) bool {
+	for _, app := range apps {
+		other := filepath.Clean(app.InstallLocation)
+		if app.InstallLocation == "" || !filepath.IsAbs(other) {
+			continue
+		}
+		if budget.Within(loc, other) || budget.Within(other, loc) {
+			return true
+		}
+	}
+	return false
+}
+
+// appDirDepth returns how many directories below its drive root path is.
+func appDirDepth(path string) int {
+	rest := strings.Trim(strings.TrimPrefix(path, filepath.VolumeName(path)), `\/`)
+	if rest == "" {
+		return 0
+	}
+	return len(strings.FieldsFunc(rest, func(r rune) bool { return r == '\\' || r == '/' }))
 }
@@ -0,0 +1,141 @@
+// Package adminpolicy enforces a machine-level policy that administrators
+// place under %ProgramData%. Unlike config.json and command-line flags,
+// which belong to the user, the policy cannot be overridden: commands check
+// it before acting and refuse with an explanation.
+package adminpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Policy is the machine-level admin policy. The zero value allows
+// everything.
+type Policy struct {
+	// DisabledCommands lists pw commands that may not run at all
+	// (e.g. "uninstall", "optimize").
+	DisabledCommands []string `json:"disabled_commands,omitempty"`
+
+	// DisabledTasks lists optimize tasks that may not run, by task ID
+	// (e.g. "dism", "event_logs") or group ("services", "maintenance").
+	DisabledTasks []string `json:"disabled_tasks,omitempty"`
+
+	// ForbiddenCategories lists clean categories that may not be cleaned
+	// (user, browser, electron, dev, system).
+	ForbiddenCategories []string `json:"forbidden_categories,omitempty"`
+
+	// ForbiddenTargets lists clean targets that may not be cleaned, by
+	// name (e.g. "WindowsOld", "RecycleBin").
+	ForbiddenTargets []string `json:"forbidden_targets,omitempty"`
+
+	// MaxBytesPerRun caps how much a single run may delete (e.g. "20GB").
+	MaxBytesPerRun string `json:"max_bytes_per_run,omitempty"`
+
+	// ForceDryRun turns every destructive command into a preview.
+	ForceDryRun bool `json:"force_dry_run,omitempty"`
+
+	// Source is the file the policy was loaded from; empty when no policy
+	// file exists.
+	Source string `json:"-"`
+}
+
+// Refusal is returned when the policy blocks an action.
+type Refusal struct {
+	// Source is the policy file responsible.
+	Source string
+
+	// What describes the blocked action.
+	What string
+}
+
+func (r *Refusal) Error() string {
+	return fmt.Sprintf("%s is disabled by administrator policy (%s)", r.What, r.Source)
+}
+
+// Load reads the policy at path. A missing file yields an empty policy and
+// no error. An unreadable or malformed file is an error: callers should
+// refuse to run rather than ignore a policy they cannot read.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Policy{}, nil
+		}
+		return nil, fmt.Errorf("cannot read administrator policy %s: %w", path, err)
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid administrator policy %s: %w", path, err)
+	}
+	p.Source = path
+	return &p, nil
+}
+
+// Active reports whether a policy file was loaded.
+func (p *Policy) Active() bool {
+	return p != nil && p.Source != ""
+}
+
+// CheckCommand returns a *Refusal if the command is disabled.
+func (p *Policy) CheckCommand(name string) error {
+	if p == nil || !contains(p.DisabledCommands, name) {
+		return nil
+	}
+	return &Refusal{Source: p.Source, What: fmt.Sprintf("pw %s", name)}
+}
+
+// TaskAllowed reports whether an optimize task may run, checking both its
+// ID and its group.
+func (p *Policy) TaskAllowed(group, id string) bool {
+	if p == nil {
+		return true
+	}
+	return !contains(p.DisabledTasks, group) && !contains(p.DisabledTasks, id)
+}
+
+// CategoryAllowed reports whether a clean category may be cleaned.
+func (p *Policy) CategoryAllowed(category string) bool {
+	return p == nil || !contains(p.ForbiddenCategories, category)
+}
+
+// TargetAllowed reports whether a clean target may be cleaned.
+func (p *Policy) TargetAllowed(name string) bool {
+	return p == nil || !contains(p.ForbiddenTargets, name)
+}
+
+// DryRun returns dryRun, or true if the policy forces dry-run.
+func (p *Policy) DryRun(dryRun bool) bool {
+	return dryRun || (p != nil && p.ForceDryRun)
+}
+
+// contains reports whether list has s, ignoring case.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ─── Budget ──────────────────────────────────────────────────────────────────
+
+// Fit returns the indexes of the items, in order, whose sizes fit within
+// limit bytes in total. Items that would overflow the limit are skipped
+// and later, smaller items are still considered. A limit of zero or less
+// keeps everything.
+func Fit(sizes []int64, limit int64) []int {
+	keep := make([]int, 0, len(sizes))
+	var used int64
+	for i, size := range sizes {
+		if limit > 0 && used+size > limit {
+			continue
+		}
+		used += size
+		keep = append(keep, i)
+	}
+	return keep
+}
@@ -0,0 +1,115 @@
+package adminpolicy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadMissingIsEmpty(t *testing.T) {
+	p, err := Load(filepath.Join(t.TempDir(), "policy.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if p.Active() {
+		t.Error("missing policy should not be active")
+	}
+	if err := p.CheckCommand("uninstall"); err != nil {
+		t.Errorf("CheckCommand() = %v, want nil", err)
+	}
+	if p.DryRun(false) {
+		t.Error("empty policy should not force dry-run")
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() of malformed policy should fail")
+	}
+}
+
+func TestPolicyRestrictions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	data := `{
+  "disabled_commands": ["Uninstall"],
+  "disabled_tasks": ["maintenance", "flush_dns"],
+  "forbidden_categories": ["system"],
+  "forbidden_targets": ["WindowsOld"],
+  "max_bytes_per_run": "10GB",
+  "force_dry_run": true
+}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !p.Active() || p.Source != path {
+		t.Errorf("Source = %q, want %q", p.Source, path)
+	}
+
+	err = p.CheckCommand("uninstall")
+	var refusal *Refusal
+	if !errors.As(err, &refusal) {
+		t.Fatalf("CheckCommand(uninstall) = %v, want *Refusal", err)
+	}
+	if p.CheckCommand("clean") != nil {
+		t.Error("clean should be allowed")
+	}
+
+	if p.TaskAllowed("maintenance", "sfc") {
+		t.Error("task in a disabled group should be refused")
+	}
+	if p.TaskAllowed("services", "flush_dns") {
+		t.Error("disabled task should be refused")
+	}
+	if !p.TaskAllowed("services", "restart_wsearch") {
+		t.Error("other service tasks should be allowed")
+	}
+
+	if p.CategoryAllowed("system") || !p.CategoryAllowed("user") {
+		t.Error("CategoryAllowed() mismatch")
+	}
+	if p.TargetAllowed("WindowsOld") || !p.TargetAllowed("RecycleBin") {
+		t.Error("TargetAllowed() mismatch")
+	}
+	if !p.DryRun(false) {
+		t.Error("force_dry_run should force dry-run")
+	}
+}
+
+func TestNilPolicyAllowsEverything(t *testing.T) {
+	var p *Policy
+	if p.CheckCommand("optimize") != nil || !p.TaskAllowed("services", "flush_dns") ||
+		!p.CategoryAllowed("system") || !p.TargetAllowed("WindowsOld") || p.DryRun(false) {
+		t.Error("nil policy should allow everything")
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []int64
+		limit int64
+		want  []int
+	}{
+		{"no limit", []int64{5, 5, 5}, 0, []int{0, 1, 2}},
+		{"all fit", []int64{1, 2, 3}, 6, []int{0, 1, 2}},
+		{"skips overflow", []int64{4, 5, 1}, 6, []int{0, 2}},
+		{"nothing fits", []int64{7, 8}, 6, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fit(tt.sizes, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"sync/atomic"
 	"syscall"
 	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/throttle"
 )
 
 // DirEntry represents a file or directory in the scan tree.
@@ -44,6 +46,7 @@ type Scanner struct {
 	mu           sync.Mutex
 	warnings     []string
 	scannedCount atomic.Int64
+	limiter      *throttle.Limiter
 }
 
 // NewScanner creates a scanner with bounded concurrency.
@@ -62,6 +65,12 @@ func NewScanner(maxConcurrency int, exclude []string) *Scanner {
 	}
 }
 
+// SetLimiter paces the scan: every directory read and entry stat waits on
+// l. A nil l (the default) scans at full speed.
+func (s *Scanner) SetLimiter(l *throttle.Limiter) {
+	s.limiter = l
+}
+
 // Warnings returns any warnings accumulated during scanning.
 func (s *Scanner) Warnings() []string {
 	s.mu.Lock()
@@ -140,6 +149,7 @@ func (s *Scanner) scanDir(entry *DirEntry) {
 	dirPath := longPath(entry.Path)
 
 	// Hold semaphore only during the ReadDir I/O.
+	s.limiter.Wait(1, 0)
 	s.sem <- struct{}{}
 	entries, err := os.ReadDir(dirPath)
 	<-s.sem
@@ -167,6 +177,7 @@ func (s *Scanner) scanDir(entry *DirEntry) {
 			continue
 		}
 
+		s.limiter.Wait(1, 0)
 		info, err := e.Info()
 		if err != nil {
 			// Permission denied or other error — skip, don't fail.
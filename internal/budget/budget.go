@@ -0,0 +1,256 @@
+// Package budget implements the deletion circuit breaker: per-run limits on
+// bytes, items and the share of any single top-level directory, plus an
+// anomaly check for paths outside the roots a run is expected to touch.
+//
+// The breaker only decides; callers ask the user (or abort, when nobody is
+// there to ask) and tell it which trips were overridden.
+package budget
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Limits are the per-run safety budgets. Zero values disable a limit.
+type Limits struct {
+	// MaxBytes caps the bytes deleted in one run.
+	MaxBytes int64
+
+	// MaxItems caps the number of paths deleted in one run.
+	MaxItems int
+
+	// MaxDirShare caps the fraction (0–1) of any single top-level directory
+	// a run may delete. Top-level directories that are themselves expected
+	// roots (a data drive's Temp folder, say) are exempt.
+	MaxDirShare float64
+}
+
+// Validate checks that the limits are in range.
+func (l Limits) Validate() error {
+	if l.MaxBytes < 0 || l.MaxItems < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	if l.MaxDirShare < 0 || l.MaxDirShare > 1 {
+		return fmt.Errorf("max_dir_share must be between 0 and 1, got %g", l.MaxDirShare)
+	}
+	return nil
+}
+
+// Trip reasons.
+const (
+	ReasonBytes    = "bytes"
+	ReasonItems    = "items"
+	ReasonDirShare = "dir_share"
+	ReasonOutside  = "outside_roots"
+)
+
+// Trip is returned when a deletion would break a limit or falls outside the
+// expected roots.
+type Trip struct {
+	// Reason is one of the Reason constants.
+	Reason string
+
+	// Path is the path that was about to be deleted.
+	Path string
+
+	// Dir is the top-level directory involved, for ReasonDirShare and
+	// ReasonOutside.
+	Dir string
+
+	// Detail describes the limit in human terms.
+	Detail string
+}
+
+func (t *Trip) Error() string {
+	return fmt.Sprintf("circuit breaker: %s (%s)", t.Detail, t.Path)
+}
+
+// Options configures a Breaker.
+type Options struct {
+	Limits Limits
+
+	// Roots are the directories (or glob patterns) the run is expected to
+	// delete from. Empty disables the outside-roots check.
+	Roots []string
+
+	// Home is the user profile directory. Top-level directories beneath it
+	// are its immediate children (AppData, Documents) rather than the
+	// profile itself.
+	Home string
+
+	// DirSize measures a directory for the share limit. Nil disables it.
+	DirSize func(dir string) int64
+}
+
+// State is the breaker's running totals.
+type State struct {
+	Limits Limits
+	Bytes  int64
+	Items  int
+	Trips  int
+}
+
+// Breaker tracks one run's deletions against its limits.
+type Breaker struct {
+	opts     Options
+	state    State
+	dirBytes map[string]int64
+	dirSize  map[string]int64
+	approved map[string]bool
+}
+
+// New creates a breaker for one run.
+func New(opts Options) *Breaker {
+	return &Breaker{
+		opts:     opts,
+		state:    State{Limits: opts.Limits},
+		dirBytes: make(map[string]int64),
+		dirSize:  make(map[string]int64),
+		approved: make(map[string]bool),
+	}
+}
+
+// Check reports whether deleting path, estimated at size bytes, stays
+// within the limits. It does not record the deletion; call Record after.
+func (b *Breaker) Check(path string, size int64) *Trip {
+	lim := b.state.Limits
+	dir := b.topDir(path)
+
+	if len(b.opts.Roots) > 0 && !b.inRoots(path) && !b.approved[dir] {
+		return b.trip(&Trip{Reason: ReasonOutside, Path: path, Dir: dir,
+			Detail: fmt.Sprintf("path is outside every expected target root, under %s", dir)})
+	}
+	if trip := b.CheckSize(path, size); trip != nil {
+		return trip
+	}
+	if lim.MaxDirShare > 0 && b.opts.DirSize != nil && dir != "" && !b.approved[dir] && !b.inRoots(dir) {
+		total, ok := b.dirSize[dir]
+		if !ok {
+			total = b.opts.DirSize(dir)
+			b.dirSize[dir] = total
+		}
+		if total > 0 && float64(b.dirBytes[dir]+size) > lim.MaxDirShare*float64(total) {
+			return b.trip(&Trip{Reason: ReasonDirShare, Path: path, Dir: dir,
+				Detail: fmt.Sprintf("run would delete more than %.0f%% of %s", lim.MaxDirShare*100, dir)})
+		}
+	}
+	return nil
+}
+
+// CheckSize reports whether one more deletion of size bytes stays within
+// the byte and item limits. It is for cleanups that are not a path (a
+// package manager's, a container prune), named by name in the trip; Check
+// calls it for paths. Record the deletion with RecordSize.
+func (b *Breaker) CheckSize(name string, size int64) *Trip {
+	lim := b.state.Limits
+	if lim.MaxItems > 0 && b.state.Items+1 > lim.MaxItems {
+		return b.trip(&Trip{Reason: ReasonItems, Path: name,
+			Detail: fmt.Sprintf("run would delete more than %d items", lim.MaxItems)})
+	}
+	if lim.MaxBytes > 0 && b.state.Bytes+size > lim.MaxBytes {
+		return b.trip(&Trip{Reason: ReasonBytes, Path: name,
+			Detail: fmt.Sprintf("run would delete more than %d bytes", lim.MaxBytes)})
+	}
+	return nil
+}
+
+// trip counts t and returns it.
+func (b *Breaker) trip(t *Trip) *Trip {
+	b.state.Trips++
+	return t
+}
+
+// Record counts a completed deletion.
+func (b *Breaker) Record(path string, freed int64) {
+	b.state.Items++
+	b.state.Bytes += freed
+	if dir := b.topDir(path); dir != "" {
+		b.dirBytes[dir] += freed
+	}
+}
+
+// RecordSize counts a completed cleanup that is not a path.
+func (b *Breaker) RecordSize(freed int64) {
+	b.state.Items++
+	b.state.Bytes += freed
+}
+
+// Override lifts the limit behind t for the rest of the run, after the user
+// chose to continue: byte and item limits are removed, and share and
+// outside-roots checks stop applying to t's directory.
+func (b *Breaker) Override(t *Trip) {
+	switch t.Reason {
+	case ReasonBytes:
+		b.state.Limits.MaxBytes = 0
+	case ReasonItems:
+		b.state.Limits.MaxItems = 0
+	case ReasonDirShare, ReasonOutside:
+		b.approved[t.Dir] = true
+	}
+}
+
+// State returns the running totals.
+func (b *Breaker) State() State {
+	return b.state
+}
+
+// inRoots reports whether path lies within any expected root.
+func (b *Breaker) inRoots(path string) bool {
+	for _, root := range b.opts.Roots {
+		if Within(path, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// topDir returns the top-level directory that holds path: the first
+// directory below the user profile when path is inside it, otherwise the
+// first directory below the volume root. It returns "" for a path directly
+// at the volume root.
+func (b *Breaker) topDir(path string) string {
+	path = filepath.Clean(path)
+	base := ""
+	if b.opts.Home != "" {
+		home := filepath.Clean(b.opts.Home)
+		if hasPathPrefix(path, home) && len(path) > len(home) {
+			base = home
+		}
+	}
+	if base == "" {
+		base = filepath.VolumeName(path) + string(filepath.Separator)
+	}
+	rest := strings.TrimPrefix(path[len(base):], string(filepath.Separator))
+	first, _, found := strings.Cut(rest, string(filepath.Separator))
+	if !found {
+		return ""
+	}
+	return filepath.Join(base, first)
+}
+
+// Within reports whether path is root or lies beneath it, ignoring case.
+// Root may contain glob patterns (e.g. D:\Users\*\AppData\Local\Temp).
+func Within(path, root string) bool {
+	path, root = filepath.Clean(path), filepath.Clean(root)
+	if !strings.ContainsAny(root, "*?[") {
+		return hasPathPrefix(path, root)
+	}
+	sep := string(filepath.Separator)
+	n := len(strings.Split(root, sep))
+	parts := strings.Split(path, sep)
+	if len(parts) < n {
+		return false
+	}
+	ok, _ := filepath.Match(strings.ToLower(root), strings.ToLower(strings.Join(parts[:n], sep)))
+	return ok
+}
+
+// hasPathPrefix reports whether path equals root or is inside it.
+func hasPathPrefix(path, root string) bool {
+	if len(path) < len(root) || !strings.EqualFold(path[:len(root)], root) {
+		return false
+	}
+	return len(path) == len(root) || path[len(root)] == filepath.Separator ||
+		strings.HasSuffix(root, string(filepath.Separator))
+}
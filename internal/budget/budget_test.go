@@ -0,0 +1,142 @@
+package budget
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBreakerLimits(t *testing.T) {
+	b := New(Options{Limits: Limits{MaxBytes: 100, MaxItems: 3}})
+
+	for i, size := range []int64{40, 40} {
+		p := filepath.Join("/cache", string(rune('a'+i)))
+		if trip := b.Check(p, size); trip != nil {
+			t.Fatalf("Check(%s) tripped early: %v", p, trip)
+		}
+		b.Record(p, size)
+	}
+
+	trip := b.Check("/cache/c", 30)
+	if trip == nil || trip.Reason != ReasonBytes {
+		t.Fatalf("Check() = %v, want bytes trip", trip)
+	}
+	b.Override(trip)
+	if trip := b.Check("/cache/c", 30); trip != nil {
+		t.Fatalf("Check() after override = %v, want nil", trip)
+	}
+	b.Record("/cache/c", 30)
+
+	trip = b.Check("/cache/d", 1)
+	if trip == nil || trip.Reason != ReasonItems {
+		t.Fatalf("Check() = %v, want items trip", trip)
+	}
+
+	st := b.State()
+	if st.Bytes != 110 || st.Items != 3 || st.Trips != 2 || st.Limits.MaxBytes != 0 {
+		t.Errorf("State() = %+v", st)
+	}
+}
+
+func TestBreakerCheckSize(t *testing.T) {
+	b := New(Options{
+		Limits: Limits{MaxBytes: 100, MaxDirShare: 0.1},
+		Roots:  []string{filepath.Join("/", "cache")},
+	})
+	b.Record(filepath.Join("/", "cache", "a"), 60)
+
+	// Not a path, so never outside the roots; only the budgets apply.
+	if trip := b.CheckSize("DockerVolumes", 30); trip != nil {
+		t.Fatalf("CheckSize() tripped early: %v", trip)
+	}
+	b.RecordSize(30)
+	trip := b.CheckSize("WindowsOld", 20)
+	if trip == nil || trip.Reason != ReasonBytes || trip.Path != "WindowsOld" {
+		t.Fatalf("CheckSize() = %v, want bytes trip for WindowsOld", trip)
+	}
+	if st := b.State(); st.Bytes != 90 || st.Items != 2 {
+		t.Errorf("State() = %+v, want 90 bytes in 2 items", st)
+	}
+}
+
+func TestBreakerOutsideRoots(t *testing.T) {
+	home := filepath.FromSlash("/home/alice")
+	b := New(Options{
+		Roots: []string{
+			filepath.Join(home, "AppData", "Local", "Temp"),
+			filepath.FromSlash("/data/Users/*/AppData/Local/Temp"),
+		},
+		Home: home,
+	})
+
+	inside := []string{
+		filepath.Join(home, "AppData", "Local", "Temp", "x.tmp"),
+		filepath.Join(home, "appdata", "local", "temp", "sub", "y.tmp"),
+		filepath.FromSlash("/data/Users/bob/AppData/Local/Temp/z.tmp"),
+	}
+	for _, p := range inside {
+		if trip := b.Check(p, 1); trip != nil {
+			t.Errorf("Check(%s) = %v, want nil", p, trip)
+		}
+	}
+
+	doc := filepath.Join(home, "Documents", "thesis.docx")
+	trip := b.Check(doc, 1)
+	if trip == nil || trip.Reason != ReasonOutside {
+		t.Fatalf("Check(%s) = %v, want outside trip", doc, trip)
+	}
+	if trip.Dir != filepath.Join(home, "Documents") {
+		t.Errorf("trip.Dir = %q", trip.Dir)
+	}
+
+	b.Override(trip)
+	if trip := b.Check(filepath.Join(home, "Documents", "other.txt"), 1); trip != nil {
+		t.Errorf("Check() under approved dir = %v, want nil", trip)
+	}
+	if trip := b.Check(filepath.Join(home, "Desktop", "a.txt"), 1); trip == nil {
+		t.Error("approval should not extend to other directories")
+	}
+}
+
+func TestBreakerDirShare(t *testing.T) {
+	home := filepath.FromSlash("/home/alice")
+	b := New(Options{
+		Limits: Limits{MaxDirShare: 0.5},
+		Roots:  []string{filepath.Join(home, "AppData"), filepath.FromSlash("/scratch")},
+		Home:   home,
+		DirSize: func(dir string) int64 {
+			return 100
+		},
+	})
+
+	p := filepath.Join(home, "AppData", "Local", "Temp", "a")
+	if trip := b.Check(p, 40); trip != nil {
+		t.Fatalf("Check() = %v, want nil", trip)
+	}
+	b.Record(p, 40)
+
+	// AppData is an expected root itself, so the share limit does not apply.
+	if trip := b.Check(p, 40); trip != nil {
+		t.Fatalf("Check() under a root dir = %v, want nil", trip)
+	}
+
+	b = New(Options{
+		Limits:  Limits{MaxDirShare: 0.5},
+		Home:    home,
+		DirSize: func(dir string) int64 { return 100 },
+	})
+	p = filepath.Join(home, "AppData", "Local", "cache.bin")
+	b.Record(p, 40)
+	trip := b.Check(p, 20)
+	if trip == nil || trip.Reason != ReasonDirShare {
+		t.Fatalf("Check() = %v, want share trip", trip)
+	}
+}
+
+func TestLimitsValidate(t *testing.T) {
+	if err := (Limits{MaxDirShare: 1.5}).Validate(); err == nil {
+		t.Error("share above 1 should be invalid")
+	}
+	if err := (Limits{MaxBytes: 10, MaxItems: 5, MaxDirShare: 0.9}).Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
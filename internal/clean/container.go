@@ -0,0 +1,52 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/container"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+)
+
+// ─── Container Runtimes ──────────────────────────────────────────────────────
+
+// PruneUntil limits container prunes to data older than it (see
+// config.ContainersConfig.PruneUntil). Commands set it from config before
+// cleaning.
+var PruneUntil = container.DefaultUntil
+
+// containerSize returns the bytes a prune of res would free, or 0 when the
+// runtime is not installed or not running.
+func containerSize(res container.Resource) int64 {
+	if !res.Available(toolRunner) {
+		return 0
+	}
+	size, err := res.Reclaimable(context.Background(), toolRunner)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// containerConfirmation returns the question to ask before pruning res.
+// Volumes hold real data, so pruning them needs an explicit yes; the other
+// resources are rebuilt or pulled again.
+func containerConfirmation(res container.Resource, size int64) string {
+	if res.Kind != container.Volumes {
+		return ""
+	}
+	return fmt.Sprintf("Delete every unused %s volume (%s)? Data in them is lost for good.",
+		res.Runtime, core.FormatSize(size))
+}
+
+// cleanContainer prunes res and returns the bytes freed. In dryRun mode it
+// returns the estimate.
+func cleanContainer(res container.Resource, dryRun bool) (int64, error) {
+	if !res.Available(toolRunner) {
+		return 0, nil // Runtime not installed, skip silently.
+	}
+	if dryRun {
+		return containerSize(res), nil
+	}
+	return res.Prune(context.Background(), toolRunner, PruneUntil)
+}
@@ -1,11 +1,13 @@
 package clean
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
 	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
 )
 
@@ -58,17 +60,12 @@ var commonJunkPatterns = []string{
 	"desktop.ini",
 }
 
-// ScanNonSystemDrives discovers all non-system drives and scans them for
-// temp files, junk files, and common cache directories.
-func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
-	drives := nonSystemDrives()
-	if len(drives) == 0 {
-		return nil
-	}
-
+// scanDriveTemp discovers all non-system drives and scans them for temp
+// files, junk files, and users' temp directories.
+func scanDriveTemp(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
 	var items []CleanItem
 
-	for _, drive := range drives {
+	for _, drive := range nonSystemDrives() {
 		root := drive + `\`
 		driveLetter := drive[:1]
 
@@ -89,7 +86,7 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 				continue
 			}
 
-			dirItems := scanDirectory(dir, "user", driveLetter+": Temp files", wl)
+			dirItems := scanDirectory(ctx, dir, "user", driveLetter+": Temp files", wl, tally, pace)
 			items = append(items, dirItems...)
 		}
 
@@ -103,27 +100,24 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 				if wl != nil && wl.IsWhitelisted(match) {
 					continue
 				}
+				wait(pace)
 				info, err := os.Stat(match)
 				if err != nil || info.IsDir() {
 					continue
 				}
+				tally.add(1, info.Size())
 				items = append(items, CleanItem{
 					Path:        match,
 					Size:        info.Size(),
+					Files:       1,
 					Category:    "user",
 					Description: driveLetter + ": Junk files",
+					Root:        root,
 				})
 			}
 		}
 
-		// 3. Scan Windows.old on non-system drives (rare but possible).
-		winOld := filepath.Join(root, "Windows.old")
-		if info, err := os.Stat(winOld); err == nil && info.IsDir() {
-			dirItems := scanDirectory(winOld, "system", driveLetter+": Windows.old", wl)
-			items = append(items, dirItems...)
-		}
-
-		// 4. Scan nested temp directories (common on data drives).
+		// 3. Scan nested temp directories (common on data drives).
 		//    e.g., D:\Users\*\AppData\Local\Temp
 		userDirs := filepath.Join(root, "Users")
 		if info, err := os.Stat(userDirs); err == nil && info.IsDir() {
@@ -135,7 +129,7 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 					if wl != nil && wl.IsWhitelisted(tempDir) {
 						continue
 					}
-					dirItems := scanDirectory(tempDir, "user", driveLetter+": User temp", wl)
+					dirItems := scanDirectory(ctx, tempDir, "user", driveLetter+": User temp", wl, tally, pace)
 					items = append(items, dirItems...)
 				}
 			}
@@ -145,6 +139,50 @@ func ScanNonSystemDrives(wl *whitelist.Whitelist) []CleanItem {
 	return items
 }
 
+// scanDriveWindowsOld scans Windows.old on non-system drives (rare but
+// possible).
+func scanDriveWindowsOld(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
+	var items []CleanItem
+	for _, drive := range nonSystemDrives() {
+		winOld := filepath.Join(drive+`\`, "Windows.old")
+		if wl != nil && wl.IsWhitelisted(winOld) {
+			continue
+		}
+		if info, err := os.Stat(winOld); err == nil && info.IsDir() {
+			dirItems := scanDirectory(ctx, winOld, "system", drive[:1]+": Windows.old", wl, tally, pace)
+			items = append(items, dirItems...)
+		}
+	}
+	return items
+}
+
+// driveTempRoots returns the temp and junk locations scanDriveTemp draws
+// items from.
+func driveTempRoots() []string {
+	var roots []string
+	for _, drive := range nonSystemDrives() {
+		root := drive + `\`
+		for _, dir := range commonTempDirs {
+			roots = append(roots, filepath.Join(root, dir))
+		}
+		for _, pattern := range commonJunkPatterns {
+			roots = append(roots, filepath.Join(root, pattern))
+		}
+		roots = append(roots, filepath.Join(root, "Users", "*", "AppData", "Local", "Temp"))
+	}
+	return roots
+}
+
+// driveWindowsOldRoots returns the Windows.old folders scanDriveWindowsOld
+// may draw items from.
+func driveWindowsOldRoots() []string {
+	var roots []string
+	for _, drive := range nonSystemDrives() {
+		roots = append(roots, filepath.Join(drive+`\`, "Windows.old"))
+	}
+	return roots
+}
+
 // ScanDriveJunkFiles scans a specific drive for common junk files
 // recursively in the top 2 directory levels (not deep — too slow).
 func ScanDriveJunkFiles(drive string, wl *whitelist.Whitelist) []CleanItem {
@@ -188,7 +226,7 @@ func ScanDriveJunkFiles(drive string, wl *whitelist.Whitelist) []CleanItem {
 				if wl != nil && wl.IsWhitelisted(subPath) {
 					continue
 				}
-				dirItems := scanDirectory(subPath, "user", driveLetter+": "+name+" temp", wl)
+				dirItems := scanDirectory(context.Background(), subPath, "user", driveLetter+": "+name+" temp", wl, nil, nil)
 				items = append(items, dirItems...)
 			}
 		}
@@ -0,0 +1,49 @@
+package clean
+
+import "sort"
+
+// ─── Profiles ────────────────────────────────────────────────────────────────
+
+// Profile is a named set of clean categories used for unattended runs
+// (e.g. pw guard --profile safe) and as a shorthand for pw clean.
+type Profile struct {
+	// Name is the profile identifier used on the command line.
+	Name string
+
+	// Description is a human-readable summary of what the profile cleans.
+	Description string
+
+	// Categories lists the high-level categories (user, browser, electron,
+	// dev, system) the profile covers.
+	Categories []string
+}
+
+// profiles is the built-in profile table keyed by name.
+var profiles = map[string]Profile{
+	"safe": {
+		Name:        "safe",
+		Description: "User temp files, browser and app caches, and developer caches (no admin needed)",
+		Categories:  []string{"user", "browser", "electron", "dev"},
+	},
+	"standard": {
+		Name:        "standard",
+		Description: "Everything in safe plus system caches and logs (admin recommended)",
+		Categories:  []string{"user", "browser", "electron", "dev", "system"},
+	},
+}
+
+// GetProfile returns the built-in profile with the given name.
+func GetProfile(name string) (Profile, bool) {
+	p, ok := profiles[name]
+	return p, ok
+}
+
+// ProfileNames returns the names of all built-in profiles, sorted.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
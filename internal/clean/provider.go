@@ -0,0 +1,339 @@
+package clean
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/container"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/pkgtool"
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// ─── Provider ────────────────────────────────────────────────────────────────
+
+// Provider is one clean target: what it is, how risky it is, how to find
+// its files and how to clean it. Providers come in two shapes:
+//
+//   - Itemized providers list their files from Scan. Callers normally
+//     delete those items themselves (through the safety checks, breaker
+//     and whitelist) or pass them back to Clean.
+//   - Other providers clean through a tool or system API (the Recycle Bin,
+//     go clean -modcache). Scan returns nothing; EstimateSize sizes them
+//     and Clean runs the tool.
+//
+// A dev cache whose package manager is on PATH is of the second shape: npm
+// cache clean, pip cache purge and so on know what is safe to remove.
+type Provider interface {
+	// Name is the unique target name (e.g. "ChromeCache").
+	Name() string
+
+	// Description is a human-readable label. For a cache its package
+	// manager cleans, it names the command.
+	Description() string
+
+	// Category is the high-level category (user, browser, electron, dev,
+	// system).
+	Category() string
+
+	// Risk is the risk level (low, medium, high).
+	Risk() string
+
+	// RequiresAdmin reports whether cleaning needs elevation.
+	RequiresAdmin() bool
+
+	// Processes lists the executables of the applications that own the
+	// target's files (e.g. "chrome.exe"), if any.
+	Processes() []string
+
+	// Itemized reports whether Scan lists the provider's files, given the
+	// whitelist in force. A cache cleaned by its package manager is
+	// itemized after all when wl protects something inside it, since the
+	// tool would not respect the whitelist.
+	Itemized(wl *whitelist.Whitelist) bool
+
+	// Roots returns the directories and glob patterns the provider's
+	// files live under.
+	Roots() []string
+
+	// Scan returns the cleanable files, skipping whitelisted ones, and
+	// counts them into tally (which may be nil) as they are found.
+	// Directories the whitelist leaves whole are returned as one item. It
+	// stops walking once ctx is canceled and returns what it has so far,
+	// and waits on pace (which may be nil) before each stat.
+	Scan(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem
+
+	// EstimateSize returns the bytes a Clean would free.
+	EstimateSize() int64
+
+	// Confirmation returns the question to put to the user before a real
+	// Clean destroys size bytes that cannot be recovered, or "" when the
+	// provider cleans without asking. Clean itself never prompts.
+	Confirmation(size int64) string
+
+	// Clean frees the provider's space and returns the bytes freed. An
+	// itemized provider deletes items, or runs its package manager when
+	// items is nil and one is on PATH; other providers ignore items. In
+	// dryRun mode nothing is removed.
+	Clean(items []CleanItem, dryRun bool) (int64, error)
+}
+
+// ─── Registry ────────────────────────────────────────────────────────────────
+
+var (
+	registryMu sync.Mutex
+	registry   []Provider
+)
+
+// Providers returns a provider for every configured clean target, in
+// config order. The targets are resolved once and reused until Reload;
+// the slice is shared and must not be modified.
+func Providers() []Provider {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry == nil {
+		targets := config.GetCleanTargets()
+		registry = make([]Provider, 0, len(targets))
+		for _, t := range targets {
+			registry = append(registry, newProvider(t))
+		}
+	}
+	return registry
+}
+
+// Reload discards the resolved providers, so the next Providers call
+// looks for targets afresh (a browser or app installed since, say).
+// Long-running callers call it at the start of each run.
+func Reload() {
+	registryMu.Lock()
+	registry = nil
+	registryMu.Unlock()
+}
+
+// Lookup returns the provider with the given name, ignoring case.
+func Lookup(name string) (Provider, bool) {
+	for _, p := range Providers() {
+		if strings.EqualFold(p.Name(), name) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// newProvider builds the provider for a target from its kind. An unknown
+// kind is a programming error in the target table.
+func newProvider(t config.CleanTarget) *provider {
+	p := &provider{target: t}
+	switch t.Kind {
+	case config.KindPaths:
+		p.scan = func(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
+			return scanTarget(ctx, t, wl, tally, pace)
+		}
+		if tool, ok := pkgtool.ForTarget(t.Name); ok {
+			p.tool = &tool
+		}
+	case config.KindDriveTemp:
+		p.scan = scanDriveTemp
+		p.roots = driveTempRoots
+	case config.KindDriveWindowsOld:
+		p.scan = scanDriveWindowsOld
+		p.roots = driveWindowsOldRoots
+	case config.KindRecycleBin:
+		p.size = func() int64 {
+			size, _ := ScanRecycleBin()
+			return size
+		}
+		p.clean = func(dryRun bool) (int64, error) {
+			size, _ := ScanRecycleBin()
+			if err := EmptyRecycleBin(dryRun); err != nil {
+				return 0, err
+			}
+			return size, nil
+		}
+	case config.KindGoModCache:
+		p.size = GoModCacheSize
+		p.clean = CleanGoModCache
+	case config.KindContainer:
+		res, ok := container.ForTarget(t.Name)
+		if !ok {
+			panic("clean: container target " + t.Name + " has no runtime resource")
+		}
+		p.size = func() int64 { return containerSize(res) }
+		p.clean = func(dryRun bool) (int64, error) { return cleanContainer(res, dryRun) }
+		p.confirm = func(size int64) string { return containerConfirmation(res, size) }
+	case config.KindWindowsOld:
+		p.size = WindowsOldSize
+		p.clean = CleanWindowsOld
+		p.confirm = windowsOldConfirmation
+	default:
+		panic("clean: target " + t.Name + " has unknown kind " + t.Kind)
+	}
+	return p
+}
+
+// toolRunner runs package managers; tests substitute a fake.
+var toolRunner pkgtool.Runner = pkgtool.Exec{}
+
+// provider implements Provider for a configured target. Itemized targets
+// set scan, and tool when a package manager can clean them instead; the
+// others set size and clean, and confirm when cleaning destroys data.
+type provider struct {
+	target  config.CleanTarget
+	scan    func(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem
+	roots   func() []string
+	tool    *pkgtool.Tool
+	size    func() int64
+	clean   func(dryRun bool) (int64, error)
+	confirm func(size int64) string
+}
+
+func (p *provider) Name() string        { return p.target.Name }
+func (p *provider) Category() string    { return p.target.Category }
+func (p *provider) Risk() string        { return p.target.RiskLevel }
+func (p *provider) RequiresAdmin() bool { return p.target.RequiresAdmin }
+func (p *provider) Processes() []string { return p.target.Processes }
+
+func (p *provider) Description() string {
+	if p.nativeTool() != nil {
+		return p.target.Description + " (" + p.tool.Command() + ")"
+	}
+	return p.target.Description
+}
+
+func (p *provider) Itemized(wl *whitelist.Whitelist) bool {
+	if p.scan == nil {
+		return false
+	}
+	return p.nativeTool() == nil || shields(wl, p.target.Paths)
+}
+
+// nativeTool returns the package manager that cleans the target, or nil
+// when there is none or it is not on PATH.
+func (p *provider) nativeTool() *pkgtool.Tool {
+	if p.tool == nil || !p.tool.Available(toolRunner) {
+		return nil
+	}
+	return p.tool
+}
+
+func (p *provider) Roots() []string {
+	roots := append([]string(nil), p.target.Paths...)
+	if p.roots != nil {
+		roots = append(roots, p.roots()...)
+	}
+	return roots
+}
+
+func (p *provider) Scan(ctx context.Context, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
+	if p.scan == nil {
+		return nil
+	}
+	return p.scan(ctx, wl, tally, pace)
+}
+
+func (p *provider) EstimateSize() int64 {
+	if p.size != nil {
+		return p.size()
+	}
+	if tool := p.nativeTool(); tool != nil {
+		if size, ok := tool.EstimateSize(context.Background(), toolRunner); ok {
+			return size
+		}
+		return dirsSize(p.toolDirs(tool))
+	}
+	var total int64
+	for _, item := range p.Scan(context.Background(), nil, nil, nil) {
+		total += item.Size
+	}
+	return total
+}
+
+func (p *provider) Confirmation(size int64) string {
+	if p.confirm == nil {
+		return ""
+	}
+	return p.confirm(size)
+}
+
+func (p *provider) Clean(items []CleanItem, dryRun bool) (int64, error) {
+	if p.clean != nil {
+		return p.clean(dryRun)
+	}
+	if tool := p.nativeTool(); tool != nil && items == nil {
+		return p.cleanWithTool(tool, dryRun)
+	}
+	var (
+		freed int64
+		errs  []error
+	)
+	for _, item := range items {
+		n, err := core.SafeDeleteWithin(item.Path, item.Root, dryRun)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		freed += n
+	}
+	return freed, errors.Join(errs...)
+}
+
+// ─── Package Manager Cleanup ─────────────────────────────────────────────────
+
+// cleanWithTool runs the target's package manager and returns the bytes
+// its cache directories shrank by. In dryRun mode it returns the estimate.
+func (p *provider) cleanWithTool(tool *pkgtool.Tool, dryRun bool) (int64, error) {
+	if dryRun {
+		return p.EstimateSize(), nil
+	}
+	dirs := p.toolDirs(tool)
+	before := dirsSize(dirs)
+	if err := tool.Run(context.Background(), toolRunner); err != nil {
+		return 0, err
+	}
+	return max(before-dirsSize(dirs), 0), nil
+}
+
+// toolDirs returns the cache directories the tool reports, falling back to
+// the target's configured paths.
+func (p *provider) toolDirs(tool *pkgtool.Tool) []string {
+	if dirs := tool.CacheDirs(context.Background(), toolRunner); len(dirs) > 0 {
+		return dirs
+	}
+	return p.target.Paths
+}
+
+// dirsSize returns the combined size of paths, expanding glob patterns.
+// Missing paths count as empty.
+func dirsSize(paths []string) int64 {
+	var total int64
+	for _, pattern := range paths {
+		matches, err := filepath.Glob(pattern)
+		if err != nil || len(matches) == 0 {
+			matches = []string{pattern}
+		}
+		for _, path := range matches {
+			if size, err := core.GetDirSize(path); err == nil {
+				total += size
+			}
+		}
+	}
+	return total
+}
+
+// shields reports whether wl protects any of roots or anything inside
+// them.
+func shields(wl *whitelist.Whitelist, roots []string) bool {
+	if wl == nil {
+		return false
+	}
+	for _, root := range roots {
+		if wl.IsWhitelisted(root) || reachesInside(wl, root) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package clean
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/pkgtool"
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// ---------------------------------------------------------------------------
+// Provider registry tests
+// ---------------------------------------------------------------------------
+
+func TestProviders_OnePerTarget(t *testing.T) {
+	targets := config.GetCleanTargets()
+	providers := Providers()
+	if len(providers) != len(targets) {
+		t.Fatalf("Providers() = %d providers, want one per target (%d)", len(providers), len(targets))
+	}
+	for i, p := range providers {
+		if p.Name() != targets[i].Name {
+			t.Errorf("provider %d = %q, want %q", i, p.Name(), targets[i].Name)
+		}
+		if _, native := pkgtool.ForTarget(p.Name()); native {
+			continue // Depends on what is on PATH.
+		}
+		itemized := targets[i].Kind == config.KindPaths ||
+			targets[i].Kind == config.KindDriveTemp ||
+			targets[i].Kind == config.KindDriveWindowsOld
+		if p.Itemized(nil) != itemized {
+			t.Errorf("%s: Itemized() = %v for kind %q", p.Name(), p.Itemized(nil), targets[i].Kind)
+		}
+	}
+}
+
+func TestProviders_HaveRiskLevel(t *testing.T) {
+	for _, p := range Providers() {
+		switch p.Risk() {
+		case "low", "medium", "high":
+		default:
+			t.Errorf("%s: Risk() = %q, want low, medium or high", p.Name(), p.Risk())
+		}
+	}
+	// These need an explicit opt-in.
+	for _, name := range []string{"WindowsOld", "DriveWindowsOld", "MemoryDumps", "DockerVolumes"} {
+		if p, ok := Lookup(name); !ok || p.Risk() != "high" {
+			t.Errorf("%s is not high risk", name)
+		}
+	}
+}
+
+func TestLookup_IgnoresCase(t *testing.T) {
+	p, ok := Lookup("recyclebin")
+	if !ok || p.Name() != "RecycleBin" {
+		t.Fatalf("Lookup(recyclebin) = %v, %v", p, ok)
+	}
+	if p.Itemized(nil) {
+		t.Error("RecycleBin should clean through the Shell API, not listed items")
+	}
+	if _, ok := Lookup("NoSuchTarget"); ok {
+		t.Error("Lookup(NoSuchTarget) found a provider")
+	}
+}
+
+func TestExpectedRoots_CoverTargetPaths(t *testing.T) {
+	roots := make(map[string]bool)
+	for _, r := range ExpectedRoots() {
+		roots[strings.ToLower(r)] = true
+	}
+	for _, target := range config.GetCleanTargets() {
+		for _, p := range target.Paths {
+			if !roots[strings.ToLower(p)] {
+				t.Errorf("ExpectedRoots() misses %s path %q", target.Name, p)
+			}
+		}
+	}
+}
+
+// fakeRunner pretends every executable is on PATH and records the command
+// lines it is asked to run.
+type fakeRunner struct {
+	ran    []string
+	output map[string]string
+}
+
+func (f *fakeRunner) LookPath(name string) (string, error) { return name, nil }
+
+func (f *fakeRunner) Run(_ context.Context, name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	f.ran = append(f.ran, line)
+	return []byte(f.output[line]), nil
+}
+
+func TestProvider_PrefersPackageManager(t *testing.T) {
+	fake := &fakeRunner{output: map[string]string{
+		"pip cache info": "Package index page cache size: 2 MB\nLocally built wheels size: 1 MB\n",
+	}}
+	old := toolRunner
+	toolRunner = fake
+	t.Cleanup(func() { toolRunner = old })
+
+	p, _ := Lookup("PipCache")
+	if p.Itemized(nil) {
+		t.Fatal("PipCache itemized although pip is on PATH")
+	}
+	if got := p.EstimateSize(); got != 3<<20 {
+		t.Errorf("EstimateSize() = %d, want %d from pip cache info", got, 3<<20)
+	}
+	if _, err := p.Clean(nil, false); err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if last := fake.ran[len(fake.ran)-1]; last != "pip cache purge" {
+		t.Errorf("last command = %q, want pip cache purge", last)
+	}
+}
+
+func TestProvider_WhitelistKeepsFileDeletion(t *testing.T) {
+	old := toolRunner
+	toolRunner = &fakeRunner{}
+	t.Cleanup(func() { toolRunner = old })
+
+	p, _ := Lookup("NpmCache")
+	root := p.Roots()[0]
+	wl := whitelist.New(filepath.Join(root, "_cacache", "index-v5"))
+	if !p.Itemized(wl) {
+		t.Error("NpmCache cleaned by npm although the whitelist protects part of it")
+	}
+}
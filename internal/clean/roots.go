@@ -0,0 +1,14 @@
+package clean
+
+// ─── Expected Roots ──────────────────────────────────────────────────────────
+
+// ExpectedRoots returns the directories (and glob patterns) that the clean
+// providers draw items from. A deletion outside all of them points at a bad
+// target or whitelist mistake.
+func ExpectedRoots() []string {
+	var roots []string
+	for _, p := range Providers() {
+		roots = append(roots, p.Roots()...)
+	}
+	return roots
+}
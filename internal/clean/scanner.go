@@ -1,12 +1,17 @@
 package clean
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/envutil"
 	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
 )
 
@@ -17,14 +22,22 @@ type CleanItem struct {
 	// Path is the absolute filesystem path.
 	Path string
 
-	// Size is the size in bytes.
+	// Size is the size in bytes, for a directory of its whole tree.
 	Size int64
 
-	// Category is the high-level grouping (user, browser, dev, system).
+	// Files is the number of files the item covers: 1 for a file, the
+	// file count of its tree for a directory.
+	Files int
+
+	// Category is the high-level grouping (user, browser, electron, dev, system).
 	Category string
 
 	// Description is a human-readable label for the parent target.
 	Description string
+
+	// Root is the target directory the item was found under. Deletion
+	// refuses the item if its real path escapes Root.
+	Root string
 }
 
 // ScanResult holds the aggregated scan output for a single clean target.
@@ -40,50 +53,88 @@ type ScanResult struct {
 
 	// ItemCount is the number of items discovered.
 	ItemCount int
+
+	// FileCount is the number of files the items cover.
+	FileCount int
 }
 
-// ─── Parallel Scan Engine ────────────────────────────────────────────────────
+// Tally counts the files and bytes a scan has found so far, for progress
+// displays. It is safe for concurrent use; a nil *Tally counts nothing.
+type Tally struct {
+	files atomic.Int64
+	bytes atomic.Int64
+}
 
-// ScanAll scans all provided targets in parallel, returning results for each
-// target that has cleanable items. Targets requiring admin privileges are
-// skipped when isAdmin is false. Whitelisted paths are excluded.
-func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool) []ScanResult {
-	var (
-		mu      sync.Mutex
-		wg      sync.WaitGroup
-		results []ScanResult
-	)
+// add counts files more files totalling bytes.
+func (t *Tally) add(files int, bytes int64) {
+	if t == nil {
+		return
+	}
+	t.files.Add(int64(files))
+	t.bytes.Add(bytes)
+}
+
+// Files returns the number of files found so far.
+func (t *Tally) Files() int64 { return t.files.Load() }
 
-	for _, t := range targets {
+// Bytes returns the combined size of the files found so far.
+func (t *Tally) Bytes() int64 { return t.bytes.Load() }
+
+// ─── Parallel Scan Engine ────────────────────────────────────────────────────
+
+// Stream scans the itemized providers in parallel, sending a result on
+// the returned channel as each one that has cleanable items finishes, and
+// closing the channel once all are done. Files are counted into tally as
+// they are found, so a progress display can poll it in between; tally may
+// be nil. Each file and directory statted waits on pace first, if set. The
+// caller must drain the channel or cancel ctx; once ctx is
+// canceled the walks stop and nothing more is sent. Providers requiring
+// admin privileges are skipped when isAdmin is false; providers that clean
+// through a tool under wl are skipped always (size them with
+// EstimateSize). Whitelisted paths are excluded.
+func Stream(ctx context.Context, providers []Provider, wl *whitelist.Whitelist, isAdmin bool, tally *Tally, pace core.Pacer) <-chan ScanResult {
+	results := make(chan ScanResult)
+	var wg sync.WaitGroup
+
+	for _, p := range providers {
 		// Skip admin-required targets if not elevated.
-		if t.RequiresAdmin && !isAdmin {
+		if p.RequiresAdmin() && !isAdmin {
 			continue
 		}
-
-		// RecycleBin has no filesystem paths; handled via Shell API separately.
-		if t.Name == "RecycleBin" {
+		if !p.Itemized(wl) {
 			continue
 		}
 
 		wg.Add(1)
-		go func(target config.CleanTarget) {
+		go func(p Provider) {
 			defer wg.Done()
 
-			items := scanTarget(target, wl)
-			if len(items) == 0 {
+			items := p.Scan(ctx, wl, tally, pace)
+			if len(items) == 0 || ctx.Err() != nil {
 				return
 			}
+			select {
+			case results <- ItemsToResult(p.Name(), items):
+			case <-ctx.Done():
+			}
+		}(p)
+	}
 
-			result := ItemsToResult(target.Name, items)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
 
-			mu.Lock()
-			results = append(results, result)
-			mu.Unlock()
-		}(t)
+// ScanAll is Stream collected: it returns a result for each provider that
+// has cleanable items, sorted by name.
+func ScanAll(ctx context.Context, providers []Provider, wl *whitelist.Whitelist, isAdmin bool) []ScanResult {
+	var results []ScanResult
+	for r := range Stream(ctx, providers, wl, isAdmin, nil, nil) {
+		results = append(results, r)
 	}
 
-	wg.Wait()
-
 	// Sort results by category name for stable output.
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Category < results[j].Category
@@ -95,8 +146,8 @@ func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool
 // ─── Single-Target Scanning ──────────────────────────────────────────────────
 
 // scanTarget scans a single CleanTarget by resolving environment variables
-// and glob patterns in its paths.
-func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem {
+// and glob patterns in its paths. It stops early once ctx is canceled.
+func scanTarget(ctx context.Context, target config.CleanTarget, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
 	var items []CleanItem
 
 	for _, rawPath := range target.Paths {
@@ -111,6 +162,9 @@ func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem
 		}
 
 		for _, path := range matches {
+			if ctx.Err() != nil {
+				return items
+			}
 			path = filepath.Clean(path)
 
 			// Skip whitelisted paths.
@@ -118,20 +172,24 @@ func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem
 				continue
 			}
 
+			wait(pace)
 			info, statErr := os.Lstat(path)
 			if statErr != nil {
 				continue // Path doesn't exist or is inaccessible.
 			}
 
 			if info.IsDir() {
-				dirItems := scanDirectory(path, target.Category, target.Description, wl)
+				dirItems := scanDirectory(ctx, path, target.Category, target.Description, wl, tally, pace)
 				items = append(items, dirItems...)
 			} else {
+				tally.add(1, info.Size())
 				items = append(items, CleanItem{
 					Path:        path,
 					Size:        info.Size(),
+					Files:       1,
 					Category:    target.Category,
 					Description: target.Description,
+					Root:        filepath.Dir(path),
 				})
 			}
 		}
@@ -140,38 +198,164 @@ func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem
 	return items
 }
 
-// scanDirectory walks a directory tree collecting all files as CleanItems.
-// Whitelisted and inaccessible entries are silently skipped.
-func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) []CleanItem {
-	var items []CleanItem
+// scanDirectory collects the cleanable contents of dir as CleanItems
+// rooted at dir. A subdirectory that no whitelist pattern can reach into
+// becomes a single item sized for its whole tree; only where a pattern
+// could match something inside is a tree listed entry by entry, so a cache
+// of millions of files costs a handful of items. Whitelisted and
+// inaccessible entries are skipped, and symlinks and junctions are neither
+// listed nor followed. Once ctx is canceled the walk stops and returns
+// what it has so far. Each entry waits on pace, if set, before its stat.
+func scanDirectory(ctx context.Context, dir, category, description string, wl *whitelist.Whitelist, tally *Tally, pace core.Pacer) []CleanItem {
+	s := &dirScan{ctx: ctx, root: dir, category: category, description: description, wl: wl, tally: tally, pace: pace}
+	s.expand(dir)
+	return s.items
+}
+
+// dirScan is the state of one scanDirectory call.
+type dirScan struct {
+	ctx         context.Context
+	root        string
+	category    string
+	description string
+	wl          *whitelist.Whitelist
+	tally       *Tally
+	pace        core.Pacer
+	items       []CleanItem
+}
 
+// expand lists dir's entries as items, aggregating every subdirectory the
+// whitelist leaves whole and expanding the others in turn.
+func (s *dirScan) expand(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return // Skip inaccessible directories.
+	}
+	for _, e := range entries {
+		if s.ctx.Err() != nil {
+			return
+		}
+		path := filepath.Join(dir, e.Name())
+		wait(s.pace)
+		info, err := e.Info()
+		if err != nil || core.IsReparsePoint(info) {
+			continue
+		}
+		if s.wl != nil && s.wl.IsWhitelisted(path) {
+			continue
+		}
+
+		switch {
+		case !info.IsDir():
+			s.tally.add(1, info.Size())
+			s.add(path, info.Size(), 1)
+		case reachesInside(s.wl, path):
+			s.expand(path)
+		default:
+			if size, files := s.tree(path); files > 0 {
+				s.add(path, size, files)
+			}
+		}
+	}
+}
+
+// tree returns the size and file count of the tree under dir, counting
+// each file into the tally as it goes.
+func (s *dirScan) tree(dir string) (int64, int) {
+	var (
+		size  int64
+		files int
+	)
 	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
 			return nil // Skip inaccessible entries.
 		}
-		if d.IsDir() {
+		wait(s.pace)
+		info, infoErr := d.Info()
+		if infoErr != nil {
 			return nil
 		}
-
-		if wl != nil && wl.IsWhitelisted(path) {
+		if path != dir && core.IsReparsePoint(info) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-
-		info, infoErr := d.Info()
-		if infoErr != nil {
+		if d.IsDir() {
 			return nil
 		}
-
-		items = append(items, CleanItem{
-			Path:        path,
-			Size:        info.Size(),
-			Category:    category,
-			Description: description,
-		})
+		s.tally.add(1, info.Size())
+		size += info.Size()
+		files++
 		return nil
 	})
+	return size, files
+}
 
-	return items
+// add appends an item rooted at the scanned directory.
+func (s *dirScan) add(path string, size int64, files int) {
+	s.items = append(s.items, CleanItem{
+		Path:        path,
+		Size:        size,
+		Files:       files,
+		Category:    s.category,
+		Description: s.description,
+		Root:        s.root,
+	})
+}
+
+// wait waits on pace, if set, for one more entry to be statted.
+func wait(pace core.Pacer) {
+	if pace != nil {
+		pace.Wait(1, 0)
+	}
+}
+
+// reachesInside reports whether a whitelist pattern could match something
+// strictly inside dir, in which case dir cannot be removed whole. Patterns
+// are compared a path segment at a time, with globs in either the pattern
+// or dir (a target's own path may be one) matching conservatively.
+func reachesInside(wl *whitelist.Whitelist, dir string) bool {
+	if wl == nil {
+		return false
+	}
+	dirParts := splitLower(dir)
+	for _, pattern := range wl.List() {
+		parts := splitLower(envutil.ExpandWindowsEnv(pattern))
+		if len(parts) <= len(dirParts) {
+			continue
+		}
+		if segmentsMatch(parts[:len(dirParts)], dirParts) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLower splits a cleaned, lower-cased path into its segments.
+func splitLower(path string) []string {
+	return strings.Split(strings.ToLower(filepath.Clean(path)), string(filepath.Separator))
+}
+
+// segmentsMatch reports whether two equally long segment lists match, each
+// pair equal or one matching the other as a glob.
+func segmentsMatch(a, b []string) bool {
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		if ok, _ := filepath.Match(a[i], b[i]); ok {
+			continue
+		}
+		if ok, _ := filepath.Match(b[i], a[i]); ok {
+			continue
+		}
+		return false
+	}
+	return true
 }
 
 // ─── Aggregation Helpers ─────────────────────────────────────────────────────
@@ -179,20 +363,25 @@ func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) [
 // ItemsToResult converts a slice of CleanItems into a ScanResult with
 // the given name and pre-calculated totals.
 func ItemsToResult(name string, items []CleanItem) ScanResult {
-	var totalSize int64
+	var (
+		totalSize int64
+		fileCount int
+	)
 	for _, item := range items {
 		totalSize += item.Size
+		fileCount += item.Files
 	}
 	return ScanResult{
 		Category:  name,
 		Items:     items,
 		TotalSize: totalSize,
 		ItemCount: len(items),
+		FileCount: fileCount,
 	}
 }
 
 // GroupByCategory aggregates scan results by the high-level category of
-// their items (user, browser, dev, system).
+// their items (user, browser, electron, dev, system).
 func GroupByCategory(results []ScanResult) map[string][]ScanResult {
 	groups := make(map[string][]ScanResult)
 	for _, r := range results {
@@ -0,0 +1,101 @@
+package clean
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// ---------------------------------------------------------------------------
+// Directory scanning tests
+// ---------------------------------------------------------------------------
+
+// writeTree creates files of the given sizes under root.
+func writeTree(t *testing.T, root string, files map[string]int) {
+	t.Helper()
+	for rel, size := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScanDirectory_AggregatesWholeDirectories(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]int{
+		`content\a\1`: 10,
+		`content\a\2`: 20,
+		`content\b\3`: 30,
+		`index`:       5,
+	})
+
+	var tally Tally
+	items := scanDirectory(context.Background(), root, "dev", "Test cache", nil, &tally, nil)
+	got := make(map[string]CleanItem, len(items))
+	for _, item := range items {
+		got[item.Path] = item
+	}
+	if len(items) != 2 {
+		t.Fatalf("scanDirectory() = %d items, want content and index only", len(items))
+	}
+	content := got[filepath.Join(root, "content")]
+	if content.Size != 60 || content.Files != 3 || content.Root != root {
+		t.Errorf("content item = %+v, want 60 bytes in 3 files rooted at %s", content, root)
+	}
+	if tally.Files() != 4 || tally.Bytes() != 65 {
+		t.Errorf("tally = %d files, %d bytes, want 4 and 65", tally.Files(), tally.Bytes())
+	}
+}
+
+func TestScanDirectory_ExpandsAroundWhitelist(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]int{
+		`content\a\keep`: 10,
+		`content\a\drop`: 20,
+		`content\b\3`:    30,
+	})
+	wl := whitelist.New(filepath.Join(root, "content", "a", "keep"))
+
+	items := scanDirectory(context.Background(), root, "dev", "Test cache", wl, nil, nil)
+	want := map[string]int64{
+		filepath.Join(root, "content", "a", "drop"): 20,
+		filepath.Join(root, "content", "b"):         30,
+	}
+	if len(items) != len(want) {
+		t.Fatalf("scanDirectory() = %+v, want %d items", items, len(want))
+	}
+	for _, item := range items {
+		if size, ok := want[item.Path]; !ok || item.Size != size {
+			t.Errorf("unexpected item %s (%d bytes)", item.Path, item.Size)
+		}
+	}
+}
+
+func TestReachesInside(t *testing.T) {
+	wl := whitelist.New(`C:\Cache\*\keep`)
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{`C:\Cache`, true},
+		{`C:\Cache\one`, true},
+		{`C:\Cache\one\keep`, false}, // Matched itself, not inside.
+		{`C:\Other`, false},
+		{`C:\*`, true}, // Glob roots match conservatively.
+	}
+	for _, tt := range tests {
+		if got := reachesInside(wl, tt.dir); got != tt.want {
+			t.Errorf("reachesInside(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+	if reachesInside(nil, `C:\Cache`) {
+		t.Error("reachesInside(nil) = true")
+	}
+}
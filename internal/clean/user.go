@@ -2,9 +2,6 @@ package clean
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 	"syscall"
 	"unsafe"
 )
@@ -32,70 +29,6 @@ type shQueryRBInfo struct {
 	i64NumItems int64
 }
 
-// ─── User Cache Scanning ─────────────────────────────────────────────────────
-
-// ScanUserCaches scans user temporary file directories (%TEMP% and
-// %LOCALAPPDATA%\Temp), deduplicating if they resolve to the same path.
-func ScanUserCaches() []CleanItem {
-	dirs := []string{
-		os.ExpandEnv("$TEMP"),
-		filepath.Join(os.Getenv("LOCALAPPDATA"), "Temp"),
-	}
-
-	// Deduplicate — %TEMP% often points to %LOCALAPPDATA%\Temp.
-	seen := make(map[string]bool)
-	var unique []string
-	for _, d := range dirs {
-		cleaned := filepath.Clean(d)
-		key := strings.ToLower(cleaned)
-		if !seen[key] {
-			seen[key] = true
-			unique = append(unique, cleaned)
-		}
-	}
-
-	var items []CleanItem
-	for _, dir := range unique {
-		info, err := os.Stat(dir)
-		if err != nil || !info.IsDir() {
-			continue
-		}
-		dirItems := scanDirectory(dir, "user", "User temporary files", nil)
-		items = append(items, dirItems...)
-	}
-
-	return items
-}
-
-// ScanThumbnailCache scans for Windows Explorer thumbnail cache files
-// (thumbcache_*.db) in the Explorer directory.
-func ScanThumbnailCache() []CleanItem {
-	explorerDir := filepath.Join(os.Getenv("LOCALAPPDATA"),
-		"Microsoft", "Windows", "Explorer")
-
-	pattern := filepath.Join(explorerDir, "thumbcache_*.db")
-	matches, err := filepath.Glob(pattern)
-	if err != nil || len(matches) == 0 {
-		return nil
-	}
-
-	var items []CleanItem
-	for _, path := range matches {
-		info, statErr := os.Stat(path)
-		if statErr != nil {
-			continue
-		}
-		items = append(items, CleanItem{
-			Path:        path,
-			Size:        info.Size(),
-			Category:    "user",
-			Description: "Thumbnail cache",
-		})
-	}
-
-	return items
-}
-
 // ─── Recycle Bin ──────────────────────────────────────────────────────────────
 
 // ScanRecycleBin calculates the total size of items in the Windows Recycle
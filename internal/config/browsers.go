@@ -0,0 +1,344 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ─── Browser Definitions ─────────────────────────────────────────────────────
+
+// Browser engines, which decide how profiles are discovered.
+const (
+	engineChromium = "chromium"
+	engineGecko    = "gecko"
+)
+
+// browserDef describes where a browser keeps its profiles and caches.
+type browserDef struct {
+	name  string // Clean target name (e.g. "ChromeCache").
+	label string // Human-readable browser name.
+
+	engine string
+
+	// processes are the browser's executables.
+	processes []string
+
+	// dataDir is the Chromium "User Data" directory, or the Gecko
+	// directory holding profiles.ini.
+	dataDir string
+
+	// cacheDir mirrors dataDir on the local (non-roaming) side, where
+	// browsers that roam their profiles keep the caches. Empty means the
+	// caches live under dataDir.
+	cacheDir string
+
+	// rootProfile marks a Chromium browser whose dataDir is itself the
+	// default profile (Opera) rather than holding profile directories.
+	rootProfile bool
+}
+
+// browserDefs returns the supported browsers.
+func browserDefs(local, roaming string) []browserDef {
+	return []browserDef{
+		{
+			name:      "ChromeCache",
+			label:     "Google Chrome",
+			engine:    engineChromium,
+			processes: []string{"chrome.exe"},
+			dataDir:   filepath.Join(local, "Google", "Chrome", "User Data"),
+		},
+		{
+			name:      "EdgeCache",
+			label:     "Microsoft Edge",
+			engine:    engineChromium,
+			processes: []string{"msedge.exe"},
+			dataDir:   filepath.Join(local, "Microsoft", "Edge", "User Data"),
+		},
+		{
+			name:      "FirefoxCache",
+			label:     "Mozilla Firefox",
+			engine:    engineGecko,
+			processes: []string{"firefox.exe"},
+			dataDir:   filepath.Join(roaming, "Mozilla", "Firefox"),
+			cacheDir:  filepath.Join(local, "Mozilla", "Firefox"),
+		},
+		{
+			name:      "BraveCache",
+			label:     "Brave",
+			engine:    engineChromium,
+			processes: []string{"brave.exe"},
+			dataDir:   filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data"),
+		},
+		{
+			name:      "VivaldiCache",
+			label:     "Vivaldi",
+			engine:    engineChromium,
+			processes: []string{"vivaldi.exe"},
+			dataDir:   filepath.Join(local, "Vivaldi", "User Data"),
+		},
+		{
+			name:        "OperaCache",
+			label:       "Opera",
+			engine:      engineChromium,
+			processes:   []string{"opera.exe"},
+			dataDir:     filepath.Join(roaming, "Opera Software", "Opera Stable"),
+			cacheDir:    filepath.Join(local, "Opera Software", "Opera Stable"),
+			rootProfile: true,
+		},
+		{
+			name:        "OperaGXCache",
+			label:       "Opera GX",
+			engine:      engineChromium,
+			processes:   []string{"opera.exe"},
+			dataDir:     filepath.Join(roaming, "Opera Software", "Opera GX Stable"),
+			cacheDir:    filepath.Join(local, "Opera Software", "Opera GX Stable"),
+			rootProfile: true,
+		},
+		{
+			name:      "ArcCache",
+			label:     "Arc",
+			engine:    engineChromium,
+			processes: []string{"Arc.exe"},
+			dataDir: filepath.Join(local, "Packages", "TheBrowserCompany.Arc_ttt52w2q2amvc",
+				"LocalCache", "Local", "Arc", "User Data"),
+		},
+		{
+			name:      "ChromiumCache",
+			label:     "Chromium",
+			engine:    engineChromium,
+			processes: []string{"chrome.exe"},
+			dataDir:   filepath.Join(local, "Chromium", "User Data"),
+		},
+		{
+			name:      "WaterfoxCache",
+			label:     "Waterfox",
+			engine:    engineGecko,
+			processes: []string{"waterfox.exe"},
+			dataDir:   filepath.Join(roaming, "Waterfox"),
+			cacheDir:  filepath.Join(local, "Waterfox"),
+		},
+		{
+			name:      "LibreWolfCache",
+			label:     "LibreWolf",
+			engine:    engineGecko,
+			processes: []string{"librewolf.exe"},
+			dataDir:   filepath.Join(roaming, "librewolf"),
+			cacheDir:  filepath.Join(local, "librewolf"),
+		},
+	}
+}
+
+// Cache subdirectories within a profile. Only these are ever cleaned —
+// bookmarks, passwords, cookies, history, extensions and settings are
+// never included.
+var (
+	chromiumCacheDirs = []string{
+		"Cache",
+		"Code Cache",
+		"GPUCache",
+		filepath.Join("Service Worker", "CacheStorage"),
+	}
+	geckoCacheDirs = []string{"cache2", "startupCache", "thumbnails"}
+)
+
+// chromiumHiddenProfiles are profile directories Chromium creates that
+// Local State never lists.
+var chromiumHiddenProfiles = []string{"Guest Profile", "System Profile"}
+
+// browserTargets returns a clean target per supported browser, with the
+// cache directories of every profile the browser knows about.
+func browserTargets(local, roaming string) []CleanTarget {
+	defs := browserDefs(local, roaming)
+	targets := make([]CleanTarget, 0, len(defs))
+	for _, b := range defs {
+		targets = append(targets, CleanTarget{
+			Name:          b.name,
+			Paths:         b.cachePaths(),
+			Description:   b.label + " browser cache (all profiles)",
+			RequiresAdmin: false,
+			Category:      "browser",
+			RiskLevel:     "low",
+			Processes:     b.processes,
+		})
+	}
+	return targets
+}
+
+// cachePaths returns the cache directories of the browser's profiles.
+func (b browserDef) cachePaths() []string {
+	var profiles, subdirs []string
+	switch b.engine {
+	case engineChromium:
+		profiles, subdirs = b.chromiumProfiles(), chromiumCacheDirs
+	case engineGecko:
+		profiles, subdirs = b.geckoProfiles(), geckoCacheDirs
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, profile := range profiles {
+		for _, subdir := range subdirs {
+			path := filepath.Join(profile, subdir)
+			if key := strings.ToLower(path); !seen[key] {
+				seen[key] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// ─── Chromium Profiles ───────────────────────────────────────────────────────
+
+// chromiumProfiles returns the browser's profile directories, on both the
+// data and cache side. Profiles come from Local State; when it cannot be
+// read, every directory under User Data is a candidate.
+func (b browserDef) chromiumProfiles() []string {
+	names, ok := readChromiumLocalState(filepath.Join(b.dataDir, "Local State"))
+	if !ok {
+		names = []string{"*"}
+	} else {
+		names = append(names, chromiumHiddenProfiles...)
+	}
+	if b.rootProfile {
+		names = append([]string{"."}, names...)
+	}
+
+	var profiles []string
+	for _, name := range names {
+		for _, base := range []string{b.dataDir, b.cacheDir} {
+			if base != "" {
+				profiles = append(profiles, filepath.Join(base, name))
+			}
+		}
+	}
+	return profiles
+}
+
+// readChromiumLocalState returns the profile directory names listed in a
+// Chromium Local State file's profile.info_cache, sorted. ok is false when
+// the file is missing or unreadable.
+func readChromiumLocalState(path string) (names []string, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var state struct {
+		Profile struct {
+			InfoCache map[string]json.RawMessage `json:"info_cache"`
+		} `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, false
+	}
+	for name := range state.Profile.InfoCache {
+		// Keys are directory names inside User Data; anything that
+		// would step outside it is not a profile.
+		if name == "" || filepath.Base(name) != name || name == "." || name == ".." {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// ─── Gecko Profiles ──────────────────────────────────────────────────────────
+
+// geckoProfile is a profile listed in profiles.ini or installs.ini.
+type geckoProfile struct {
+	path     string // As written, with the file's forward slashes.
+	relative bool   // path is relative to the profiles.ini directory.
+}
+
+// geckoProfiles returns the directories holding the browser's profile
+// caches. A relative profile keeps its caches under the local mirror of
+// the data directory; a profile at a custom path (say, on D:) keeps them
+// in the profile itself. Without profiles.ini, every directory under the
+// local Profiles folder is a candidate.
+func (b browserDef) geckoProfiles() []string {
+	cacheRoot := b.cacheDir
+	if cacheRoot == "" {
+		cacheRoot = b.dataDir
+	}
+
+	listed, ok := readGeckoProfiles(b.dataDir)
+	if !ok {
+		return []string{filepath.Join(cacheRoot, "Profiles", "*")}
+	}
+	var profiles []string
+	for _, p := range listed {
+		path := filepath.FromSlash(p.path)
+		switch {
+		case p.relative && filepath.IsLocal(path):
+			profiles = append(profiles, filepath.Join(cacheRoot, path))
+		case !p.relative && filepath.IsAbs(path):
+			profiles = append(profiles, filepath.Clean(path))
+		}
+	}
+	return profiles
+}
+
+// readGeckoProfiles returns the [ProfileN] entries of dir's profiles.ini
+// and the per-install default profiles of installs.ini, which may name a
+// profile profiles.ini has lost. ok is false when neither file can
+// be read.
+func readGeckoProfiles(dir string) (profiles []geckoProfile, ok bool) {
+	if sections, err := readINI(filepath.Join(dir, "profiles.ini")); err == nil {
+		ok = true
+		for _, s := range sections {
+			if path := s.keys["Path"]; path != "" && strings.HasPrefix(s.name, "Profile") {
+				profiles = append(profiles, geckoProfile{path: path, relative: s.keys["IsRelative"] != "0"})
+			}
+		}
+	}
+	if sections, err := readINI(filepath.Join(dir, "installs.ini")); err == nil {
+		ok = true
+		for _, s := range sections {
+			if path := s.keys["Default"]; path != "" {
+				profiles = append(profiles, geckoProfile{path: path, relative: !filepath.IsAbs(path)})
+			}
+		}
+	}
+	return profiles, ok
+}
+
+// iniSection is one [section] of an INI file.
+type iniSection struct {
+	name string
+	keys map[string]string
+}
+
+// readINI parses the simple INI dialect Mozilla uses: [sections],
+// key=value lines, and ; or # comments.
+func readINI(path string) ([]iniSection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var sections []iniSection
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || line[0] == ';' || line[0] == '#':
+		case line[0] == '[' && strings.HasSuffix(line, "]"):
+			sections = append(sections, iniSection{
+				name: strings.TrimSpace(line[1 : len(line)-1]),
+				keys: make(map[string]string),
+			})
+		case len(sections) > 0:
+			if key, value, ok := strings.Cut(line, "="); ok {
+				sections[len(sections)-1].keys[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return sections, sc.Err()
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadChromiumLocalState(t *testing.T) {
+	names, ok := readChromiumLocalState(filepath.Join("testdata", "chromium", "Local State"))
+	if !ok {
+		t.Fatal("readChromiumLocalState() could not read the fixture")
+	}
+	if want := []string{"Default", "Profile 3"}; !slices.Equal(names, want) {
+		t.Errorf("readChromiumLocalState() = %q, want %q", names, want)
+	}
+
+	if _, ok := readChromiumLocalState(filepath.Join("testdata", "missing")); ok {
+		t.Error("readChromiumLocalState() reported ok for a missing file")
+	}
+}
+
+func TestReadGeckoProfiles(t *testing.T) {
+	profiles, ok := readGeckoProfiles(filepath.Join("testdata", "firefox"))
+	if !ok {
+		t.Fatal("readGeckoProfiles() could not read the fixtures")
+	}
+	want := []geckoProfile{
+		{path: `D:\Firefox\work`, relative: false},
+		{path: "Profiles/abcd1234.default-release", relative: true},
+		{path: "Profiles/efgh5678.dev-edition-default", relative: true},
+	}
+	if !slices.Equal(profiles, want) {
+		t.Errorf("readGeckoProfiles() = %+v, want %+v", profiles, want)
+	}
+}
+
+func TestBrowserTargets_DiscoversProfiles(t *testing.T) {
+	local, roaming := t.TempDir(), t.TempDir()
+
+	userData := filepath.Join(local, "Google", "Chrome", "User Data")
+	copyFixture(t, filepath.Join("testdata", "chromium", "Local State"), filepath.Join(userData, "Local State"))
+
+	// A Firefox profile outside the default location, as on a second drive.
+	custom := filepath.Join(t.TempDir(), "Firefox", "work")
+	ini := "[Profile0]\nName=default\nIsRelative=1\nPath=Profiles/abcd.default\n\n" +
+		"[Profile1]\nName=work\nIsRelative=0\nPath=" + custom + "\n"
+	writeFixture(t, filepath.Join(roaming, "Mozilla", "Firefox", "profiles.ini"), ini)
+
+	paths := make(map[string][]string)
+	for _, target := range browserTargets(local, roaming) {
+		paths[target.Name] = target.Paths
+	}
+
+	for _, want := range []string{
+		filepath.Join(userData, "Default", "Cache"),
+		filepath.Join(userData, "Profile 3", "Code Cache"),
+		filepath.Join(userData, "Guest Profile", "GPUCache"),
+	} {
+		if !slices.Contains(paths["ChromeCache"], want) {
+			t.Errorf("ChromeCache paths missing %q", want)
+		}
+	}
+	for _, p := range paths["ChromeCache"] {
+		if strings.Contains(p, "Escape") || strings.Contains(p, "*") {
+			t.Errorf("ChromeCache has unexpected path %q", p)
+		}
+	}
+
+	for _, want := range []string{
+		filepath.Join(local, "Mozilla", "Firefox", "Profiles", "abcd.default", "cache2"),
+		filepath.Join(custom, "cache2"),
+	} {
+		if !slices.Contains(paths["FirefoxCache"], want) {
+			t.Errorf("FirefoxCache paths missing %q", want)
+		}
+	}
+
+	// Without a profile list, fall back to every profile directory.
+	if want := filepath.Join(local, "Vivaldi", "User Data", "*", "Cache"); !slices.Contains(paths["VivaldiCache"], want) {
+		t.Errorf("VivaldiCache paths missing fallback %q", want)
+	}
+	if want := filepath.Join(local, "librewolf", "Profiles", "*", "cache2"); !slices.Contains(paths["LibreWolfCache"], want) {
+		t.Errorf("LibreWolfCache paths missing fallback %q", want)
+	}
+}
+
+// writeFixture writes data to path, creating its directory.
+func writeFixture(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// copyFixture copies a testdata file to path.
+func copyFixture(t *testing.T, src, path string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, path, string(data))
+}
@@ -39,9 +39,204 @@ type Config struct {
 	// DryRunMode enables dry-run globally (no actual deletions).
 	DryRunMode bool `json:"dry_run_mode"`
 
+	// MaxRisk is the highest target risk level (low, medium, high) clean,
+	// guard and serve include unless --max-risk says otherwise. Empty
+	// means medium, so high-risk targets need an explicit opt-in.
+	MaxRisk string `json:"max_risk,omitempty"`
+
+	// Guard holds defaults for the low-disk guard (pw guard).
+	Guard GuardConfig `json:"guard"`
+
+	// Hooks holds user commands run at fixed points of a cleanup session.
+	Hooks HooksConfig `json:"hooks"`
+
+	// Notify holds webhook sinks and status alert thresholds.
+	Notify NotifyConfig `json:"notify"`
+
+	// Fleet points at a central policy and reports directory.
+	Fleet FleetConfig `json:"fleet"`
+
+	// Safety holds the per-run deletion budgets for the circuit breaker.
+	Safety SafetyConfig `json:"safety"`
+
+	// Gentle holds the priority and rate caps for gentle runs.
+	Gentle GentleConfig `json:"gentle"`
+
+	// Containers configures Docker and Podman pruning.
+	Containers ContainersConfig `json:"containers"`
+
 	mu sync.RWMutex
 }
 
+// GuardConfig holds defaults for pw guard. Command-line flags override
+// these values. Sizes use the same format as other size flags (e.g. "10GB").
+type GuardConfig struct {
+	// Drives lists the drives to watch (e.g. "C:", "D:"). Empty means the
+	// system drive.
+	Drives []string `json:"drives,omitempty"`
+
+	// Threshold is the free space below which a cleanup is triggered.
+	Threshold string `json:"threshold,omitempty"`
+
+	// Target is the free space to restore once triggered. Empty means
+	// twice the threshold.
+	Target string `json:"target,omitempty"`
+
+	// Profile is the clean profile to run (see pw clean --profile).
+	Profile string `json:"profile,omitempty"`
+
+	// Interval is how often to re-check free space (e.g. "5m").
+	Interval string `json:"interval,omitempty"`
+
+	// MaxBytesPerRun caps how much a single triggered cleanup may delete.
+	MaxBytesPerRun string `json:"max_bytes_per_run,omitempty"`
+}
+
+// HooksConfig lists user commands to run at each hook stage. Hooks run for
+// every cleanup command (clean, purge, installer, guard) unless restricted
+// with Hook.Commands.
+type HooksConfig struct {
+	// PreScan runs before scanning. A non-zero exit aborts the run.
+	PreScan []Hook `json:"pre_scan,omitempty"`
+
+	// PostScan runs after scanning and receives the plan JSON on stdin.
+	PostScan []Hook `json:"post_scan,omitempty"`
+
+	// PreDelete runs after confirmation, before anything is deleted.
+	// A non-zero exit aborts the run.
+	PreDelete []Hook `json:"pre_delete,omitempty"`
+
+	// PostSession runs at the end of a session and receives the result
+	// JSON on stdin. It also runs after pw uninstall.
+	PostSession []Hook `json:"post_session,omitempty"`
+}
+
+// Hook is a single user command run through the system shell.
+type Hook struct {
+	// Command is the shell command line to run.
+	Command string `json:"command"`
+
+	// Timeout bounds how long the hook may run (e.g. "30s"). Empty means
+	// the default of one minute.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Commands restricts the hook to specific pw commands (e.g. "purge").
+	// Empty means all cleanup commands.
+	Commands []string `json:"commands,omitempty"`
+}
+
+// NotifyConfig configures webhook notifications for session results and
+// status alerts.
+type NotifyConfig struct {
+	// Webhooks lists the endpoints to POST events to.
+	Webhooks []Webhook `json:"webhooks,omitempty"`
+
+	// SpoolDir holds deliveries that failed after all retries; they are
+	// resent on the next notification. Empty means <config dir>/spool.
+	SpoolDir string `json:"spool_dir,omitempty"`
+
+	// Alerts sets the status thresholds that trigger an alert event.
+	Alerts AlertThresholds `json:"alerts"`
+}
+
+// Webhook is a single HTTP endpoint that receives events.
+type Webhook struct {
+	// URL is the endpoint events are POSTed to.
+	URL string `json:"url"`
+
+	// Template is an optional Go text/template for the request body. The
+	// event is the template data. Empty means the event as JSON.
+	Template string `json:"template,omitempty"`
+
+	// ContentType overrides the request Content-Type (default
+	// application/json).
+	ContentType string `json:"content_type,omitempty"`
+
+	// Headers are extra request headers (e.g. Authorization).
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Events restricts the event types sent ("session", "alert"). Empty
+	// means all events.
+	Events []string `json:"events,omitempty"`
+
+	// Timeout bounds each delivery attempt (e.g. "10s").
+	Timeout string `json:"timeout,omitempty"`
+
+	// Retries is the number of extra attempts after a failed delivery.
+	Retries int `json:"retries,omitempty"`
+}
+
+// AlertThresholds sets the status readings that trigger an alert. A zero
+// value disables that alert.
+type AlertThresholds struct {
+	// CPUPercent alerts when total CPU usage reaches this percentage.
+	CPUPercent float64 `json:"cpu_percent,omitempty"`
+
+	// MemoryPercent alerts when memory usage reaches this percentage.
+	MemoryPercent float64 `json:"memory_percent,omitempty"`
+
+	// DiskPercent alerts when any partition's usage reaches this percentage.
+	DiskPercent float64 `json:"disk_percent,omitempty"`
+}
+
+// FleetConfig connects this machine to a fleet policy shared by many
+// machines. Paths may be local or UNC (\\server\share\...).
+type FleetConfig struct {
+	// Policy is the path to the central policy file. Empty disables fleet
+	// mode.
+	Policy string `json:"policy,omitempty"`
+
+	// ReportsDir overrides the policy's reports directory.
+	ReportsDir string `json:"reports_dir,omitempty"`
+
+	// KeyFile holds the key used to sign reports. Empty writes unsigned
+	// reports.
+	KeyFile string `json:"key_file,omitempty"`
+}
+
+// SafetyConfig holds the deletion circuit breaker's per-run budgets and
+// the secure-delete settings. When a run would exceed a budget, or delete
+// outside the expected target roots, it pauses for confirmation (or aborts
+// when nobody can answer). Zero values disable a budget.
+type SafetyConfig struct {
+	// MaxBytesPerRun caps the bytes deleted in one run (e.g. "50GB").
+	MaxBytesPerRun string `json:"max_bytes_per_run,omitempty"`
+
+	// MaxItemsPerRun caps the number of paths deleted in one run.
+	MaxItemsPerRun int `json:"max_items_per_run,omitempty"`
+
+	// MaxDirShare caps the fraction (0–1) of any single top-level directory,
+	// such as %USERPROFILE%\AppData, that one run may delete.
+	MaxDirShare float64 `json:"max_dir_share,omitempty"`
+
+	// ShredPasses is the number of overwrite passes pw clean --shred makes
+	// over each file. Zero means one.
+	ShredPasses int `json:"shred_passes,omitempty"`
+}
+
+// GentleConfig configures gentle mode, which lowers the process's CPU and
+// IO priority and caps the rate of deletes and stats so a cleanup does not
+// make the machine sluggish. Guard runs are gentle unless --gentle=false.
+type GentleConfig struct {
+	// Always makes every run gentle, as if --gentle were given.
+	Always bool `json:"always,omitempty"`
+
+	// FilesPerSec caps deletes and stats per second. Zero means 200.
+	FilesPerSec int `json:"files_per_sec,omitempty"`
+
+	// BytesPerSec caps the bytes deleted per second (e.g. "50MB"). Empty
+	// means 100MB.
+	BytesPerSec string `json:"bytes_per_sec,omitempty"`
+}
+
+// ContainersConfig configures how container runtime data is pruned.
+type ContainersConfig struct {
+	// PruneUntil limits prunes to data older than this, as a duration
+	// ("24h", "0s" for everything) or an RFC 3339 timestamp. Empty means
+	// 24h. Volumes are never filtered by age.
+	PruneUntil string `json:"prune_until,omitempty"`
+}
+
 // configPath returns the full path to the config.json file.
 func configPath(configDir string) string {
 	return filepath.Join(configDir, ConfigFileName)
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/electron"
+)
+
+// ─── Electron App Caches ─────────────────────────────────────────────────────
+
+// electronTargets returns a clean target per Electron application found
+// under the app data folders, named "Electron" plus the application's
+// name (e.g. "ElectronSlack"). Each lists only its own application's
+// processes, so one running app leaves the others' caches cleanable.
+// Cache folders one of others already cleans (a browser's, VS Code's) are
+// left to it, and an application left with none gets no target.
+func electronTargets(local, roaming string, others []CleanTarget) []CleanTarget {
+	covered := coveredPaths(others)
+
+	var targets []CleanTarget
+	index := make(map[string]int)
+	for _, app := range electron.Find(roaming, local) {
+		var caches []string
+		for _, dir := range app.Caches {
+			if !covered[strings.ToLower(filepath.Clean(dir))] {
+				caches = append(caches, dir)
+			}
+		}
+		if len(caches) == 0 {
+			continue
+		}
+
+		// The same application may keep a profile on both sides.
+		name := "Electron" + targetSuffix(app.Name)
+		if i, ok := index[strings.ToLower(name)]; ok {
+			targets[i].Paths = append(targets[i].Paths, caches...)
+			continue
+		}
+		index[strings.ToLower(name)] = len(targets)
+		targets = append(targets, CleanTarget{
+			Name:          name,
+			Paths:         caches,
+			Description:   app.Name + " cache",
+			RequiresAdmin: false,
+			Category:      "electron",
+			RiskLevel:     "low",
+			Processes:     app.Processes(),
+		})
+	}
+	return targets
+}
+
+// targetSuffix turns an application's folder name into a target name
+// suffix by dropping everything but letters and digits ("Docker Desktop"
+// becomes "DockerDesktop").
+func targetSuffix(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// coveredPaths returns the directories the path-based targets clean,
+// lower-cased, with glob patterns expanded.
+func coveredPaths(targets []CleanTarget) map[string]bool {
+	covered := make(map[string]bool)
+	for _, t := range targets {
+		if t.Kind != KindPaths {
+			continue
+		}
+		for _, pattern := range t.Paths {
+			matches, err := filepath.Glob(pattern)
+			if err != nil || len(matches) == 0 {
+				matches = []string{pattern}
+			}
+			for _, m := range matches {
+				covered[strings.ToLower(filepath.Clean(m))] = true
+			}
+		}
+	}
+	return covered
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// mkElectronProfile creates an Electron profile at dir with the given
+// cache folders.
+func mkElectronProfile(t *testing.T, dir string, caches ...string) {
+	t.Helper()
+	for _, c := range caches {
+		if err := os.MkdirAll(filepath.Join(dir, c), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{"Local State", "Preferences"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestElectronTargets_OnePerApp(t *testing.T) {
+	local, roaming := t.TempDir(), t.TempDir()
+	mkElectronProfile(t, filepath.Join(roaming, "Slack"), "Cache", "GPUCache")
+	mkElectronProfile(t, filepath.Join(roaming, "Docker Desktop"), "Cache")
+	mkElectronProfile(t, filepath.Join(roaming, "Code"), "Cache")
+
+	// VS Code's cache already belongs to another target.
+	others := []CleanTarget{{Name: "VSCodeCache", Paths: []string{filepath.Join(roaming, "Code", "Cache")}}}
+
+	targets := electronTargets(local, roaming, others)
+	byName := make(map[string]CleanTarget)
+	for _, tgt := range targets {
+		byName[tgt.Name] = tgt
+	}
+	if len(targets) != 2 {
+		t.Fatalf("electronTargets() = %+v, want Slack and Docker Desktop only", targets)
+	}
+
+	slack := byName["ElectronSlack"]
+	if !slices.Equal(slack.Processes, []string{"Slack.exe"}) {
+		t.Errorf("ElectronSlack.Processes = %q, want Slack.exe alone", slack.Processes)
+	}
+	if len(slack.Paths) != 2 || slack.Category != "electron" {
+		t.Errorf("ElectronSlack = %+v, want its two caches in the electron category", slack)
+	}
+
+	docker := byName["ElectronDockerDesktop"]
+	if !slices.Equal(docker.Processes, []string{"Docker Desktop.exe"}) {
+		t.Errorf("ElectronDockerDesktop.Processes = %q, want Docker Desktop.exe", docker.Processes)
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"encoding/xml"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/lakshaymaurya-felt/purewin/internal/envutil"
 )
@@ -21,13 +23,78 @@ type CleanTarget struct {
 	// RequiresAdmin indicates whether elevated privileges are needed.
 	RequiresAdmin bool
 
-	// Category groups related targets (e.g., "user", "system", "browser", "dev").
+	// Category groups related targets (e.g., "user", "system", "browser",
+	// "electron", "dev").
 	Category string
 
-	// RiskLevel is one of "low", "medium", "high".
+	// RiskLevel is one of RiskLevels.
 	RiskLevel string
+
+	// Processes lists the image names of the applications that own the
+	// target's files (e.g. "chrome.exe"). While one of them runs, its
+	// files are in use and the target is best left alone.
+	Processes []string
+
+	// Kind says how internal/clean scans and cleans the target. The
+	// default, KindPaths, walks Paths and deletes the files found; the
+	// other kinds discover their files at scan time or clean through a
+	// tool or system API.
+	Kind string
+}
+
+// RiskLevels are the risk levels a target can have, lowest first.
+var RiskLevels = []string{"low", "medium", "high"}
+
+// RiskRank returns the position of a risk level in RiskLevels (0 = low),
+// ignoring case, or -1 if the level is unknown.
+func RiskRank(level string) int {
+	for i, l := range RiskLevels {
+		if strings.EqualFold(l, level) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RiskWithin reports whether risk is at or below max. An empty max allows
+// everything; an unknown risk is treated as high.
+func RiskWithin(risk, max string) bool {
+	if max == "" {
+		return true
+	}
+	rank := RiskRank(risk)
+	if rank < 0 {
+		rank = len(RiskLevels) - 1
+	}
+	return rank <= RiskRank(max)
 }
 
+// Target kinds.
+const (
+	// KindPaths walks Paths (which may hold glob patterns).
+	KindPaths = ""
+
+	// KindDriveTemp finds temp and junk files on non-system drives.
+	KindDriveTemp = "drive-temp"
+
+	// KindDriveWindowsOld finds Windows.old folders on non-system drives.
+	KindDriveWindowsOld = "drive-windows-old"
+
+	// KindRecycleBin empties the Recycle Bin through the Shell API.
+	KindRecycleBin = "recycle-bin"
+
+	// KindGoModCache runs go clean -modcache.
+	KindGoModCache = "go-modcache"
+
+	// KindContainer prunes one kind of container runtime data (images,
+	// containers, volumes, build cache) through the docker or podman CLI.
+	KindContainer = "container"
+
+	// KindWindowsOld removes the system drive's Windows.old after an
+	// extra confirmation.
+	KindWindowsOld = "windows-old"
+)
+
 // expand resolves environment variables in a path, supporting both
 // Windows %VAR% and Unix $VAR / ${VAR} syntax.
 func expand(path string) string {
@@ -58,13 +125,13 @@ func winDir() string {
 	return `C:\Windows`
 }
 
-// programData returns the ProgramData directory (e.g., C:\ProgramData).
-// Falls back to C:\ProgramData only if %PROGRAMDATA% is not set.
-func programData() string {
-	if p := os.Getenv("PROGRAMDATA"); p != "" {
-		return p
-	}
-	return `C:\ProgramData`
+// MachinePolicyPath returns the machine-wide admin policy file,
+// %ProgramData%\purewin\policy.json. Only administrators can normally
+// write there, so users cannot relax it. The directory comes from the
+// known folder, never the PROGRAMDATA variable, which any user can point
+// at a file of their own.
+func MachinePolicyPath() string {
+	return filepath.Join(programData(), AppName, "policy.json")
 }
 
 // systemDrive returns the system drive letter with backslash (e.g., C:\).
@@ -92,13 +159,96 @@ func programFilesX86() string {
 	return `C:\Program Files (x86)`
 }
 
+// envDir returns the directory named by the environment variable name, or
+// fallback when it is unset or not a usable cache location.
+func envDir(name, fallback string) string {
+	return envDirs(name, "", fallback)[0]
+}
+
+// envDirs returns the directories listed in the environment variable name,
+// split on sep (no splitting when sep is empty). Unusable entries are
+// dropped; when none is left, it returns fallback.
+func envDirs(name, sep string, fallback ...string) []string {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	parts := []string{value}
+	if sep != "" {
+		parts = strings.Split(value, sep)
+	}
+	var dirs []string
+	for _, part := range parts {
+		dir := filepath.Clean(expand(strings.TrimSpace(part)))
+		if usableOverride(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return fallback
+	}
+	return dirs
+}
+
+// usableOverride reports whether dir may stand in for a cache directory:
+// an absolute path that is not a drive root, a profile or app data folder,
+// or a NEVER_DELETE path or one of its parents. A cache variable pointing
+// at any of those would hand the whole folder to the cleaner.
+func usableOverride(dir string) bool {
+	if dir == "" || !filepath.IsAbs(dir) || filepath.Dir(dir) == dir {
+		return false
+	}
+	key := strings.ToLower(dir)
+	for _, p := range []string{userProfile(), localAppData(), appData()} {
+		if p != "" && key == strings.ToLower(filepath.Clean(p)) {
+			return false
+		}
+	}
+	for _, nd := range GetNeverDeletePaths() {
+		ndKey := strings.ToLower(filepath.Clean(nd))
+		if ndKey == key || strings.HasPrefix(ndKey+string(filepath.Separator), key+string(filepath.Separator)) {
+			return false
+		}
+	}
+	return true
+}
+
+// mavenRepository returns Maven's local repository: -Dmaven.repo.local in
+// MAVEN_OPTS, else <localRepository> in ~/.m2/settings.xml, else
+// ~/.m2/repository.
+func mavenRepository(home string) string {
+	for _, opt := range strings.Fields(os.Getenv("MAVEN_OPTS")) {
+		if dir, ok := strings.CutPrefix(opt, "-Dmaven.repo.local="); ok {
+			if dir = filepath.Clean(expand(strings.Trim(dir, `"`))); usableOverride(dir) {
+				return dir
+			}
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(home, ".m2", "settings.xml")); err == nil {
+		var settings struct {
+			LocalRepository string `xml:"localRepository"`
+		}
+		if xml.Unmarshal(data, &settings) == nil {
+			dir := filepath.Clean(expand(strings.TrimSpace(settings.LocalRepository)))
+			if usableOverride(dir) {
+				return dir
+			}
+		}
+	}
+	return filepath.Join(home, ".m2", "repository")
+}
+
 // GetCleanTargets returns all available cleanup targets with paths expanded.
+// It is the single list of clean targets: internal/clean builds its
+// providers from it, so a new target only needs an entry here.
 func GetCleanTargets() []CleanTarget {
 	home := userProfile()
 	local := localAppData()
 	roaming := appData()
+	cargoHome := envDir("CARGO_HOME", filepath.Join(home, ".cargo"))
+	goPath := envDirs("GOPATH", string(os.PathListSeparator), filepath.Join(home, "go"))[0]
 
-	return []CleanTarget{
+	targets := []CleanTarget{
 		// ── User Temp ───────────────────────────────────────────
 		{
 			Name:          "UserTemp",
@@ -118,109 +268,279 @@ func GetCleanTargets() []CleanTarget {
 			Category:      "system",
 			RiskLevel:     "low",
 		},
+	}
+
+	// ── Browser Caches ──────────────────────────────────────
+	// Profiles come from each browser's own profile list.
+	targets = append(targets, browserTargets(local, roaming)...)
 
-		// ── Browser Caches ──────────────────────────────────────
+	rest := []CleanTarget{
+		// ── Developer Caches ────────────────────────────────────
+		// Locations honour each tool's own override (environment
+		// variable or config file) before falling back to the default.
 		{
-			Name: "ChromeCache",
+			Name: "NpmCache",
 			Paths: []string{
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Service Worker", "CacheStorage"),
+				envDir("npm_config_cache", filepath.Join(local, "npm-cache")),
+				filepath.Join(roaming, "npm-cache"),
 			},
-			Description:   "Google Chrome browser cache",
+			Description:   "npm package manager cache",
 			RequiresAdmin: false,
-			Category:      "browser",
+			Category:      "dev",
 			RiskLevel:     "low",
 		},
 		{
-			Name: "EdgeCache",
-			Paths: []string{
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Service Worker", "CacheStorage"),
-			},
-			Description:   "Microsoft Edge browser cache",
+			Name:          "PnpmStore",
+			Paths:         []string{envDir("npm_config_store_dir", filepath.Join(envDir("PNPM_HOME", filepath.Join(local, "pnpm")), "store"))},
+			Description:   "pnpm content-addressable package store",
 			RequiresAdmin: false,
-			Category:      "browser",
-			RiskLevel:     "low",
+			Category:      "dev",
+			RiskLevel:     "medium",
 		},
 		{
-			Name: "FirefoxCache",
+			Name: "YarnCache",
 			Paths: []string{
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "cache2"),
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "startupCache"),
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "thumbnails"),
+				envDir("YARN_CACHE_FOLDER", filepath.Join(local, "Yarn", "Cache")),
+				filepath.Join(envDir("YARN_GLOBAL_FOLDER", filepath.Join(local, "Yarn", "Berry")), "cache"),
 			},
-			Description:   "Mozilla Firefox browser cache (cache2 within profiles)",
+			Description:   "Yarn package cache (v1 and Berry global cache)",
 			RequiresAdmin: false,
-			Category:      "browser",
+			Category:      "dev",
 			RiskLevel:     "low",
 		},
 		{
-			Name: "BraveCache",
-			Paths: []string{
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "GPUCache"),
-			},
-			Description:   "Brave browser cache",
+			Name:          "BunCache",
+			Paths:         []string{envDir("BUN_INSTALL_CACHE_DIR", filepath.Join(envDir("BUN_INSTALL", filepath.Join(home, ".bun")), "install", "cache"))},
+			Description:   "Bun package cache",
 			RequiresAdmin: false,
-			Category:      "browser",
+			Category:      "dev",
 			RiskLevel:     "low",
 		},
-
-		// ── Developer Caches ────────────────────────────────────
 		{
-			Name:          "NpmCache",
-			Paths:         []string{filepath.Join(roaming, "npm-cache")},
-			Description:   "npm package manager cache",
+			Name:          "DenoCache",
+			Paths:         []string{envDir("DENO_DIR", filepath.Join(local, "deno"))},
+			Description:   "Deno module and npm cache",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
 		},
 		{
 			Name:          "PipCache",
-			Paths:         []string{filepath.Join(local, "pip", "Cache")},
+			Paths:         []string{envDir("PIP_CACHE_DIR", filepath.Join(local, "pip", "Cache"))},
 			Description:   "Python pip package cache",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
 		},
 		{
-			Name:          "CargoCache",
-			Paths:         []string{filepath.Join(home, ".cargo", "registry", "cache")},
+			Name:          "PoetryCache",
+			Paths:         []string{envDir("POETRY_CACHE_DIR", filepath.Join(local, "pypoetry", "Cache"))},
+			Description:   "Poetry package and virtualenv cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "PipenvCache",
+			Paths:         []string{envDir("PIPENV_CACHE_DIR", filepath.Join(local, "pipenv", "pipenv", "Cache"))},
+			Description:   "pipenv package cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "UvCache",
+			Paths:         []string{envDir("UV_CACHE_DIR", filepath.Join(local, "uv", "cache"))},
+			Description:   "uv package cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name: "CargoCache",
+			Paths: []string{
+				// NEVER include .cargo\bin — only registry caches.
+				filepath.Join(cargoHome, "registry", "cache"),
+				filepath.Join(cargoHome, "registry", "src"),
+			},
 			Description:   "Rust cargo registry cache",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
 		},
+		{
+			Name: "CondaPkgs",
+			Paths: envDirs("CONDA_PKGS_DIRS", ",",
+				filepath.Join(home, "miniconda3", "pkgs"),
+				filepath.Join(home, "anaconda3", "pkgs"),
+				filepath.Join(home, ".conda", "pkgs"),
+			),
+			Description:   "Conda package cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+		},
 		{
 			Name:          "GradleCache",
-			Paths:         []string{filepath.Join(home, ".gradle", "caches")},
+			Paths:         []string{filepath.Join(envDir("GRADLE_USER_HOME", filepath.Join(home, ".gradle")), "caches")},
 			Description:   "Gradle build cache",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
 		},
+		{
+			Name:          "MavenRepository",
+			Paths:         []string{mavenRepository(home)},
+			Description:   "Maven local repository",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+		},
 		{
 			Name:          "NuGetCache",
-			Paths:         []string{filepath.Join(home, ".nuget", "packages")},
+			Paths:         []string{envDir("NUGET_PACKAGES", filepath.Join(home, ".nuget", "packages"))},
 			Description:   "NuGet package cache",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "medium",
 		},
+		{
+			Name:          "ComposerCache",
+			Paths:         []string{envDir("COMPOSER_CACHE_DIR", filepath.Join(local, "Composer"))},
+			Description:   "PHP Composer download cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "GoBuildCache",
+			Paths:         []string{envDir("GOCACHE", filepath.Join(local, "go-build"))},
+			Description:   "Go build cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "CCache",
+			Paths:         []string{envDir("CCACHE_DIR", filepath.Join(local, "ccache"))},
+			Description:   "ccache compiler cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "SCCache",
+			Paths:         []string{envDir("SCCACHE_DIR", filepath.Join(local, "Mozilla", "sccache", "cache"))},
+			Description:   "sccache compiler cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "BazelOutputBase",
+			Paths:         []string{filepath.Join(home, "_bazel_*")},
+			Description:   "Bazel output bases (next build starts from scratch)",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+		},
+		{
+			Name:          "PlaywrightBrowsers",
+			Paths:         []string{envDir("PLAYWRIGHT_BROWSERS_PATH", filepath.Join(local, "ms-playwright"))},
+			Description:   "Playwright browser downloads",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+		},
+		{
+			Name:          "CypressCache",
+			Paths:         []string{envDir("CYPRESS_CACHE_FOLDER", filepath.Join(local, "Cypress", "Cache"))},
+			Description:   "Cypress binary cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+			Processes:     []string{"Cypress.exe"},
+		},
 		{
 			Name: "GoModCache",
 			Paths: []string{
-				filepath.Join(home, "go", "pkg", "mod", "cache"),
+				filepath.Join(envDir("GOMODCACHE", filepath.Join(goPath, "pkg", "mod")), "cache"),
 			},
-			Description:   "Go module download cache",
+			Description:   "Go module download cache (go clean -modcache)",
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
+			Kind:          KindGoModCache,
+		},
+
+		// ── Container Runtimes ──────────────────────────────────
+		// Each kind of data is its own target so it can be selected or
+		// excluded on its own. Image, container and build cache prunes
+		// only touch data older than containers.prune_until (default
+		// 24h). Volumes cannot be filtered by age: every unused volume is
+		// pruned, and they hold real data.
+		{
+			Name:          "DockerImages",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Docker dangling images",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "DockerContainers",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Docker stopped containers",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "DockerVolumes",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Docker unused volumes",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "high",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "DockerBuildCache",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Docker build cache",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "PodmanImages",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Podman dangling images",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "low",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "PodmanContainers",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Podman stopped containers",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "medium",
+			Kind:          KindContainer,
+		},
+		{
+			Name:          "PodmanVolumes",
+			Paths:         []string{}, // Pruned via the runtime CLI, no direct path
+			Description:   "Podman unused volumes",
+			RequiresAdmin: false,
+			Category:      "dev",
+			RiskLevel:     "high",
+			Kind:          KindContainer,
 		},
 
 		// ── IDE Caches ──────────────────────────────────────────
@@ -237,6 +557,7 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "low",
+			Processes:     []string{"Code.exe"},
 		},
 		{
 			Name: "JetBrainsCache",
@@ -249,6 +570,10 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "medium",
+			Processes: []string{
+				"idea64.exe", "goland64.exe", "pycharm64.exe", "webstorm64.exe", "rider64.exe",
+				"clion64.exe", "phpstorm64.exe", "rubymine64.exe", "datagrip64.exe",
+			},
 		},
 		{
 			Name: "VisualStudioCache",
@@ -260,6 +585,7 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "dev",
 			RiskLevel:     "medium",
+			Processes:     []string{"devenv.exe"},
 		},
 
 		// ── System Caches ───────────────────────────────────────
@@ -321,7 +647,7 @@ func GetCleanTargets() []CleanTarget {
 		{
 			Name: "Thumbnails",
 			Paths: []string{
-				filepath.Join(local, "Microsoft", "Windows", "Explorer"),
+				filepath.Join(local, "Microsoft", "Windows", "Explorer", "thumbcache_*.db"),
 			},
 			Description:   "Windows Explorer thumbnail cache (thumbcache_*.db)",
 			RequiresAdmin: false,
@@ -339,7 +665,7 @@ func GetCleanTargets() []CleanTarget {
 			Description:   "Kernel and minidump crash files",
 			RequiresAdmin: true,
 			Category:      "system",
-			RiskLevel:     "low",
+			RiskLevel:     "high",
 		},
 
 		// ── Windows.old ─────────────────────────────────────────
@@ -350,6 +676,27 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: true,
 			Category:      "system",
 			RiskLevel:     "high",
+			Kind:          KindWindowsOld,
+		},
+
+		// ── Other Drives ────────────────────────────────────────
+		{
+			Name:          "DriveTemp",
+			Paths:         []string{}, // Discovered per drive at scan time
+			Description:   "Temp and junk files on other drives",
+			RequiresAdmin: false,
+			Category:      "user",
+			RiskLevel:     "low",
+			Kind:          KindDriveTemp,
+		},
+		{
+			Name:          "DriveWindowsOld",
+			Paths:         []string{}, // Discovered per drive at scan time
+			Description:   "Windows.old folders on other drives",
+			RequiresAdmin: true,
+			Category:      "system",
+			RiskLevel:     "high",
+			Kind:          KindDriveWindowsOld,
 		},
 
 		// ── Recycle Bin ─────────────────────────────────────────
@@ -360,8 +707,14 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "user",
 			RiskLevel:     "medium",
+			Kind:          KindRecycleBin,
 		},
 	}
+
+	// ── Electron App Caches ─────────────────────────────────
+	// One target per app found, minus the folders the others clean.
+	targets = append(targets, electronTargets(local, roaming, append(targets, rest...))...)
+	return append(targets, rest...)
 }
 
 // GetTargetsByCategory returns clean targets filtered by category.
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -57,9 +58,10 @@ func TestGetCleanTargets_AllHaveRequiredFields(t *testing.T) {
 		if !validRisk {
 			t.Errorf("CleanTarget %q has invalid RiskLevel %q", target.Name, target.RiskLevel)
 		}
-		// RecycleBin uses shell API with no direct paths — that's expected.
-		if target.Name != "RecycleBin" && len(target.Paths) == 0 {
-			t.Errorf("CleanTarget %q has no Paths (and is not RecycleBin)", target.Name)
+		// Only targets that walk their paths need any — the Recycle Bin,
+		// Docker and the other drives are found another way.
+		if target.Kind == KindPaths && len(target.Paths) == 0 {
+			t.Errorf("CleanTarget %q has no Paths (and is a %q target)", target.Name, KindPaths)
 		}
 	}
 }
@@ -119,3 +121,61 @@ func TestGetTargetsByCategory(t *testing.T) {
 		}
 	}
 }
+
+func TestGetCleanTargets_EnvOverrides(t *testing.T) {
+	profile := t.TempDir()
+	t.Setenv("USERPROFILE", profile)
+	uvCache := filepath.Join(t.TempDir(), "uv")
+	t.Setenv("UV_CACHE_DIR", uvCache)
+	gradleHome := filepath.Join(t.TempDir(), "gradle")
+	t.Setenv("GRADLE_USER_HOME", gradleHome)
+	t.Setenv("PIP_CACHE_DIR", profile) // the whole profile: ignored
+
+	paths := make(map[string][]string)
+	for _, target := range GetCleanTargets() {
+		paths[target.Name] = target.Paths
+	}
+	if got := paths["UvCache"]; len(got) != 1 || got[0] != uvCache {
+		t.Errorf("UvCache paths = %q, want [%s]", got, uvCache)
+	}
+	if got := paths["GradleCache"]; len(got) != 1 || got[0] != filepath.Join(gradleHome, "caches") {
+		t.Errorf("GradleCache paths = %q, want under %s", got, gradleHome)
+	}
+	if got := paths["PipCache"]; len(got) != 1 || got[0] == profile {
+		t.Errorf("PipCache paths = %q; an override naming the user profile must be ignored", got)
+	}
+}
+
+func TestMavenRepository_FromSettings(t *testing.T) {
+	home := t.TempDir()
+	repo := filepath.Join(t.TempDir(), "m2repo")
+	settings := "<settings><localRepository>" + repo + "</localRepository></settings>"
+	if err := os.MkdirAll(filepath.Join(home, ".m2"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".m2", "settings.xml"), []byte(settings), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MAVEN_OPTS", "")
+	if got := mavenRepository(home); got != repo {
+		t.Errorf("mavenRepository() = %q, want %q from settings.xml", got, repo)
+	}
+
+	other := filepath.Join(t.TempDir(), "opts-repo")
+	t.Setenv("MAVEN_OPTS", "-Xmx1g -Dmaven.repo.local="+other)
+	if got := mavenRepository(home); got != other {
+		t.Errorf("mavenRepository() = %q, want %q from MAVEN_OPTS", got, other)
+	}
+}
+
+func TestMachinePolicyPath_IgnoresProgramDataEnv(t *testing.T) {
+	fake := t.TempDir()
+	t.Setenv("PROGRAMDATA", fake)
+	got := MachinePolicyPath()
+	if strings.HasPrefix(strings.ToLower(got), strings.ToLower(fake)) {
+		t.Errorf("MachinePolicyPath() = %q, followed PROGRAMDATA=%q", got, fake)
+	}
+	if filepath.Base(got) != "policy.json" {
+		t.Errorf("MachinePolicyPath() = %q, want a policy.json file", got)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package config
+
+// programData returns the default ProgramData directory. Other platforms
+// have no known folder to resolve it from.
+func programData() string {
+	return `C:\ProgramData`
+}
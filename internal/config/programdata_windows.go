@@ -0,0 +1,15 @@
+//go:build windows
+
+package config
+
+import "golang.org/x/sys/windows"
+
+// programData returns the ProgramData directory (e.g., C:\ProgramData),
+// resolved from the known folder rather than %PROGRAMDATA%. Falls back to
+// C:\ProgramData only if the known folder cannot be resolved.
+func programData() string {
+	if p, err := windows.KnownFolderPath(windows.FOLDERID_ProgramData, 0); err == nil && p != "" {
+		return p
+	}
+	return `C:\ProgramData`
+}
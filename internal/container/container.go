@@ -0,0 +1,262 @@
+// Package container reclaims space from container runtimes (Docker and
+// Podman): dangling images, stopped containers, unused volumes and the
+// build cache. Sizes come from the runtime's JSON output and cleanup runs
+// through its own prune commands.
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/pkgtool"
+)
+
+// Runtimes.
+const (
+	Docker = "docker"
+	Podman = "podman"
+)
+
+// Kinds of reclaimable data.
+const (
+	Images     = "images"
+	Containers = "containers"
+	Volumes    = "volumes"
+	BuildCache = "build-cache"
+)
+
+// DefaultUntil is the default age filter for prunes: only data older than
+// this is removed, so a build or container from the last day survives.
+const DefaultUntil = "24h"
+
+// Resource is one kind of reclaimable data in one runtime, cleaned as its
+// own target.
+type Resource struct {
+	// Runtime is the CLI that manages it (Docker or Podman).
+	Runtime string
+
+	// Kind is what is reclaimed (Images, Containers, Volumes, BuildCache).
+	Kind string
+}
+
+// resources maps clean target names to the data they reclaim.
+var resources = map[string]Resource{
+	"DockerImages":     {Docker, Images},
+	"DockerContainers": {Docker, Containers},
+	"DockerVolumes":    {Docker, Volumes},
+	"DockerBuildCache": {Docker, BuildCache},
+	"PodmanImages":     {Podman, Images},
+	"PodmanContainers": {Podman, Containers},
+	"PodmanVolumes":    {Podman, Volumes},
+}
+
+// ForTarget returns the resource a clean target reclaims, if it is a
+// container target.
+func ForTarget(target string) (Resource, bool) {
+	r, ok := resources[target]
+	return r, ok
+}
+
+// Available reports whether the runtime's CLI is on PATH.
+func (r Resource) Available(run pkgtool.Runner) bool {
+	_, err := run.LookPath(r.Runtime)
+	return err == nil
+}
+
+// dfTypes are the system df rows for each kind. Dangling images are not
+// a df row; they are sized from the image list.
+var dfTypes = map[string]string{
+	Containers: "Containers",
+	Volumes:    "Local Volumes",
+	BuildCache: "Build Cache",
+}
+
+// Reclaimable returns the bytes a prune would free.
+func (r Resource) Reclaimable(ctx context.Context, run pkgtool.Runner) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, pkgtool.QueryTimeout)
+	defer cancel()
+
+	if r.Kind == Images {
+		out, err := run.Run(ctx, r.Runtime, "images", "--filter", "dangling=true", "--format", "{{json .}}")
+		if err != nil {
+			return 0, err
+		}
+		return sumImageSizes(out)
+	}
+
+	out, err := run.Run(ctx, r.Runtime, "system", "df", "--format", "{{json .}}")
+	if err != nil {
+		return 0, err
+	}
+	rows, err := parseSystemDF(out)
+	if err != nil {
+		return 0, err
+	}
+	return rows[dfTypes[r.Kind]], nil
+}
+
+// PruneArgs returns the prune command's arguments. until limits the prune
+// to data older than it (a duration such as "24h" or a timestamp); empty
+// means no limit. Volumes cannot be filtered by age and ignore it.
+func (r Resource) PruneArgs(until string) []string {
+	var args []string
+	switch r.Kind {
+	case Images:
+		args = []string{"image", "prune", "--force"}
+	case Containers:
+		args = []string{"container", "prune", "--force"}
+	case Volumes:
+		// Docker 23+ only prunes anonymous volumes without --all;
+		// Podman prunes every unused volume and has no such flag.
+		args = []string{"volume", "prune", "--force"}
+		if r.Runtime == Docker {
+			args = append(args, "--all")
+		}
+		return args
+	case BuildCache:
+		args = []string{"builder", "prune", "--all", "--force"}
+	}
+	if until != "" {
+		args = append(args, "--filter", "until="+until)
+	}
+	return args
+}
+
+// Command returns the prune command line, for display.
+func (r Resource) Command(until string) string {
+	return strings.Join(append([]string{r.Runtime}, r.PruneArgs(until)...), " ")
+}
+
+// Prune runs the prune command and returns the bytes freed, as the
+// runtime reports them or, when it does not, as the drop in Reclaimable.
+func (r Resource) Prune(ctx context.Context, run pkgtool.Runner, until string) (int64, error) {
+	before, _ := r.Reclaimable(ctx, run)
+
+	pruneCtx, cancel := context.WithTimeout(ctx, pkgtool.CleanTimeout)
+	defer cancel()
+	out, err := run.Run(pruneCtx, r.Runtime, r.PruneArgs(until)...)
+	if err != nil {
+		return 0, err
+	}
+	if freed, ok := parseReclaimed(out); ok {
+		return freed, nil
+	}
+	after, err := r.Reclaimable(ctx, run)
+	if err != nil {
+		return 0, nil
+	}
+	return max(before-after, 0), nil
+}
+
+// ─── Output Parsers ──────────────────────────────────────────────────────────
+
+// parseSystemDF reads system df --format '{{json .}}' output, one JSON
+// object per row, into the reclaimable bytes per row type.
+func parseSystemDF(out []byte) (map[string]int64, error) {
+	rows := make(map[string]int64)
+	for _, line := range jsonLines(out) {
+		var row struct {
+			Type        string
+			Reclaimable json.RawMessage
+		}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return nil, fmt.Errorf("parsing system df output: %w", err)
+		}
+		size, ok := parseSizeValue(row.Reclaimable)
+		if !ok {
+			return nil, fmt.Errorf("parsing system df output: bad size %s for %s", row.Reclaimable, row.Type)
+		}
+		rows[row.Type] = size
+	}
+	return rows, nil
+}
+
+// sumImageSizes totals the Size of every image in images --format
+// '{{json .}}' output.
+func sumImageSizes(out []byte) (int64, error) {
+	var total int64
+	for _, line := range jsonLines(out) {
+		var img struct {
+			Size json.RawMessage
+		}
+		if err := json.Unmarshal(line, &img); err != nil {
+			return 0, fmt.Errorf("parsing image list: %w", err)
+		}
+		size, ok := parseSizeValue(img.Size)
+		if !ok {
+			return 0, fmt.Errorf("parsing image list: bad size %s", img.Size)
+		}
+		total += size
+	}
+	return total, nil
+}
+
+// parseReclaimed reads the "Total reclaimed space: 1.2GB" line prunes
+// print.
+func parseReclaimed(out []byte) (int64, bool) {
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		if _, value, ok := strings.Cut(sc.Text(), "Total reclaimed space:"); ok {
+			return ParseSISize(value)
+		}
+	}
+	return 0, false
+}
+
+// jsonLines returns the non-empty lines of out.
+func jsonLines(out []byte) [][]byte {
+	var lines [][]byte
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseSizeValue reads a size that is either a JSON number of bytes or a
+// string such as "1.2GB (45%)".
+func parseSizeValue(raw json.RawMessage) (int64, bool) {
+	var n int64
+	if json.Unmarshal(raw, &n) == nil {
+		return n, true
+	}
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return 0, false
+	}
+	s, _, _ = strings.Cut(s, "(")
+	return ParseSISize(s)
+}
+
+// ParseSISize parses the sizes container runtimes print, such as "1.2GB",
+// "512kB" or "0B". Like the runtimes, it reads kB, MB and GB as SI powers
+// of 1000, and KiB, MiB and GiB as powers of 1024. Package managers round
+// the other way, so their output goes through pkgtool.ParseHumanSize,
+// which reads both as powers of 1024.
+func ParseSISize(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	multipliers := map[string]float64{
+		"": 1, "B": 1,
+		"KB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12, "PB": 1e15,
+		"KIB": 1 << 10, "MIB": 1 << 20, "GIB": 1 << 30, "TIB": 1 << 40,
+	}
+	m, ok := multipliers[strings.ToUpper(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, false
+	}
+	return int64(value * m), true
+}
@@ -0,0 +1,139 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeCLI is a container runtime CLI that answers known command lines with
+// canned output and records what it ran.
+type fakeCLI struct {
+	name    string
+	outputs map[string]string // Command line (after the name) to output.
+	ran     []string
+}
+
+func (f *fakeCLI) LookPath(name string) (string, error) {
+	if name != f.name {
+		return "", exec.ErrNotFound
+	}
+	return name, nil
+}
+
+func (f *fakeCLI) Run(_ context.Context, name string, args ...string) ([]byte, error) {
+	line := strings.Join(args, " ")
+	f.ran = append(f.ran, line)
+	out, ok := f.outputs[line]
+	if name != f.name || !ok {
+		return nil, errors.New("unknown command: " + name + " " + line)
+	}
+	return []byte(out), nil
+}
+
+const dockerDF = `{"Active":"2","Reclaimable":"1.5GB (60%)","Size":"2.5GB","TotalCount":"7","Type":"Images"}
+{"Active":"1","Reclaimable":"120MB (92%)","Size":"130.2MB","TotalCount":"4","Type":"Containers"}
+{"Active":"0","Reclaimable":"3.2GB (100%)","Size":"3.2GB","TotalCount":"2","Type":"Local Volumes"}
+{"Active":"0","Reclaimable":"845.3MB","Size":"845.3MB","TotalCount":"31","Type":"Build Cache"}
+`
+
+func newDocker() *fakeCLI {
+	return &fakeCLI{name: Docker, outputs: map[string]string{
+		"system df --format {{json .}}": dockerDF,
+		"images --filter dangling=true --format {{json .}}": `{"ID":"a1","Repository":"<none>","Size":"250MB"}` + "\n" +
+			`{"ID":"b2","Repository":"<none>","Size":"12.5kB"}` + "\n",
+		"builder prune --all --force --filter until=24h": "Deleted build cache objects:\nx1\nx2\n\nTotal reclaimed space: 845.3MB\n",
+		"container prune --force --filter until=24h":     "",
+	}}
+}
+
+func TestReclaimable(t *testing.T) {
+	docker := newDocker()
+	tests := []struct {
+		target string
+		want   int64
+	}{
+		{"DockerImages", 250e6 + 12500},
+		{"DockerContainers", 120e6},
+		{"DockerVolumes", 3.2e9},
+		{"DockerBuildCache", 845.3e6},
+	}
+	for _, tt := range tests {
+		r, ok := ForTarget(tt.target)
+		if !ok {
+			t.Fatalf("ForTarget(%q) not found", tt.target)
+		}
+		got, err := r.Reclaimable(context.Background(), docker)
+		if err != nil || got != tt.want {
+			t.Errorf("%s Reclaimable() = %d, %v, want %d", tt.target, got, err, tt.want)
+		}
+	}
+}
+
+func TestReclaimable_PodmanNumbers(t *testing.T) {
+	podman := &fakeCLI{name: Podman, outputs: map[string]string{
+		"images --filter dangling=true --format {{json .}}": `{"Id":"c3","Size":1048576}` + "\n",
+	}}
+	r, _ := ForTarget("PodmanImages")
+	if !r.Available(podman) {
+		t.Fatal("fake podman not available")
+	}
+	if got, err := r.Reclaimable(context.Background(), podman); err != nil || got != 1<<20 {
+		t.Errorf("Reclaimable() = %d, %v, want %d", got, err, 1<<20)
+	}
+	if r, _ := ForTarget("DockerImages"); r.Available(podman) {
+		t.Error("docker reported available with only podman on PATH")
+	}
+}
+
+func TestPrune_UsesFiltersAndReportsFreed(t *testing.T) {
+	docker := newDocker()
+	r, _ := ForTarget("DockerBuildCache")
+	freed, err := r.Prune(context.Background(), docker, DefaultUntil)
+	if err != nil || freed != 845.3e6 {
+		t.Errorf("Prune() = %d, %v, want %d from the reclaimed line", freed, err, int64(845.3e6))
+	}
+	if !slices.Contains(docker.ran, "builder prune --all --force --filter until=24h") {
+		t.Errorf("ran %q, want builder prune with until filter", docker.ran)
+	}
+
+	// Without a reclaimed line, the drop in reclaimable size counts.
+	docker = newDocker()
+	r, _ = ForTarget("DockerContainers")
+	if freed, err := r.Prune(context.Background(), docker, DefaultUntil); err != nil || freed != 0 {
+		t.Errorf("Prune() = %d, %v, want 0 (nothing changed)", freed, err)
+	}
+}
+
+func TestPruneArgs_Volumes(t *testing.T) {
+	docker, _ := ForTarget("DockerVolumes")
+	if got := docker.Command(DefaultUntil); got != "docker volume prune --force --all" {
+		t.Errorf("Command() = %q", got)
+	}
+	podman, _ := ForTarget("PodmanVolumes")
+	if got := podman.Command(DefaultUntil); got != "podman volume prune --force" {
+		t.Errorf("Command() = %q", got)
+	}
+}
+
+func TestParseSISize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"0B", 0, true},
+		{"1.5GB", 1.5e9, true},
+		{"512kB", 512e3, true},
+		{" 2 MiB", 2 << 20, true},
+		{"lots", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseSISize(tt.in); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseSISize(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
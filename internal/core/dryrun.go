@@ -15,6 +15,13 @@ type DryRunItem struct {
 	Path     string
 	Size     int64
 	Category string
+
+	// Problem is the SkipReason of a predicted failure, set only by a deep
+	// dry run; Size then counts nothing toward the total.
+	Problem string
+
+	// ReadOnly marks items holding read-only entries.
+	ReadOnly bool
 }
 
 // DryRunContext tracks what WOULD be deleted during a dry-run.
@@ -41,16 +48,22 @@ func (d *DryRunContext) Add(path string, size int64, category string) {
 	})
 }
 
-// TotalSize returns the total bytes that would be freed.
-func (d *DryRunContext) TotalSize() int64 {
+// AddProbed records an item checked by a deep dry run. A non-empty problem
+// is the SkipReason of a predicted failure: the item is listed with it but
+// frees nothing.
+func (d *DryRunContext) AddProbed(path string, size int64, category, problem string, readOnly bool) {
+	item := DryRunItem{Path: path, Size: size, Category: category, Problem: problem, ReadOnly: readOnly}
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	d.Items = append(d.Items, item)
+}
 
-	var total int64
-	for _, item := range d.Items {
-		total += item.Size
-	}
-	return total
+// TotalSize returns the total bytes that would be freed. Predicted
+// failures are left out.
+func (d *DryRunContext) TotalSize() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.TotalSizeUnlocked()
 }
 
 // Count returns the total number of items tracked.
@@ -61,6 +74,7 @@ func (d *DryRunContext) Count() int {
 }
 
 // categorySummary groups items by category and calculates totals.
+// Predicted failures are left out.
 func (d *DryRunContext) categorySummary() map[string]struct {
 	count int
 	size  int64
@@ -70,6 +84,9 @@ func (d *DryRunContext) categorySummary() map[string]struct {
 		size  int64
 	})
 	for _, item := range d.Items {
+		if item.Problem != "" {
+			continue
+		}
 		entry := summary[item.Category]
 		entry.count++
 		entry.size += item.Size
@@ -115,9 +132,28 @@ func (d *DryRunContext) PrintSummary() {
 	fmt.Println("  ──────────────────────────────────────────")
 	fmt.Printf("  %-20s  %5d items  %10s\n",
 		"TOTAL",
-		len(d.Items),
+		d.countUnlocked(),
 		FormatSize(d.TotalSizeUnlocked()),
 	)
+
+	if failures := d.problemSummary(); len(failures) > 0 {
+		fmt.Println()
+		fmt.Println("  Predicted failures (not counted above):")
+		for _, reason := range SkipReasons {
+			entry, ok := failures[reason]
+			if !ok {
+				continue
+			}
+			fmt.Printf("    %-18s  %5d items  %10s\n",
+				reason,
+				entry.count,
+				FormatSize(entry.size),
+			)
+		}
+	}
+	if n := d.readOnlyCount(); n > 0 {
+		fmt.Printf("  %d items contain read-only files; they will be cleared before deletion.\n", n)
+	}
 	fmt.Println()
 	fmt.Println("  Run without --dry-run to execute cleanup.")
 }
@@ -127,11 +163,57 @@ func (d *DryRunContext) PrintSummary() {
 func (d *DryRunContext) TotalSizeUnlocked() int64 {
 	var total int64
 	for _, item := range d.Items {
-		total += item.Size
+		if item.Problem == "" {
+			total += item.Size
+		}
 	}
 	return total
 }
 
+// countUnlocked counts the items predicted to succeed. Must only be called
+// while the lock is already held.
+func (d *DryRunContext) countUnlocked() int {
+	n := 0
+	for _, item := range d.Items {
+		if item.Problem == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// problemSummary groups predicted failures by reason.
+func (d *DryRunContext) problemSummary() map[string]struct {
+	count int
+	size  int64
+} {
+	summary := make(map[string]struct {
+		count int
+		size  int64
+	})
+	for _, item := range d.Items {
+		if item.Problem == "" {
+			continue
+		}
+		entry := summary[item.Problem]
+		entry.count++
+		entry.size += item.Size
+		summary[item.Problem] = entry
+	}
+	return summary
+}
+
+// readOnlyCount counts items flagged as holding read-only entries.
+func (d *DryRunContext) readOnlyCount() int {
+	n := 0
+	for _, item := range d.Items {
+		if item.ReadOnly {
+			n++
+		}
+	}
+	return n
+}
+
 // ExportToFile writes the dry-run results to a text file.
 // Default location: %APPDATA%\purewin\clean-list.txt
 func (d *DryRunContext) ExportToFile(path string) error {
@@ -156,7 +238,12 @@ func (d *DryRunContext) ExportToFile(path string) error {
 
 	// Group items by category.
 	grouped := make(map[string][]DryRunItem)
+	var failed []DryRunItem
 	for _, item := range d.Items {
+		if item.Problem != "" {
+			failed = append(failed, item)
+			continue
+		}
 		grouped[item.Category] = append(grouped[item.Category], item)
 	}
 
@@ -165,14 +252,22 @@ func (d *DryRunContext) ExportToFile(path string) error {
 		sb.WriteString(fmt.Sprintf("[%s] — %d items, %s\n",
 			strings.ToUpper(cat), entry.count, FormatSize(entry.size)))
 		for _, item := range grouped[cat] {
-			sb.WriteString(fmt.Sprintf("  %10s  %s\n", FormatSize(item.Size), item.Path))
+			sb.WriteString(fmt.Sprintf("  %10s  %s%s\n", FormatSize(item.Size), item.Path, exportMark(item)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(failed) > 0 {
+		sb.WriteString(fmt.Sprintf("[PREDICTED FAILURES] — %d items, not counted in the total\n", len(failed)))
+		for _, item := range failed {
+			sb.WriteString(fmt.Sprintf("  %10s  %s%s\n", FormatSize(item.Size), item.Path, exportMark(item)))
 		}
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString(strings.Repeat("=", 60) + "\n")
 	sb.WriteString(fmt.Sprintf("Total: %d items, %s\n",
-		len(d.Items), FormatSize(d.TotalSizeUnlocked())))
+		d.countUnlocked(), FormatSize(d.TotalSizeUnlocked())))
 
 	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
 		return fmt.Errorf("cannot write export file %s: %w", path, err)
@@ -180,3 +275,16 @@ func (d *DryRunContext) ExportToFile(path string) error {
 
 	return nil
 }
+
+// exportMark tags an exported line with its predicted failure and
+// read-only flag, e.g. " [locked]" or " [read-only]".
+func exportMark(item DryRunItem) string {
+	var mark string
+	if item.Problem != "" {
+		mark += " [" + item.Problem + "]"
+	}
+	if item.ReadOnly {
+		mark += " [read-only]"
+	}
+	return mark
+}
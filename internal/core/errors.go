@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ─── Error Taxonomy ──────────────────────────────────────────────────────────
+
+// Sentinel errors returned (wrapped) by ValidatePath, SafeDelete and
+// SafeDeleteWithWhitelist. Match them with errors.Is.
+var (
+	// ErrInvalidPath: the path is empty, relative, or contains traversal
+	// components or control characters.
+	ErrInvalidPath = errors.New("invalid path")
+
+	// ErrProtected: the path is a drive root, on the NEVER_DELETE list, or
+	// a link resolving to one.
+	ErrProtected = errors.New("path is protected")
+
+	// ErrWhitelisted: the user's whitelist covers the path.
+	ErrWhitelisted = errors.New("path is whitelisted")
+
+	// ErrLocked: another process holds the file open.
+	ErrLocked = errors.New("file is locked")
+
+	// ErrAccessDenied: the process lacks permission to remove the path.
+	ErrAccessDenied = errors.New("access denied")
+
+	// ErrVanished: the path disappeared while it was being deleted.
+	ErrVanished = errors.New("path vanished")
+
+	// ErrOutsideScope: the path's real location escapes its target root.
+	ErrOutsideScope = errors.New("path escapes its target root")
+)
+
+// Skip reasons reported by SkipReason, in display order.
+const (
+	ReasonLocked       = "locked"
+	ReasonAccessDenied = "access denied"
+	ReasonProtected    = "protected"
+	ReasonWhitelisted  = "whitelisted"
+	ReasonOutsideScope = "outside scope"
+	ReasonVanished     = "vanished"
+	ReasonInvalid      = "invalid path"
+	ReasonOther        = "other"
+)
+
+// SkipReasons lists every reason SkipReason can return, in display order.
+var SkipReasons = []string{
+	ReasonLocked, ReasonAccessDenied, ReasonProtected, ReasonWhitelisted,
+	ReasonOutsideScope, ReasonVanished, ReasonInvalid, ReasonOther,
+}
+
+// SkipReason names why err kept a path from being deleted.
+func SkipReason(err error) string {
+	switch {
+	case errors.Is(err, ErrLocked):
+		return ReasonLocked
+	case errors.Is(err, ErrAccessDenied):
+		return ReasonAccessDenied
+	case errors.Is(err, ErrProtected):
+		return ReasonProtected
+	case errors.Is(err, ErrWhitelisted):
+		return ReasonWhitelisted
+	case errors.Is(err, ErrOutsideScope):
+		return ReasonOutsideScope
+	case errors.Is(err, ErrVanished):
+		return ReasonVanished
+	case errors.Is(err, ErrInvalidPath):
+		return ReasonInvalid
+	}
+	return ReasonOther
+}
+
+// classifyOSError maps a raw filesystem error to its sentinel, or nil if it
+// is none of the known kinds.
+func classifyOSError(err error) error {
+	switch {
+	case isRetryableError(err):
+		return ErrLocked
+	case isAccessDenied(err):
+		return ErrAccessDenied
+	case os.IsNotExist(err):
+		return ErrVanished
+	}
+	return nil
+}
+
+// wrapOSError tags err with its sentinel so errors.Is matches both.
+func wrapOSError(err error) error {
+	if kind := classifyOSError(err); kind != nil {
+		return fmt.Errorf("%w: %w", kind, err)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestValidatePath_TypedErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"", ErrInvalidPath},
+		{"relative", ErrInvalidPath},
+		{`C:\Temp\..\Windows`, ErrInvalidPath},
+		{`C:\`, ErrProtected},
+		{`C:\Windows\System32`, ErrProtected},
+	}
+	for _, tc := range tests {
+		if err := ValidatePath(tc.path); !errors.Is(err, tc.want) {
+			t.Errorf("ValidatePath(%q) = %v, want %v", tc.path, err, tc.want)
+		}
+	}
+}
+
+func TestSafeDelete_TypedErrors(t *testing.T) {
+	if _, err := SafeDelete(`C:\Windows`, false); !errors.Is(err, ErrProtected) {
+		t.Errorf("SafeDelete(protected) = %v, want ErrProtected", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keep.tmp")
+	_, err := SafeDeleteWithWhitelist(path, false, func(string) bool { return true })
+	if !errors.Is(err, ErrWhitelisted) {
+		t.Errorf("SafeDeleteWithWhitelist() = %v, want ErrWhitelisted", err)
+	}
+}
+
+func TestSkipReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{wrapOSError(windows.ERROR_SHARING_VIOLATION), ReasonLocked},
+		{wrapOSError(windows.ERROR_ACCESS_DENIED), ReasonAccessDenied},
+		{fmt.Errorf("safety check failed: %w", ErrProtected), ReasonProtected},
+		{&ScopeError{Path: `C:\a`, Root: `C:\b`, Resolved: `C:\a`}, ReasonOutsideScope},
+		{errors.New("disk on fire"), ReasonOther},
+	}
+	for _, tc := range tests {
+		if got := SkipReason(tc.err); got != tc.want {
+			t.Errorf("SkipReason(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
@@ -44,8 +44,28 @@ func isAccessDenied(err error) bool {
 // SafeDelete removes a file or directory after safety validation.
 // In dryRun mode, it calculates and returns the size without deleting.
 // It retries up to 3 times with exponential backoff for locked files.
-// Returns the number of bytes freed (or that would be freed).
+// Returns the number of bytes freed (or that would be freed). Failures wrap
+// one of the sentinel errors in errors.go; see SkipReason.
 func SafeDelete(path string, dryRun bool) (int64, error) {
+	return SafeDeleteWithin(path, "", dryRun)
+}
+
+// SafeDeleteWithin is SafeDelete confined to root: it refuses, with a
+// *ScopeError, any path whose real location escapes root once symlinks and
+// junctions are resolved. An empty root skips the scope check. Directories
+// are removed without traversing links inside them; a link is removed as a
+// link, never followed.
+func SafeDeleteWithin(path, root string, dryRun bool) (int64, error) {
+	return SafeDeleteWith(path, root, dryRun, nil)
+}
+
+// SafeDeleteWith is SafeDeleteWithin with a pluggable deletion strategy:
+// after every safety check passes, each file, directory and link is handed
+// to r. A nil r deletes plainly; see Shredder for the alternative.
+func SafeDeleteWith(path, root string, dryRun bool, r Remover) (int64, error) {
+	if r == nil {
+		r = unlink{}
+	}
 	// Validate path through safety checks.
 	if err := ValidatePath(path); err != nil {
 		return 0, fmt.Errorf("safety check failed for %s: %w", path, err)
@@ -57,18 +77,22 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 		if os.IsNotExist(err) {
 			return 0, nil // Nothing to delete.
 		}
-		return 0, fmt.Errorf("cannot stat %s: %w", path, err)
+		return 0, fmt.Errorf("cannot stat %s: %w", path, wrapOSError(err))
 	}
 
-	// Calculate size.
-	var size int64
-	if info.IsDir() {
-		size, err = GetDirSize(path)
-		if err != nil {
-			// Non-fatal: we can still attempt deletion.
-			size = 0
+	if root != "" {
+		if err := checkWithin(path, root); err != nil {
+			return 0, err
 		}
-	} else {
+	}
+
+	// Calculate size. Links free nothing but themselves.
+	var size int64
+	switch {
+	case IsReparsePoint(info):
+	case info.IsDir():
+		size = treeSize(path)
+	default:
 		size = info.Size()
 	}
 
@@ -84,12 +108,7 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 			time.Sleep(backoff)
 		}
 
-		if info.IsDir() {
-			lastErr = os.RemoveAll(path)
-		} else {
-			lastErr = os.Remove(path)
-		}
-
+		lastErr = removeTree(path, r)
 		if lastErr == nil {
 			return size, nil
 		}
@@ -99,17 +118,11 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 			continue
 		}
 
-		// For access denied, try removing read-only attribute and retry.
-		if isAccessDenied(lastErr) && !info.IsDir() {
-			_ = os.Chmod(path, 0o666)
-			continue
-		}
-
 		// Non-retryable error: bail out.
 		break
 	}
 
-	return 0, fmt.Errorf("failed to delete %s after %d attempts: %w", path, maxRetries, lastErr)
+	return 0, fmt.Errorf("failed to delete %s after %d attempts: %w", path, maxRetries, wrapOSError(lastErr))
 }
 
 // SafeDeleteWithWhitelist removes a file or directory after checking
@@ -121,7 +134,7 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 func SafeDeleteWithWhitelist(path string, dryRun bool, isWhitelisted func(string) bool) (int64, error) {
 	// Check whitelist BEFORE any other validation.
 	if isWhitelisted != nil && isWhitelisted(path) {
-		return 0, fmt.Errorf("path is whitelisted and will be skipped: %s", path)
+		return 0, fmt.Errorf("%w and will be skipped: %s", ErrWhitelisted, path)
 	}
 
 	return SafeDelete(path, dryRun)
@@ -171,6 +184,12 @@ func SafeCleanDir(dir string, pattern string, dryRun bool) (int64, int, error) {
 
 // GetDirSize calculates the total size of all files in a directory tree.
 func GetDirSize(path string) (int64, error) {
+	return PacedDirSize(path, nil)
+}
+
+// PacedDirSize is GetDirSize waiting on p, if set, before each file is
+// statted.
+func PacedDirSize(path string, p Pacer) (int64, error) {
 	var total int64
 	err := filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -178,6 +197,9 @@ func GetDirSize(path string) (int64, error) {
 			return nil
 		}
 		if !d.IsDir() {
+			if p != nil {
+				p.Wait(1, 0)
+			}
 			info, infoErr := d.Info()
 			if infoErr != nil {
 				return nil
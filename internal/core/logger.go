@@ -116,6 +116,23 @@ func (l *Logger) LogSummary(freed int64, files int, errCount int) {
 	_, _ = l.file.WriteString(line)
 }
 
+// LogEvent writes a free-form event entry (e.g. hook output) to the log file.
+func (l *Logger) LogEvent(operation, detail string) {
+	if !l.enabled || l.file == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	line := fmt.Sprintf("[%s] EVENT %s %s\n",
+		time.Now().Format(logTimeFormat),
+		operation,
+		detail,
+	)
+	_, _ = l.file.WriteString(line)
+}
+
 // Close flushes and closes the log file.
 func (l *Logger) Close() {
 	if l.file != nil {
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// fileDeleteChild is the FILE_DELETE_CHILD directory access right.
+const fileDeleteChild = 0x40
+
+// Probe is the predicted outcome of deleting a path, found without
+// deleting anything.
+type Probe struct {
+	// Size is the number of bytes that would be freed.
+	Size int64
+
+	// Err is the first predicted failure, wrapping one of the sentinel
+	// errors; nil when everything under the path looks removable.
+	Err error
+
+	// ReadOnly reports read-only entries. They are still deleted, after
+	// the attribute is cleared, but are worth knowing about.
+	ReadOnly bool
+}
+
+// ProbeDelete predicts whether SafeDeleteWithin(path, root, false) would
+// succeed. Each entry is opened exclusively for deletion and closed again:
+// a sharing violation predicts ErrLocked, and an access-denied error that
+// the parent directory's delete-child right does not override predicts
+// ErrAccessDenied. Directories are probed entry by entry without following
+// links. Nothing is modified.
+func ProbeDelete(path, root string) Probe {
+	if err := ValidatePath(path); err != nil {
+		return Probe{Err: fmt.Errorf("safety check failed for %s: %w", path, err)}
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Probe{}
+		}
+		return Probe{Err: fmt.Errorf("cannot stat %s: %w", path, wrapOSError(err))}
+	}
+	if root != "" {
+		if err := checkWithin(path, root); err != nil {
+			return Probe{Err: err}
+		}
+	}
+
+	var p Probe
+	if !info.IsDir() || IsReparsePoint(info) {
+		probeEntry(path, info, &p)
+		return p
+	}
+	_ = filepath.WalkDir(path, func(sub string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if p.Err == nil {
+				p.Err = fmt.Errorf("cannot read %s: %w", sub, wrapOSError(walkErr))
+			}
+			return nil
+		}
+		subInfo, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		probeEntry(sub, subInfo, &p)
+		if d.IsDir() && sub != path && IsReparsePoint(subInfo) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return p
+}
+
+// probeEntry checks one file, directory or link and folds the result
+// into p. Only regular files add to the size.
+func probeEntry(path string, info os.FileInfo, p *Probe) {
+	if attrs, ok := info.Sys().(*syscall.Win32FileAttributeData); ok &&
+		attrs.FileAttributes&windows.FILE_ATTRIBUTE_READONLY != 0 {
+		p.ReadOnly = true
+	}
+	if err := openForDelete(path); err != nil {
+		if p.Err == nil {
+			p.Err = fmt.Errorf("cannot delete %s: %w", path, wrapOSError(err))
+		}
+		return
+	}
+	if info.Mode().IsRegular() {
+		p.Size += info.Size()
+	}
+}
+
+// openForDelete opens path with delete access and no sharing, the way a
+// deletion would need it, and closes it again.
+func openForDelete(path string) error {
+	err := openHandle(path, windows.DELETE, 0,
+		windows.FILE_FLAG_OPEN_REPARSE_POINT|windows.FILE_FLAG_BACKUP_SEMANTICS)
+	if err == nil || !isAccessDenied(err) {
+		return err
+	}
+	// Delete access can also come from the parent directory, which other
+	// programs routinely have open, so share it fully.
+	share := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+	if openHandle(filepath.Dir(path), fileDeleteChild, share, windows.FILE_FLAG_BACKUP_SEMANTICS) != nil {
+		return err
+	}
+	return nil
+}
+
+// openHandle opens path with the given access and sharing, then closes the
+// handle.
+func openHandle(path string, access, share, flags uint32) error {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	h, err := windows.CreateFile(p, access, share, nil, windows.OPEN_EXISTING, flags, 0)
+	if err != nil {
+		return &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return windows.CloseHandle(h)
+}
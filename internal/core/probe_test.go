@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+// ---------------------------------------------------------------------------
+// ProbeDelete tests
+// ---------------------------------------------------------------------------
+
+func TestProbeDelete_PredictsLockedFile(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	free := filepath.Join(dir, "cache", "free.bin")
+	held := filepath.Join(dir, "cache", "held.bin")
+	writeFile(t, free)
+	writeFile(t, held)
+
+	// Hold held.bin open without delete sharing, like a running program.
+	p, err := windows.UTF16PtrFromString(held)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := windows.CreateFile(p, windows.GENERIC_READ, windows.FILE_SHARE_READ, nil,
+		windows.OPEN_EXISTING, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer windows.CloseHandle(h)
+
+	if got := ProbeDelete(free, ""); got.Err != nil || got.Size != int64(len("keep me")) {
+		t.Errorf("ProbeDelete(free) = %+v, want success", got)
+	}
+	got := ProbeDelete(filepath.Join(dir, "cache"), "")
+	if !errors.Is(got.Err, ErrLocked) {
+		t.Errorf("ProbeDelete(dir) error = %v, want ErrLocked", got.Err)
+	}
+	if _, err := os.Stat(held); err != nil {
+		t.Errorf("probe removed the file: %v", err)
+	}
+}
+
+func TestProbeDelete_FlagsReadOnly(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	path := filepath.Join(dir, "readonly.txt")
+	writeFile(t, path)
+	if err := os.Chmod(path, 0o444); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(path, 0o644) })
+
+	got := ProbeDelete(path, "")
+	if got.Err != nil || !got.ReadOnly {
+		t.Errorf("ProbeDelete() = %+v, want read-only success", got)
+	}
+}
+
+func TestProbeDelete_RefusesProtectedPath(t *testing.T) {
+	got := ProbeDelete(`C:\Windows\System32`, "")
+	if !errors.Is(got.Err, ErrProtected) {
+		t.Errorf("ProbeDelete() error = %v, want ErrProtected", got.Err)
+	}
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// ScopeError is returned when a path, once its symlinks and junctions are
+// resolved, does not lie within the root it was scanned from.
+type ScopeError struct {
+	// Path is the path that was about to be deleted.
+	Path string
+
+	// Root is the target root the path was expected to stay within.
+	Root string
+
+	// Resolved is the real location Path points at.
+	Resolved string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("%s resolves to %s, outside its target root %s", e.Path, e.Resolved, e.Root)
+}
+
+// Unwrap lets errors.Is(err, ErrOutsideScope) match.
+func (e *ScopeError) Unwrap() error {
+	return ErrOutsideScope
+}
+
+// IsReparsePoint reports whether info describes a symlink, junction or
+// other reparse point. Such entries are removed themselves and never
+// traversed.
+func IsReparsePoint(info os.FileInfo) bool {
+	if info.Mode()&(os.ModeSymlink|os.ModeIrregular) != 0 {
+		return true
+	}
+	if attrs, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return attrs.FileAttributes&windows.FILE_ATTRIBUTE_REPARSE_POINT != 0
+	}
+	return false
+}
+
+// checkWithin returns a *ScopeError unless path's real location lies within
+// root's real location. The final element of path is not resolved, so a
+// link that is itself the item stays in scope and is removed as a link;
+// only links among its parents can move it elsewhere.
+func checkWithin(path, root string) error {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fmt.Errorf("cannot resolve target root %s: %w", root, err)
+	}
+	resolved := realRoot
+	if !strings.EqualFold(filepath.Clean(path), filepath.Clean(root)) {
+		parent, err := filepath.EvalSymlinks(filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("cannot resolve %s: %w", filepath.Dir(path), err)
+		}
+		resolved = filepath.Join(parent, filepath.Base(path))
+	}
+	if !withinDir(path, root) || !withinDir(resolved, realRoot) {
+		return &ScopeError{Path: path, Root: root, Resolved: resolved}
+	}
+	return nil
+}
+
+// withinDir reports whether path is dir or lies beneath it, ignoring case.
+func withinDir(path, dir string) bool {
+	path, dir = filepath.Clean(path), filepath.Clean(dir)
+	if !strings.HasPrefix(strings.ToLower(path), strings.ToLower(dir)) {
+		return false
+	}
+	return len(path) == len(dir) || path[len(dir)] == filepath.Separator ||
+		strings.HasSuffix(dir, string(filepath.Separator))
+}
+
+// Remover deletes a single file, empty directory or link. The delete
+// engine walks directories itself and hands every entry to its Remover, so
+// a Remover never sees a link's target.
+type Remover interface {
+	Remove(path string, info os.FileInfo) error
+}
+
+// unlink is the default Remover: a plain delete.
+type unlink struct{}
+
+func (unlink) Remove(path string, info os.FileInfo) error {
+	return removeEntry(path, info)
+}
+
+// Pacer rate-limits deletion. Wait is called before each entry is removed
+// and may block; bytes is the entry's size, 0 for directories and links.
+type Pacer interface {
+	Wait(files int, bytes int64)
+}
+
+// Paced returns a Remover that waits on p before handing each entry to r.
+// A nil r deletes plainly; a nil p returns r unchanged.
+func Paced(r Remover, p Pacer) Remover {
+	if p == nil {
+		return r
+	}
+	if r == nil {
+		r = unlink{}
+	}
+	return paced{r: r, p: p}
+}
+
+// paced is the Remover returned by Paced.
+type paced struct {
+	r Remover
+	p Pacer
+}
+
+func (pr paced) Remove(path string, info os.FileInfo) error {
+	var size int64
+	if info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	pr.p.Wait(1, size)
+	return pr.r.Remove(path, info)
+}
+
+// removeTree deletes path and, for a real directory, everything beneath it,
+// passing each entry to r. Symlinks and junctions are removed as links;
+// their targets are never visited. It keeps going past entries it cannot
+// remove and returns the first error.
+func removeTree(path string, r Remover) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() || IsReparsePoint(info) {
+		return r.Remove(path, info)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, e := range entries {
+		if err := removeTree(filepath.Join(path, e.Name()), r); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := r.Remove(path, info); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// removeEntry deletes a single file, empty directory or link, clearing the
+// read-only attribute once if that is what blocks it.
+func removeEntry(path string, info os.FileInfo) error {
+	err := os.Remove(path)
+	if err != nil && isAccessDenied(err) && info.Mode()&0o200 == 0 {
+		_ = os.Chmod(path, 0o666)
+		err = os.Remove(path)
+	}
+	return err
+}
+
+// treeSize sums the regular files beneath path without following links.
+func treeSize(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			return nil
+		}
+		if p != path && IsReparsePoint(info) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
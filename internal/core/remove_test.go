@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// makeJunction creates a directory junction at link pointing to target,
+// skipping the test when the shell cannot create one.
+func makeJunction(t *testing.T, link, target string) {
+	t.Helper()
+	if out, err := exec.Command("cmd", "/c", "mklink", "/J", link, target).CombinedOutput(); err != nil {
+		t.Skipf("cannot create junction: %v: %s", err, out)
+	}
+}
+
+func writeFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SafeDeleteWithin tests
+// ---------------------------------------------------------------------------
+
+func TestSafeDeleteWithin_RefusesJunctionEscape(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	root := filepath.Join(dir, "temp")
+	docs := filepath.Join(dir, "documents")
+	doc := filepath.Join(docs, "thesis.docx")
+	writeFile(t, doc)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	makeJunction(t, filepath.Join(root, "planted"), docs)
+
+	_, err := SafeDeleteWithin(filepath.Join(root, "planted", "thesis.docx"), root, false)
+	var scopeErr *ScopeError
+	if !errors.As(err, &scopeErr) || !errors.Is(err, ErrOutsideScope) {
+		t.Fatalf("SafeDeleteWithin() error = %v, want *ScopeError", err)
+	}
+	if _, statErr := os.Stat(doc); statErr != nil {
+		t.Errorf("document behind the junction was deleted: %v", statErr)
+	}
+}
+
+func TestSafeDeleteWithin_RefusesPathOutsideRoot(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	root := filepath.Join(dir, "temp")
+	other := filepath.Join(dir, "other.txt")
+	writeFile(t, other)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SafeDeleteWithin(other, root, false); !errors.Is(err, ErrOutsideScope) {
+		t.Fatalf("SafeDeleteWithin() error = %v, want ErrOutsideScope", err)
+	}
+}
+
+func TestSafeDelete_RemovesJunctionNotTarget(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	cache := filepath.Join(dir, "cache")
+	docs := filepath.Join(dir, "documents")
+	doc := filepath.Join(docs, "thesis.docx")
+	writeFile(t, doc)
+	writeFile(t, filepath.Join(cache, "blob.bin"))
+	makeJunction(t, filepath.Join(cache, "planted"), docs)
+
+	if _, err := SafeDeleteWithin(cache, dir, false); err != nil {
+		t.Fatalf("SafeDeleteWithin() error = %v", err)
+	}
+	if _, err := os.Lstat(cache); !os.IsNotExist(err) {
+		t.Errorf("cache directory still exists: %v", err)
+	}
+	if _, err := os.Stat(doc); err != nil {
+		t.Errorf("junction target was emptied: %v", err)
+	}
+}
@@ -30,41 +30,42 @@ func IsSafePath(path string) bool {
 }
 
 // ValidatePath performs comprehensive validation on a path before any
-// file operation. It returns nil if the path is safe to operate on.
+// file operation. It returns nil if the path is safe to operate on, and
+// otherwise an error wrapping ErrInvalidPath or ErrProtected.
 func ValidatePath(path string) error {
 	// 1. Not empty.
 	if strings.TrimSpace(path) == "" {
-		return fmt.Errorf("path is empty")
+		return fmt.Errorf("%w: path is empty", ErrInvalidPath)
 	}
 
 	// 2. Must be absolute.
 	if !filepath.IsAbs(path) {
-		return fmt.Errorf("path must be absolute, got: %s", path)
+		return fmt.Errorf("%w: path must be absolute, got: %s", ErrInvalidPath, path)
 	}
 
 	// 2.5. Reject drive roots (e.g., C:\ or C:).
 	cleaned := filepath.Clean(path)
 	if len(cleaned) >= 2 && len(cleaned) <= 3 && cleaned[1] == ':' && unicode.IsLetter(rune(cleaned[0])) {
-		return fmt.Errorf("path is a drive root and cannot be operated on: %s", path)
+		return fmt.Errorf("%w: path is a drive root and cannot be operated on: %s", ErrProtected, path)
 	}
 
 	// 3. No path traversal components.
 	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
 		if part == ".." {
-			return fmt.Errorf("path contains traversal component (..): %s", path)
+			return fmt.Errorf("%w: path contains traversal component (..): %s", ErrInvalidPath, path)
 		}
 	}
 
 	// 4. No control characters.
 	for _, r := range path {
 		if unicode.IsControl(r) && r != '\t' {
-			return fmt.Errorf("path contains control character (U+%04X): %s", r, path)
+			return fmt.Errorf("%w: path contains control character (U+%04X): %s", ErrInvalidPath, r, path)
 		}
 	}
 
 	// 5. Not a NEVER_DELETE path.
 	if !IsSafePath(path) {
-		return fmt.Errorf("path is protected and must NEVER be deleted: %s", path)
+		return fmt.Errorf("%w and must NEVER be deleted: %s", ErrProtected, path)
 	}
 
 	// 6. If it exists and is a symlink/junction, resolve and re-check.
@@ -75,7 +76,7 @@ func ValidatePath(path string) error {
 			return fmt.Errorf("cannot resolve symlink %s: %w", path, resolveErr)
 		}
 		if !IsSafePath(resolved) {
-			return fmt.Errorf("symlink %s resolves to protected path: %s", path, resolved)
+			return fmt.Errorf("%w: symlink %s resolves to protected path: %s", ErrProtected, path, resolved)
 		}
 	}
 
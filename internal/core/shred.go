@@ -0,0 +1,192 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// shredBufSize is the chunk size used to overwrite file contents.
+const shredBufSize = 64 * 1024
+
+// Shredder is a Remover that overwrites each regular file's contents with
+// random data, truncates it, renames it to a random name and only then
+// unlinks it, so neither the data nor the original name is left in the
+// file's old clusters or directory entry. Directories, links and files
+// with more than one hard link are removed plainly: overwriting those
+// would destroy data still reachable through another name.
+//
+// Overwriting only reaches the clusters the file occupies now. On SSDs,
+// wear levelling and TRIM mean older copies may survive elsewhere on the
+// device; see VolumeHasTrim.
+type Shredder struct {
+	// Passes is the number of overwrite passes. Zero or less means one.
+	Passes int
+}
+
+// Remove shreds path if it is a regular file and removes it.
+func (s Shredder) Remove(path string, info os.FileInfo) error {
+	if !info.Mode().IsRegular() || IsReparsePoint(info) {
+		return removeEntry(path, info)
+	}
+	if links, err := linkCount(path); err != nil || links > 1 {
+		return removeEntry(path, info)
+	}
+	if info.Mode()&0o200 == 0 {
+		if err := os.Chmod(path, 0o666); err != nil {
+			return err
+		}
+	}
+	if err := overwriteFile(path, info.Size(), s.passes()); err != nil {
+		return err
+	}
+	renamed, err := renameRandom(path)
+	if err != nil {
+		// The contents are gone already; unlink under the old name.
+		renamed = path
+	}
+	return removeEntry(renamed, info)
+}
+
+// passes returns the effective number of overwrite passes.
+func (s Shredder) passes() int {
+	if s.Passes < 1 {
+		return 1
+	}
+	return s.Passes
+}
+
+// linkCount returns the number of hard links to the file at path.
+func linkCount(path string) (uint32, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	h, err := windows.CreateFile(p, 0,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(h)
+
+	var fi windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(h, &fi); err != nil {
+		return 0, err
+	}
+	return fi.NumberOfLinks, nil
+}
+
+// overwriteFile writes size bytes of random data over path passes times,
+// flushing each pass to disk, then truncates it to zero length.
+func overwriteFile(path string, size int64, passes int) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := make([]byte, shredBufSize)
+	for pass := 0; pass < passes; pass++ {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		for remaining := size; remaining > 0; {
+			chunk := buf
+			if remaining < int64(len(chunk)) {
+				chunk = chunk[:remaining]
+			}
+			if _, err := rand.Read(chunk); err != nil {
+				return err
+			}
+			n, err := f.Write(chunk)
+			if err != nil {
+				return err
+			}
+			remaining -= int64(n)
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// renameRandom renames path to a random name in the same directory and
+// returns the new path.
+func renameRandom(path string) (string, error) {
+	name := make([]byte, 12)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+	renamed := filepath.Join(filepath.Dir(path), hex.EncodeToString(name))
+	if err := os.Rename(path, renamed); err != nil {
+		return "", err
+	}
+	return renamed, nil
+}
+
+// ─── TRIM Detection ──────────────────────────────────────────────────────────
+
+const (
+	// ioctlStorageQueryProperty is IOCTL_STORAGE_QUERY_PROPERTY.
+	ioctlStorageQueryProperty = 0x002D1400
+
+	// storageDeviceTrimProperty is StorageDeviceTrimProperty.
+	storageDeviceTrimProperty = 8
+)
+
+// storagePropertyQuery mirrors the Windows STORAGE_PROPERTY_QUERY struct.
+type storagePropertyQuery struct {
+	PropertyID           uint32
+	QueryType            uint32
+	AdditionalParameters [1]byte
+}
+
+// deviceTrimDescriptor mirrors the Windows DEVICE_TRIM_DESCRIPTOR struct.
+type deviceTrimDescriptor struct {
+	Version     uint32
+	Size        uint32
+	TrimEnabled byte
+}
+
+// VolumeHasTrim reports whether the volume holding path sits on a device
+// with TRIM enabled, which in practice means an SSD. On such devices an
+// overwrite is not guaranteed to reach every copy of a file's data.
+// Paths without a drive letter report false.
+func VolumeHasTrim(path string) (bool, error) {
+	vol := filepath.VolumeName(path)
+	if len(vol) != 2 || vol[1] != ':' {
+		return false, nil
+	}
+	dev, err := windows.UTF16PtrFromString(`\\.\` + vol)
+	if err != nil {
+		return false, err
+	}
+	h, err := windows.CreateFile(dev, 0,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return false, fmt.Errorf("cannot open volume %s: %w", vol, err)
+	}
+	defer windows.CloseHandle(h)
+
+	query := storagePropertyQuery{PropertyID: storageDeviceTrimProperty}
+	var desc deviceTrimDescriptor
+	var returned uint32
+	if err := windows.DeviceIoControl(h, ioctlStorageQueryProperty,
+		(*byte)(unsafe.Pointer(&query)), uint32(unsafe.Sizeof(query)),
+		(*byte)(unsafe.Pointer(&desc)), uint32(unsafe.Sizeof(desc)),
+		&returned, nil); err != nil {
+		return false, fmt.Errorf("cannot query volume %s: %w", vol, err)
+	}
+	return desc.TrimEnabled != 0, nil
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Shredder tests
+// ---------------------------------------------------------------------------
+
+func TestShredder_RemovesTree(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	cache := filepath.Join(dir, "Cookies")
+	writeFile(t, filepath.Join(cache, "session.db"))
+	writeFile(t, filepath.Join(cache, "nested", "token.json"))
+	readOnly := filepath.Join(cache, "locked.txt")
+	writeFile(t, readOnly)
+	if err := os.Chmod(readOnly, 0o444); err != nil {
+		t.Fatal(err)
+	}
+
+	freed, err := SafeDeleteWith(cache, dir, false, Shredder{Passes: 2})
+	if err != nil {
+		t.Fatalf("SafeDeleteWith() error = %v", err)
+	}
+	if freed != 3*int64(len("keep me")) {
+		t.Errorf("freed = %d, want %d", freed, 3*len("keep me"))
+	}
+	if _, err := os.Stat(cache); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("shredded directory still exists: %v", err)
+	}
+}
+
+func TestOverwriteFile_Truncates(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	path := filepath.Join(dir, "secret.txt")
+	writeFile(t, path)
+
+	if err := overwriteFile(path, int64(len("keep me")), 3); err != nil {
+		t.Fatalf("overwriteFile() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("size after overwrite = %d, want 0", info.Size())
+	}
+}
+
+func TestShredder_RefusesProtectedPath(t *testing.T) {
+	_, err := SafeDeleteWith(`C:\Windows\System32\drivers`, "", false, Shredder{})
+	if !errors.Is(err, ErrProtected) {
+		t.Errorf("SafeDeleteWith() error = %v, want ErrProtected", err)
+	}
+}
+
+func TestShredder_UnlinksHardLinkedFile(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	cache := filepath.Join(dir, "Cache")
+	shared := filepath.Join(cache, "data.bin")
+	writeFile(t, shared)
+	other := filepath.Join(dir, "elsewhere.bin")
+	if err := os.Link(shared, other); err != nil {
+		t.Skipf("cannot create hard link: %v", err)
+	}
+
+	if _, err := SafeDeleteWith(cache, dir, false, Shredder{}); err != nil {
+		t.Fatalf("SafeDeleteWith() error = %v", err)
+	}
+	data, err := os.ReadFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "keep me" {
+		t.Errorf("hard-linked data = %q, want it left intact", data)
+	}
+}
@@ -0,0 +1,132 @@
+// Package electron finds the Chromium profiles Electron applications
+// (Teams, Slack, Discord, …) keep under the app data folders, so their
+// caches can be cleaned like a browser's.
+package electron
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CacheDirs are the cache folders inside an Electron profile. Nothing else
+// in a profile — local storage, cookies, IndexedDB, settings — is ever
+// reported.
+var CacheDirs = []string{
+	"Cache",
+	"Code Cache",
+	"GPUCache",
+	filepath.Join("Service Worker", "CacheStorage"),
+}
+
+// signatures are files Chromium writes into every profile directory.
+// Newer versions keep Network Persistent State under Network.
+var signatures = []string{
+	"Local State",
+	"Preferences",
+	"Network Persistent State",
+	filepath.Join("Network", "Network Persistent State"),
+}
+
+// minSignatures is how many signature files mark a directory as a
+// profile. One alone (a stray Preferences file) is not enough.
+const minSignatures = 2
+
+// App is an Electron application's profile.
+type App struct {
+	// Name is the profile folder's name, usually the application's (e.g.
+	// "Slack", or "Teams" for %APPDATA%\Microsoft\Teams).
+	Name string
+
+	// Dir is the profile directory.
+	Dir string
+
+	// Caches are the cache folders present in the profile.
+	Caches []string
+}
+
+// executables maps the profile folders of well-known applications,
+// lower-cased, to the executables they run as, where those differ from
+// the folder's name.
+var executables = map[string][]string{
+	"code":              {"Code.exe"},
+	"discordcanary":     {"DiscordCanary.exe"},
+	"discordptb":        {"DiscordPTB.exe"},
+	"docker desktop":    {"Docker Desktop.exe"},
+	"figma":             {"Figma.exe", "figma_agent.exe"},
+	"github desktop":    {"GitHubDesktop.exe"},
+	"skype for desktop": {"Skype.exe"},
+	"balena-etcher":     {"balenaEtcher.exe"},
+}
+
+// Processes returns the executables the application runs as (e.g.
+// "slack.exe"): the known ones for well-known applications, and otherwise
+// the folder's name with ".exe" appended.
+func (a App) Processes() []string {
+	if exes, ok := executables[strings.ToLower(a.Name)]; ok {
+		return exes
+	}
+	return []string{a.Name + ".exe"}
+}
+
+// Find looks for Electron profiles one and two levels below each base
+// directory. A directory counts when it holds at least two signature
+// files and one cache folder; its subdirectories are not searched
+// further. Symlinks and junctions are not followed.
+func Find(bases ...string) []App {
+	var apps []App
+	for _, base := range bases {
+		if base == "" {
+			continue
+		}
+		for _, dir := range subdirs(base) {
+			if app, ok := profile(dir); ok {
+				apps = append(apps, app)
+				continue
+			}
+			for _, sub := range subdirs(dir) {
+				if app, ok := profile(sub); ok {
+					apps = append(apps, app)
+				}
+			}
+		}
+	}
+	return apps
+}
+
+// subdirs returns dir's real subdirectories, skipping links.
+func subdirs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && e.Type()&(os.ModeSymlink|os.ModeIrregular) == 0 {
+			dirs = append(dirs, filepath.Join(dir, e.Name()))
+		}
+	}
+	return dirs
+}
+
+// profile reports whether dir is an Electron profile and, if so, returns
+// it with its cache folders.
+func profile(dir string) (App, bool) {
+	found := 0
+	for _, name := range signatures {
+		if info, err := os.Lstat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+			found++
+		}
+	}
+	if found < minSignatures {
+		return App{}, false
+	}
+	app := App{Name: filepath.Base(dir), Dir: dir}
+	for _, name := range CacheDirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Lstat(path); err == nil && info.IsDir() {
+			app.Caches = append(app.Caches, path)
+		}
+	}
+	return app, len(app.Caches) > 0
+}
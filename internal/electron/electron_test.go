@@ -0,0 +1,90 @@
+package electron
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// mkProfile creates dir with the given files and directories.
+func mkProfile(t *testing.T, dir string, files, dirs []string) {
+	t.Helper()
+	for _, d := range dirs {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	roaming, local := t.TempDir(), t.TempDir()
+
+	// One level down, newer layout.
+	mkProfile(t, filepath.Join(roaming, "Slack"),
+		[]string{"Local State", "Preferences", filepath.Join("Network", "Network Persistent State")},
+		[]string{"Cache", "Code Cache", filepath.Join("Service Worker", "CacheStorage"), "IndexedDB"})
+	// Two levels down, older layout.
+	mkProfile(t, filepath.Join(roaming, "Microsoft", "Teams"),
+		[]string{"Preferences", "Network Persistent State"},
+		[]string{"GPUCache"})
+	// A stray Preferences file is not a profile.
+	mkProfile(t, filepath.Join(local, "SomeTool"), []string{"Preferences"}, []string{"Cache"})
+	// Three levels down is too deep.
+	mkProfile(t, filepath.Join(local, "Vendor", "App", "Profile"),
+		[]string{"Local State", "Preferences"}, []string{"Cache"})
+	// A profile without caches has nothing to report.
+	mkProfile(t, filepath.Join(local, "Empty"), []string{"Local State", "Preferences"}, nil)
+
+	apps := Find(roaming, local, "")
+	if len(apps) != 2 {
+		t.Fatalf("Find() = %+v, want Slack and Teams", apps)
+	}
+
+	byName := make(map[string]App)
+	for _, app := range apps {
+		byName[app.Name] = app
+	}
+
+	slack := byName["Slack"]
+	if got := slack.Processes(); !slices.Equal(got, []string{"Slack.exe"}) {
+		t.Errorf("Slack.Processes() = %q, want Slack.exe", got)
+	}
+	wantCaches := []string{
+		filepath.Join(roaming, "Slack", "Cache"),
+		filepath.Join(roaming, "Slack", "Code Cache"),
+		filepath.Join(roaming, "Slack", "Service Worker", "CacheStorage"),
+	}
+	if !slices.Equal(slack.Caches, wantCaches) {
+		t.Errorf("Slack caches = %q, want %q", slack.Caches, wantCaches)
+	}
+
+	if teams := byName["Teams"]; teams.Dir != filepath.Join(roaming, "Microsoft", "Teams") {
+		t.Errorf("Teams = %+v, want it under Microsoft", teams)
+	}
+}
+
+func TestApp_Processes(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Slack", []string{"Slack.exe"}},
+		{"GitHub Desktop", []string{"GitHubDesktop.exe"}},
+		{"discordptb", []string{"DiscordPTB.exe"}},
+	}
+	for _, tt := range tests {
+		if got := (App{Name: tt.name}).Processes(); !slices.Equal(got, tt.want) {
+			t.Errorf("App{%q}.Processes() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
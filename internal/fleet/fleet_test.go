@@ -0,0 +1,156 @@
+package fleet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+var testKey = []byte("fleet-test-key")
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.json")
+	writeFile(t, path, `{
+		"name": "workstations",
+		"allowed_profiles": ["safe"],
+		"forced_whitelist": ["%LOCALAPPDATA%\\Corp\\Agent\\*"],
+		"max_risk": "low",
+		"require_dry_run": true
+	}`)
+
+	p, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy() error: %v", err)
+	}
+	if !p.AllowsProfile("SAFE") || p.AllowsProfile("standard") {
+		t.Errorf("AllowsProfile wrong for %v", p.AllowedProfiles)
+	}
+	if !p.AllowsRisk("low") || p.AllowsRisk("medium") || p.AllowsRisk("bogus") {
+		t.Errorf("AllowsRisk wrong for max_risk %q", p.MaxRisk)
+	}
+	if !p.RequireDryRun || len(p.ForcedWhitelist) != 1 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+
+	writeFile(t, path, `{"max_risk": "extreme"}`)
+	if _, err := LoadPolicy(path); err == nil {
+		t.Error("expected error for invalid max_risk")
+	}
+}
+
+func TestLoadPolicyCached_FallsBackWhenShareOffline(t *testing.T) {
+	share := t.TempDir()
+	cache := filepath.Join(t.TempDir(), "fleet-policy.json")
+	path := filepath.Join(share, "policy.json")
+	writeFile(t, path, `{"name": "v1", "max_risk": "medium"}`)
+
+	if p, fromCache, err := LoadPolicyCached(path, cache); err != nil || fromCache || p.Name != "v1" {
+		t.Fatalf("first load = %+v, %v, %v", p, fromCache, err)
+	}
+
+	os.Remove(path)
+	p, fromCache, err := LoadPolicyCached(path, cache)
+	if err != nil || !fromCache || p.Name != "v1" {
+		t.Errorf("offline load = %+v, %v, %v; want cached v1", p, fromCache, err)
+	}
+}
+
+func TestReportSignAndVerify(t *testing.T) {
+	r := NewReport("workstations", "safe", report.Session{
+		Command:  "clean",
+		Hostname: "ws-01",
+		Finished: time.Now(),
+		Freed:    1 << 30,
+	})
+	if err := r.Verify(testKey); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Verify(unsigned) = %v, want ErrUnsigned", err)
+	}
+	if err := r.Sign(testKey); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := WriteReport(t.TempDir(), r)
+	if err != nil {
+		t.Fatalf("WriteReport() error: %v", err)
+	}
+	got, err := ReadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Verify(testKey); err != nil {
+		t.Errorf("Verify() after round trip: %v", err)
+	}
+
+	got.Freed *= 10
+	if err := got.Verify(testKey); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Verify(tampered) = %v, want ErrBadSignature", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	sessions := []report.Session{
+		{Command: "clean", Hostname: "ws-01", Finished: now, Freed: 300, Items: 3,
+			Categories: map[string]report.CategoryTotal{"user": {Freed: 100, Items: 1}, "dev": {Freed: 200, Items: 2}}},
+		{Command: "clean", Hostname: "WS-01", Finished: now.Add(time.Minute), Freed: 50, Items: 1,
+			Categories: map[string]report.CategoryTotal{"user": {Freed: 50, Items: 1}}},
+		{Command: "clean", Hostname: "ws-02", Finished: now, Freed: 1000, Items: 4,
+			Categories: map[string]report.CategoryTotal{"user": {Freed: 1000, Items: 4}}},
+		{Command: "clean", Hostname: "ws-03", Finished: now, DryRun: true, Freed: 5000, Items: 9},
+	}
+	for _, s := range sessions {
+		r := NewReport("workstations", "safe", s)
+		if err := r.Sign(testKey); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := WriteReport(dir, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// An unsigned report and a non-report file.
+	if _, err := WriteReport(dir, NewReport("", "", report.Session{Command: "clean", Hostname: "rogue", Freed: 1 << 40})); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	sum, err := Summarize(dir, testKey)
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+	if sum.Reports != 4 || len(sum.Rejected) != 1 || sum.Freed != 1350 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	if len(sum.Machines) != 3 || sum.Machines[0].Hostname != "ws-02" {
+		t.Errorf("machines not sorted by freed: %+v", sum.Machines)
+	}
+	for _, m := range sum.Machines {
+		if m.Hostname == "ws-01" && (m.Runs != 2 || m.Freed != 350) {
+			t.Errorf("ws-01 = %+v, want 2 runs / 350 bytes", m)
+		}
+		if m.Hostname == "ws-03" && (m.DryRuns != 1 || m.Freed != 0) {
+			t.Errorf("ws-03 = %+v, want dry run only", m)
+		}
+	}
+	if sum.Categories[0].Category != "user" || sum.Categories[0].Freed != 1150 || sum.Categories[0].Machines != 2 {
+		t.Errorf("unexpected categories: %+v", sum.Categories)
+	}
+
+	// Without a key every readable report counts.
+	sum, err = Summarize(dir, nil)
+	if err != nil || sum.Reports != 5 {
+		t.Errorf("Summarize(nil key) = %d reports, %v; want 5", sum.Reports, err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package fleet applies a central cleanup policy across many machines and
+// collects per-run reports in a shared directory, without a server. The
+// policy and reports directory are plain paths, so a UNC share
+// (\\server\share) and a local folder work the same way.
+package fleet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+)
+
+// ─── Policy ──────────────────────────────────────────────────────────────────
+
+// Policy is the central policy file shared by a fleet of machines.
+type Policy struct {
+	// Name identifies the policy in reports (e.g. "workstations-2026").
+	Name string `json:"name,omitempty"`
+
+	// AllowedProfiles restricts pw clean to these profiles. Empty allows
+	// every profile and category flag.
+	AllowedProfiles []string `json:"allowed_profiles,omitempty"`
+
+	// ForcedWhitelist lists patterns that are always protected, on top of
+	// each user's own whitelist.
+	ForcedWhitelist []string `json:"forced_whitelist,omitempty"`
+
+	// MaxRisk is the highest target risk level that may be cleaned
+	// ("low", "medium" or "high"). Empty means no limit.
+	MaxRisk string `json:"max_risk,omitempty"`
+
+	// RequireDryRun forces every run into dry-run mode.
+	RequireDryRun bool `json:"require_dry_run,omitempty"`
+
+	// ReportsDir is where each run drops its JSON report.
+	ReportsDir string `json:"reports_dir,omitempty"`
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read fleet policy %s: %w", path, err)
+	}
+	return parsePolicy(data, path)
+}
+
+// LoadPolicyCached reads the policy from path and refreshes a local copy at
+// cachePath. When path is unreachable (e.g. the share is offline), the
+// cached copy is used instead and fromCache is true.
+func LoadPolicyCached(path, cachePath string) (p *Policy, fromCache bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		p, err := parsePolicy(data, path)
+		if err != nil {
+			return nil, false, err
+		}
+		if cachePath != "" {
+			if mkErr := os.MkdirAll(filepath.Dir(cachePath), 0o755); mkErr == nil {
+				_ = os.WriteFile(cachePath, data, 0o644)
+			}
+		}
+		return p, false, nil
+	}
+
+	readErr := fmt.Errorf("cannot read fleet policy %s: %w", path, err)
+	if cachePath == "" {
+		return nil, false, readErr
+	}
+	cached, cacheErr := os.ReadFile(cachePath)
+	if cacheErr != nil {
+		return nil, false, readErr
+	}
+	p, err = parsePolicy(cached, cachePath)
+	if err != nil {
+		return nil, false, err
+	}
+	return p, true, nil
+}
+
+// parsePolicy decodes and validates policy JSON; source names the file in
+// errors.
+func parsePolicy(data []byte, source string) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid fleet policy %s: %w", source, err)
+	}
+	if p.MaxRisk != "" && config.RiskRank(p.MaxRisk) < 0 {
+		return nil, fmt.Errorf("invalid fleet policy %s: max_risk %q must be one of %s",
+			source, p.MaxRisk, strings.Join(config.RiskLevels, ", "))
+	}
+	return &p, nil
+}
+
+// AllowsProfile reports whether the named profile may be used.
+func (p *Policy) AllowsProfile(name string) bool {
+	if len(p.AllowedProfiles) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedProfiles {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRisk reports whether a target with the given risk level may be
+// cleaned. Unknown levels are treated as high.
+func (p *Policy) AllowsRisk(level string) bool {
+	return config.RiskWithin(level, p.MaxRisk)
+}
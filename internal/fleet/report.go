@@ -0,0 +1,169 @@
+package fleet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+// reportVersion is the schema version written into every report.
+const reportVersion = 1
+
+// ErrUnsigned is returned by Verify for a report without a signature.
+var ErrUnsigned = errors.New("report is not signed")
+
+// ErrBadSignature is returned by Verify when the signature does not match.
+var ErrBadSignature = errors.New("report signature does not match")
+
+// Report is the per-run document dropped into the shared reports directory.
+type Report struct {
+	Version int `json:"version"`
+
+	// Policy is the name of the policy the run was made under.
+	Policy string `json:"policy,omitempty"`
+
+	// Profile is the clean profile used, if any.
+	Profile string `json:"profile,omitempty"`
+
+	report.Session
+
+	// Signature is a hex HMAC-SHA256 of the report with this field empty,
+	// keyed with the fleet key. Empty when no key is configured.
+	Signature string `json:"signature,omitempty"`
+}
+
+// NewReport wraps a session result in a fleet report.
+func NewReport(policy, profile string, s report.Session) Report {
+	return Report{Version: reportVersion, Policy: policy, Profile: profile, Session: s}
+}
+
+// digest computes the HMAC of r with its signature cleared.
+func (r Report) digest(key []byte) ([]byte, error) {
+	r.Signature = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode report: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// Sign sets the report signature using key.
+func (r *Report) Sign(key []byte) error {
+	sum, err := r.digest(key)
+	if err != nil {
+		return err
+	}
+	r.Signature = hex.EncodeToString(sum)
+	return nil
+}
+
+// Verify checks the report signature against key.
+func (r Report) Verify(key []byte) error {
+	if r.Signature == "" {
+		return ErrUnsigned
+	}
+	got, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return ErrBadSignature
+	}
+	want, err := r.digest(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(got, want) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// WriteReport writes r into dir as <host>-<time>-<command>.json and returns
+// the file path. The file is written under a temporary name and renamed, so
+// readers of the share never see a partial report.
+func WriteReport(dir string, r Report) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("cannot create reports directory %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("cannot encode report: %w", err)
+	}
+
+	host := r.Hostname
+	if host == "" {
+		host = "unknown"
+	}
+	name := fmt.Sprintf("%s-%s-%s.json",
+		sanitizeName(host), r.Finished.UTC().Format("20060102T150405.000Z"), sanitizeName(r.Command))
+	path := filepath.Join(dir, name)
+
+	tmp, err := os.CreateTemp(dir, ".report-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("cannot write report: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("cannot write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("cannot write report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("cannot write report %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// ReadReport reads a single report file.
+func ReadReport(path string) (Report, error) {
+	var r Report
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return r, fmt.Errorf("cannot read report %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("invalid report %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// sanitizeName keeps letters, digits, '-' and '_' so host and command names
+// are safe in file names.
+func sanitizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// LoadKey reads a hex or raw signing key from path. Surrounding whitespace
+// is ignored.
+func LoadKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read fleet key %s: %w", path, err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return nil, fmt.Errorf("fleet key %s is empty", path)
+	}
+	if b, err := hex.DecodeString(key); err == nil {
+		return b, nil
+	}
+	return []byte(key), nil
+}
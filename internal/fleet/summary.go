@@ -0,0 +1,130 @@
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MachineSummary totals the reports from one machine.
+type MachineSummary struct {
+	Hostname string    `json:"hostname"`
+	Runs     int       `json:"runs"`
+	DryRuns  int       `json:"dry_runs"`
+	Freed    int64     `json:"freed"`
+	Items    int       `json:"items"`
+	Errors   int       `json:"errors"`
+	LastRun  time.Time `json:"last_run"`
+}
+
+// CategorySummary totals one clean category across machines.
+type CategorySummary struct {
+	Category string `json:"category"`
+	Freed    int64  `json:"freed"`
+	Items    int    `json:"items"`
+	Machines int    `json:"machines"`
+}
+
+// Rejected is a report file that was not counted.
+type Rejected struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// Summary aggregates a reports directory.
+type Summary struct {
+	Reports    int               `json:"reports"`
+	Freed      int64             `json:"freed"`
+	Machines   []MachineSummary  `json:"machines"`
+	Categories []CategorySummary `json:"categories"`
+	Rejected   []Rejected        `json:"rejected,omitempty"`
+}
+
+// Summarize aggregates every *.json report in dir. Dry runs count as runs
+// but add nothing to the freed totals. When key is non-nil, reports that
+// are unsigned or fail verification are rejected instead of counted.
+func Summarize(dir string, key []byte) (*Summary, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read reports directory %s: %w", dir, err)
+	}
+
+	sum := &Summary{Machines: []MachineSummary{}, Categories: []CategorySummary{}}
+	machines := make(map[string]*MachineSummary)
+	categories := make(map[string]*CategorySummary)
+	categoryHosts := make(map[string]map[string]bool)
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".json") {
+			continue
+		}
+		r, err := ReadReport(filepath.Join(dir, e.Name()))
+		if err != nil {
+			sum.Rejected = append(sum.Rejected, Rejected{File: e.Name(), Reason: "unreadable"})
+			continue
+		}
+		if key != nil {
+			if err := r.Verify(key); err != nil {
+				sum.Rejected = append(sum.Rejected, Rejected{File: e.Name(), Reason: err.Error()})
+				continue
+			}
+		}
+
+		sum.Reports++
+		host := strings.ToLower(r.Hostname)
+		m := machines[host]
+		if m == nil {
+			m = &MachineSummary{Hostname: r.Hostname}
+			machines[host] = m
+		}
+		m.Runs++
+		if r.Finished.After(m.LastRun) {
+			m.LastRun = r.Finished
+		}
+		if r.DryRun {
+			m.DryRuns++
+			continue
+		}
+		m.Freed += r.Freed
+		m.Items += r.Items
+		m.Errors += r.Errors
+		sum.Freed += r.Freed
+
+		for name, total := range r.Categories {
+			c := categories[name]
+			if c == nil {
+				c = &CategorySummary{Category: name}
+				categories[name] = c
+				categoryHosts[name] = make(map[string]bool)
+			}
+			c.Freed += total.Freed
+			c.Items += total.Items
+			categoryHosts[name][host] = true
+		}
+	}
+
+	for _, m := range machines {
+		sum.Machines = append(sum.Machines, *m)
+	}
+	sort.Slice(sum.Machines, func(i, j int) bool {
+		if sum.Machines[i].Freed != sum.Machines[j].Freed {
+			return sum.Machines[i].Freed > sum.Machines[j].Freed
+		}
+		return sum.Machines[i].Hostname < sum.Machines[j].Hostname
+	})
+
+	for name, c := range categories {
+		c.Machines = len(categoryHosts[name])
+		sum.Categories = append(sum.Categories, *c)
+	}
+	sort.Slice(sum.Categories, func(i, j int) bool {
+		if sum.Categories[i].Freed != sum.Categories[j].Freed {
+			return sum.Categories[i].Freed > sum.Categories[j].Freed
+		}
+		return sum.Categories[i].Category < sum.Categories[j].Category
+	})
+	return sum, nil
+}
@@ -0,0 +1,127 @@
+// Package goal chooses what to delete to free a given number of bytes.
+// Candidates are ranked by risk, by what it costs to let the data grow
+// back, and by age, and the fewest, safest ones that together reach the
+// goal are proposed.
+package goal
+
+import (
+	"sort"
+	"time"
+)
+
+// Regrowth is what deleting data costs once something needs it again.
+type Regrowth int
+
+// Regrowth costs, cheapest first.
+const (
+	// RegrowNone is data nothing needs back: temp files, logs, old
+	// installers.
+	RegrowNone Regrowth = iota
+
+	// RegrowCheap is data refilled in normal use, such as browser and
+	// app caches.
+	RegrowCheap
+
+	// RegrowCostly is data that has to be downloaded or rebuilt, such as
+	// package caches and build output.
+	RegrowCostly
+)
+
+// StaleAfter is the age past which data counts as stale. Stale data is
+// proposed before fresh data of the same risk and regrowth cost.
+const StaleAfter = 30 * 24 * time.Hour
+
+// Candidate is something that could be deleted toward the goal.
+type Candidate struct {
+	// ID identifies the candidate to the caller, e.g. its path.
+	ID string
+
+	// Size is the bytes deleting it frees.
+	Size int64
+
+	// Risk is the rank of its risk level: 0 low, 1 medium, 2 high.
+	Risk int
+
+	// Regrowth is what deleting it costs later.
+	Regrowth Regrowth
+
+	// ModTime is its last modification time; zero when unknown, which
+	// counts as stale.
+	ModTime time.Time
+}
+
+// stale reports whether c was last modified more than StaleAfter before
+// now.
+func (c Candidate) stale(now time.Time) bool {
+	return c.ModTime.IsZero() || now.Sub(c.ModTime) > StaleAfter
+}
+
+// Rank sorts candidates best first: lower risk, then cheaper regrowth,
+// then stale before fresh, then larger before smaller so the goal is met
+// with as few deletions as possible.
+func Rank(cs []Candidate, now time.Time) {
+	sort.SliceStable(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.Risk != b.Risk {
+			return a.Risk < b.Risk
+		}
+		if a.Regrowth != b.Regrowth {
+			return a.Regrowth < b.Regrowth
+		}
+		if sa, sb := a.stale(now), b.stale(now); sa != sb {
+			return sa
+		}
+		return a.Size > b.Size
+	})
+}
+
+// Proposal is the set of candidates Select picked.
+type Proposal struct {
+	// Chosen are the candidates to delete, best ranked first.
+	Chosen []Candidate
+
+	// Size is the bytes the chosen candidates free.
+	Size int64
+
+	// Reached reports whether Size meets the goal. When it does not,
+	// every candidate was chosen and still fell short.
+	Reached bool
+}
+
+// Select ranks cs and takes candidates in order until their sizes add up
+// to need, so riskier candidates are only reached when safer ones fall
+// short. Chosen candidates the total can then do without are dropped, last
+// ranked first, to keep the proposal as small as it can be. A need of
+// zero or less proposes nothing.
+func Select(cs []Candidate, need int64, now time.Time) Proposal {
+	if need <= 0 {
+		return Proposal{Reached: true}
+	}
+	ranked := append([]Candidate(nil), cs...)
+	Rank(ranked, now)
+
+	var p Proposal
+	for _, c := range ranked {
+		if p.Size >= need {
+			break
+		}
+		if c.Size <= 0 {
+			continue
+		}
+		p.Chosen = append(p.Chosen, c)
+		p.Size += c.Size
+	}
+	if p.Size < need {
+		return p
+	}
+
+	// Trim the overshoot.
+	for i := len(p.Chosen) - 1; i >= 0; i-- {
+		if p.Size-p.Chosen[i].Size >= need {
+			p.Size -= p.Chosen[i].Size
+			p.Chosen = append(p.Chosen[:i], p.Chosen[i+1:]...)
+		}
+	}
+	p.Reached = true
+	return p
+}
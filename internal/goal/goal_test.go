@@ -0,0 +1,69 @@
+package goal
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// ids returns the IDs of cs in order.
+func ids(cs []Candidate) []string {
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.ID
+	}
+	return out
+}
+
+func TestRank(t *testing.T) {
+	fresh := now.Add(-time.Hour)
+	cs := []Candidate{
+		{ID: "medium", Size: 100, Risk: 1},
+		{ID: "costly", Size: 100, Regrowth: RegrowCostly},
+		{ID: "fresh", Size: 100, ModTime: fresh},
+		{ID: "small", Size: 10},
+		{ID: "large", Size: 50},
+	}
+	Rank(cs, now)
+	want := []string{"large", "small", "fresh", "costly", "medium"}
+	for i, id := range ids(cs) {
+		if id != want[i] {
+			t.Fatalf("Rank() = %v, want %v", ids(cs), want)
+		}
+	}
+}
+
+func TestSelect_PrefersLowRiskAndTrims(t *testing.T) {
+	cs := []Candidate{
+		{ID: "temp", Size: 40},
+		{ID: "logs", Size: 30},
+		{ID: "dumps", Size: 5},
+		{ID: "artifacts", Size: 500, Risk: 1, Regrowth: RegrowCostly},
+	}
+	p := Select(cs, 60, now)
+	if !p.Reached || p.Size != 70 {
+		t.Fatalf("Select() = %v, %d bytes, reached %v; want temp and logs (70 bytes)", ids(p.Chosen), p.Size, p.Reached)
+	}
+	if got := ids(p.Chosen); len(got) != 2 || got[0] != "temp" || got[1] != "logs" {
+		t.Errorf("Select() chose %v, want [temp logs]", got)
+	}
+
+	// Only when the low-risk candidates fall short is the riskier one
+	// reached, and the candidates it makes unnecessary are dropped.
+	p = Select(cs, 200, now)
+	if !p.Reached || len(p.Chosen) != 1 || p.Chosen[0].ID != "artifacts" {
+		t.Errorf("Select(200) chose %v, want [artifacts] alone", ids(p.Chosen))
+	}
+}
+
+func TestSelect_ShortOrNothingNeeded(t *testing.T) {
+	cs := []Candidate{{ID: "a", Size: 10}, {ID: "b", Size: 20}, {ID: "empty"}}
+	p := Select(cs, 100, now)
+	if p.Reached || p.Size != 30 || len(p.Chosen) != 2 {
+		t.Errorf("Select(100) = %v, %d bytes, reached %v; want both, short", ids(p.Chosen), p.Size, p.Reached)
+	}
+	if p := Select(cs, 0, now); !p.Reached || len(p.Chosen) != 0 {
+		t.Errorf("Select(0) = %v, want nothing, reached", ids(p.Chosen))
+	}
+}
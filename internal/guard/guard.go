@@ -0,0 +1,215 @@
+// Package guard watches free disk space and reclaims space when a drive
+// drops below a configured threshold.
+package guard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+// usageFunc reports disk usage for a mount point. It is a variable so tests
+// can simulate drives filling up and freeing space.
+var usageFunc = disk.Usage
+
+// ─── Drive Status ────────────────────────────────────────────────────────────
+
+// DriveStatus is a point-in-time free-space reading for a single drive.
+type DriveStatus struct {
+	// Drive is the drive root (e.g. "C:\").
+	Drive string
+
+	// Total is the drive capacity in bytes.
+	Total uint64
+
+	// Free is the free space in bytes.
+	Free uint64
+
+	// Below is true when Free is under the threshold used for the check.
+	Below bool
+}
+
+// DriveRoot normalizes a drive spec such as "c", "C:" or "C:\" to a drive
+// root ("C:\"). Other paths are returned unchanged.
+func DriveRoot(drive string) string {
+	d := strings.TrimSpace(drive)
+	if len(d) == 1 && isLetter(d[0]) {
+		return strings.ToUpper(d) + `:\`
+	}
+	if len(d) == 2 && isLetter(d[0]) && d[1] == ':' {
+		return strings.ToUpper(d) + `\`
+	}
+	if len(d) == 3 && isLetter(d[0]) && d[1] == ':' && (d[2] == '\\' || d[2] == '/') {
+		return strings.ToUpper(d[:2]) + `\`
+	}
+	return d
+}
+
+// isLetter reports whether b is an ASCII letter.
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// FreeSpace returns the free and total bytes for the given drive.
+func FreeSpace(drive string) (free, total uint64, err error) {
+	usage, err := usageFunc(DriveRoot(drive))
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot read disk usage for %s: %w", drive, err)
+	}
+	return usage.Free, usage.Total, nil
+}
+
+// Check reads free space for each drive and flags those below threshold.
+// Drives that cannot be read are reported in the returned error but do not
+// prevent the remaining drives from being checked.
+func Check(drives []string, threshold uint64) ([]DriveStatus, error) {
+	var (
+		statuses []DriveStatus
+		errs     []string
+	)
+
+	for _, d := range drives {
+		root := DriveRoot(d)
+		free, total, err := FreeSpace(root)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		statuses = append(statuses, DriveStatus{
+			Drive: root,
+			Total: total,
+			Free:  free,
+			Below: free < threshold,
+		})
+	}
+
+	if len(errs) > 0 {
+		return statuses, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return statuses, nil
+}
+
+// ─── Reclaimer ───────────────────────────────────────────────────────────────
+
+// Candidate is a single path the reclaimer may delete.
+type Candidate struct {
+	// Path is the absolute filesystem path.
+	Path string
+
+	// Size is the estimated size in bytes.
+	Size int64
+}
+
+// Result summarizes a single reclaim run.
+type Result struct {
+	// Freed is the number of bytes reported freed by Delete.
+	Freed int64
+
+	// Deleted is the number of candidates deleted successfully.
+	Deleted int
+
+	// Errors is the number of candidates that failed to delete.
+	Errors int
+
+	// Skipped is the number of candidates left out because they would
+	// exceed the byte budget.
+	Skipped int
+
+	// FreeAfter is the free space on the drive when the run finished.
+	FreeAfter uint64
+
+	// TargetReached is true when free space reached the target.
+	TargetReached bool
+
+	// BudgetExhausted is true when the byte budget stopped the run.
+	BudgetExhausted bool
+
+	// Stopped is the error returned by Allow when it ended the run early.
+	Stopped error
+}
+
+// Reclaimer deletes candidates until a drive's free space reaches Target or
+// the per-run byte Budget is spent, whichever comes first.
+type Reclaimer struct {
+	// Target is the free space in bytes to restore.
+	Target uint64
+
+	// Budget caps the bytes deleted in one run. Zero means unlimited.
+	Budget int64
+
+	// Delete removes a path and returns the bytes freed.
+	Delete func(path string) (int64, error)
+
+	// OnDelete, if set, is called after each delete attempt.
+	OnDelete func(c Candidate, freed int64, err error)
+
+	// Allow, if set, is called before each deletion. A non-nil error ends
+	// the run and is recorded in Result.Stopped.
+	Allow func(c Candidate) error
+}
+
+// Run deletes candidates in the order given, re-reading free space on drive
+// before each deletion so the run stops as soon as the target is met.
+// Callers should order candidates largest-first to touch as few paths as
+// possible. Candidates that would push the run over budget are skipped in
+// favour of smaller ones.
+func (r *Reclaimer) Run(drive string, candidates []Candidate) (Result, error) {
+	var res Result
+
+	if r.Delete == nil {
+		return res, fmt.Errorf("reclaimer has no delete function")
+	}
+
+	for _, c := range candidates {
+		free, _, err := FreeSpace(drive)
+		if err != nil {
+			return res, err
+		}
+		res.FreeAfter = free
+		if free >= r.Target {
+			res.TargetReached = true
+			return res, nil
+		}
+
+		if r.Budget > 0 {
+			if res.Freed >= r.Budget {
+				res.BudgetExhausted = true
+				break
+			}
+			if res.Freed+c.Size > r.Budget {
+				res.Skipped++
+				continue
+			}
+		}
+
+		if r.Allow != nil {
+			if err := r.Allow(c); err != nil {
+				res.Stopped = err
+				break
+			}
+		}
+
+		freed, delErr := r.Delete(c.Path)
+		if delErr != nil {
+			res.Errors++
+		} else {
+			res.Deleted++
+			res.Freed += freed
+		}
+		if r.OnDelete != nil {
+			r.OnDelete(c, freed, delErr)
+		}
+	}
+
+	free, _, err := FreeSpace(drive)
+	if err != nil {
+		return res, err
+	}
+	res.FreeAfter = free
+	res.TargetReached = free >= r.Target
+	if r.Budget > 0 && res.Freed >= r.Budget {
+		res.BudgetExhausted = true
+	}
+	return res, nil
+}
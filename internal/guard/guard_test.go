@@ -0,0 +1,177 @@
+package guard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+// fakeDrive simulates a drive whose free space grows as files are deleted.
+type fakeDrive struct {
+	total uint64
+	free  uint64
+}
+
+func (f *fakeDrive) usage(path string) (*disk.UsageStat, error) {
+	return &disk.UsageStat{Path: path, Total: f.total, Free: f.free}, nil
+}
+
+func (f *fakeDrive) delete(sizes map[string]int64) func(string) (int64, error) {
+	return func(path string) (int64, error) {
+		size := sizes[path]
+		f.free += uint64(size)
+		return size, nil
+	}
+}
+
+func useFakeDrive(t *testing.T, f *fakeDrive) {
+	t.Helper()
+	orig := usageFunc
+	usageFunc = f.usage
+	t.Cleanup(func() { usageFunc = orig })
+}
+
+func TestDriveRoot(t *testing.T) {
+	tests := map[string]string{
+		"c":       `C:\`,
+		"D:":      `D:\`,
+		`e:\`:     `E:\`,
+		"f:/":     `F:\`,
+		`C:\data`: `C:\data`,
+	}
+	for in, want := range tests {
+		if got := DriveRoot(in); got != want {
+			t.Errorf("DriveRoot(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheck_FlagsDrivesBelowThreshold(t *testing.T) {
+	useFakeDrive(t, &fakeDrive{total: 100, free: 5})
+
+	statuses, err := Check([]string{"C:"}, 10)
+	if err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(statuses))
+	}
+	if !statuses[0].Below {
+		t.Error("drive with 5 bytes free should be below a threshold of 10")
+	}
+	if statuses[0].Drive != `C:\` {
+		t.Errorf("Drive = %q, want %q", statuses[0].Drive, `C:\`)
+	}
+}
+
+func TestCheck_ReportsUnreadableDrives(t *testing.T) {
+	orig := usageFunc
+	usageFunc = func(string) (*disk.UsageStat, error) { return nil, errors.New("no such drive") }
+	t.Cleanup(func() { usageFunc = orig })
+
+	statuses, err := Check([]string{"Z:"}, 10)
+	if err == nil {
+		t.Fatal("expected error for unreadable drive")
+	}
+	if len(statuses) != 0 {
+		t.Errorf("expected no statuses, got %d", len(statuses))
+	}
+}
+
+func TestReclaimer_StopsAtTarget(t *testing.T) {
+	drive := &fakeDrive{total: 1000, free: 10}
+	useFakeDrive(t, drive)
+
+	sizes := map[string]int64{"a": 50, "b": 40, "c": 30}
+	r := &Reclaimer{Target: 90, Delete: drive.delete(sizes)}
+
+	res, err := r.Run("C:", []Candidate{{"a", 50}, {"b", 40}, {"c", 30}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !res.TargetReached {
+		t.Error("expected target to be reached")
+	}
+	if res.Deleted != 2 {
+		t.Errorf("Deleted = %d, want 2 (c should be left alone)", res.Deleted)
+	}
+	if res.Freed != 90 {
+		t.Errorf("Freed = %d, want 90", res.Freed)
+	}
+}
+
+func TestReclaimer_RespectsBudget(t *testing.T) {
+	drive := &fakeDrive{total: 1000, free: 0}
+	useFakeDrive(t, drive)
+
+	sizes := map[string]int64{"a": 60, "b": 50, "c": 30}
+	r := &Reclaimer{Target: 500, Budget: 90, Delete: drive.delete(sizes)}
+
+	res, err := r.Run("C:", []Candidate{{"a", 60}, {"b", 50}, {"c", 30}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if res.Freed > 90 {
+		t.Errorf("Freed = %d, exceeds budget of 90", res.Freed)
+	}
+	if res.Deleted != 2 || res.Skipped != 1 {
+		t.Errorf("Deleted = %d, Skipped = %d; want 2 and 1", res.Deleted, res.Skipped)
+	}
+	if res.TargetReached {
+		t.Error("target should not be reached within budget")
+	}
+}
+
+func TestReclaimer_CountsErrors(t *testing.T) {
+	useFakeDrive(t, &fakeDrive{total: 1000, free: 0})
+
+	var calls int
+	r := &Reclaimer{
+		Target: 500,
+		Delete: func(string) (int64, error) { return 0, errors.New("locked") },
+		OnDelete: func(Candidate, int64, error) {
+			calls++
+		},
+	}
+
+	res, err := r.Run("C:", []Candidate{{"a", 10}, {"b", 20}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if res.Errors != 2 || res.Deleted != 0 {
+		t.Errorf("Errors = %d, Deleted = %d; want 2 and 0", res.Errors, res.Deleted)
+	}
+	if calls != 2 {
+		t.Errorf("OnDelete called %d times, want 2", calls)
+	}
+}
+
+func TestReclaimer_AllowStopsRun(t *testing.T) {
+	drive := &fakeDrive{total: 1000, free: 0}
+	useFakeDrive(t, drive)
+	sizes := map[string]int64{"a": 10, "b": 20, "c": 30}
+
+	stop := errors.New("breaker tripped")
+	r := &Reclaimer{
+		Target: 500,
+		Delete: drive.delete(sizes),
+		Allow: func(c Candidate) error {
+			if c.Path == "b" {
+				return stop
+			}
+			return nil
+		},
+	}
+
+	res, err := r.Run("C:", []Candidate{{"a", 10}, {"b", 20}, {"c", 30}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !errors.Is(res.Stopped, stop) {
+		t.Errorf("Stopped = %v, want %v", res.Stopped, stop)
+	}
+	if res.Deleted != 1 || res.Freed != 10 {
+		t.Errorf("Deleted = %d, Freed = %d; want 1 and 10", res.Deleted, res.Freed)
+	}
+}
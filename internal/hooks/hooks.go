@@ -0,0 +1,239 @@
+// Package hooks runs user-configured commands at fixed points of a cleanup
+// session (before scan, after scan, before delete, after session).
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+)
+
+// DefaultTimeout bounds a hook that does not set its own timeout.
+const DefaultTimeout = time.Minute
+
+// waitDelay bounds how long to wait for output after a hook is killed.
+const waitDelay = 2 * time.Second
+
+// maxLoggedOutput caps how much hook output is copied into the op log.
+const maxLoggedOutput = 4096
+
+// Stage identifies when a hook runs.
+type Stage string
+
+const (
+	// PreScan runs before scanning. A failure aborts the run.
+	PreScan Stage = "pre_scan"
+
+	// PostScan runs after scanning with the plan JSON on stdin.
+	PostScan Stage = "post_scan"
+
+	// PreDelete runs after confirmation, before deletion. A failure aborts
+	// the run.
+	PreDelete Stage = "pre_delete"
+
+	// PostSession runs at the end of a session with the result JSON on stdin.
+	PostSession Stage = "post_session"
+)
+
+// IsPre reports whether a failing hook at this stage aborts the run.
+func (s Stage) IsPre() bool {
+	return s == PreScan || s == PreDelete
+}
+
+// Logger receives hook output. core.Logger satisfies it.
+type Logger interface {
+	LogEvent(operation, detail string)
+}
+
+// AbortError is returned when a pre-stage hook fails.
+type AbortError struct {
+	Stage   Stage
+	Command string
+	Err     error
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("%s hook %q failed, aborting: %v", e.Stage, e.Command, e.Err)
+}
+
+func (e *AbortError) Unwrap() error { return e.Err }
+
+// ─── Runner ──────────────────────────────────────────────────────────────────
+
+// Runner runs the hooks configured for one pw command.
+type Runner struct {
+	cfg     config.HooksConfig
+	command string
+	log     Logger
+}
+
+// NewRunner creates a Runner for the given pw command (e.g. "clean").
+// log may be nil, in which case hook output is discarded.
+func NewRunner(cfg config.HooksConfig, command string, log Logger) *Runner {
+	return &Runner{cfg: cfg, command: command, log: log}
+}
+
+// Has reports whether any hook applies to this command.
+func (r *Runner) Has() bool {
+	for _, s := range []Stage{PreScan, PostScan, PreDelete, PostSession} {
+		if len(r.hooksFor(s)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hooksFor returns the hooks configured for a stage that apply to this
+// runner's command.
+func (r *Runner) hooksFor(stage Stage) []config.Hook {
+	var all []config.Hook
+	switch stage {
+	case PreScan:
+		all = r.cfg.PreScan
+	case PostScan:
+		all = r.cfg.PostScan
+	case PreDelete:
+		all = r.cfg.PreDelete
+	case PostSession:
+		all = r.cfg.PostSession
+	}
+
+	var out []config.Hook
+	for _, h := range all {
+		if strings.TrimSpace(h.Command) == "" {
+			continue
+		}
+		if len(h.Commands) > 0 && !containsFold(h.Commands, r.command) {
+			continue
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// Run executes every hook for the stage in order. payload, if non-nil, is
+// marshalled to JSON and written to each hook's stdin. For pre stages the
+// first failing hook stops the sequence and an *AbortError is returned;
+// for post stages all hooks run and the first error is returned.
+func (r *Runner) Run(stage Stage, payload any) error {
+	hooks := r.hooksFor(stage)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	var stdin []byte
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("cannot encode %s payload: %w", stage, err)
+		}
+		stdin = data
+	}
+
+	var firstErr error
+	for _, h := range hooks {
+		err := r.runOne(stage, h, stdin)
+		if err == nil {
+			continue
+		}
+		if stage.IsPre() {
+			return &AbortError{Stage: stage, Command: h.Command, Err: err}
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s hook %q failed: %w", stage, h.Command, err)
+		}
+	}
+	return firstErr
+}
+
+// runOne runs a single hook through the system shell and logs its output.
+func (r *Runner) runOne(stage Stage, h config.Hook, stdin []byte) error {
+	timeout := DefaultTimeout
+	if h.Timeout != "" {
+		d, err := time.ParseDuration(h.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", h.Timeout, err)
+		}
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	c := shellCommand(ctx, h.Command)
+	c.Env = append(os.Environ(),
+		"PW_HOOK_STAGE="+string(stage),
+		"PW_COMMAND="+r.command,
+	)
+	if stdin != nil {
+		c.Stdin = bytes.NewReader(stdin)
+	}
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	// Don't wait on grandchildren that keep the output pipe open after
+	// the shell is killed on timeout.
+	c.WaitDelay = waitDelay
+
+	start := time.Now()
+	err := c.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	r.logResult(stage, h.Command, time.Since(start), out.Bytes(), err)
+	return err
+}
+
+// logResult records a hook's exit status and output in the op log.
+func (r *Runner) logResult(stage Stage, command string, elapsed time.Duration, output []byte, err error) {
+	if r.log == nil {
+		return
+	}
+
+	status := "exit=0"
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			status = fmt.Sprintf("exit=%d", exitErr.ExitCode())
+		} else {
+			status = fmt.Sprintf("error=%q", err.Error())
+		}
+	}
+
+	text := strings.TrimSpace(string(output))
+	if len(text) > maxLoggedOutput {
+		text = text[:maxLoggedOutput] + "…"
+	}
+
+	r.log.LogEvent("HOOK_"+strings.ToUpper(string(stage)),
+		fmt.Sprintf("command=%q %s elapsed=%s output=%q",
+			command, status, elapsed.Round(time.Millisecond), text))
+}
+
+// shellCommand wraps a command line in the platform shell.
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
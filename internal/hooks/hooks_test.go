@@ -0,0 +1,106 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+)
+
+// recordingLogger captures LogEvent calls.
+type recordingLogger struct {
+	events []string
+}
+
+func (l *recordingLogger) LogEvent(operation, detail string) {
+	l.events = append(l.events, operation+" "+detail)
+}
+
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell syntax")
+	}
+}
+
+func TestRun_PreHookFailureAborts(t *testing.T) {
+	skipOnWindows(t)
+	log := &recordingLogger{}
+	r := NewRunner(config.HooksConfig{
+		PreScan: []config.Hook{{Command: "echo stopping; exit 3"}},
+	}, "purge", log)
+
+	err := r.Run(PreScan, nil)
+	var abort *AbortError
+	if !errors.As(err, &abort) {
+		t.Fatalf("expected *AbortError, got %v", err)
+	}
+	if len(log.events) != 1 || !strings.Contains(log.events[0], "exit=3") ||
+		!strings.Contains(log.events[0], "stopping") {
+		t.Errorf("expected exit code and output in log, got %v", log.events)
+	}
+}
+
+func TestRun_PostHookFailureDoesNotAbort(t *testing.T) {
+	skipOnWindows(t)
+	r := NewRunner(config.HooksConfig{
+		PostSession: []config.Hook{{Command: "exit 1"}},
+	}, "clean", nil)
+
+	err := r.Run(PostSession, nil)
+	if err == nil {
+		t.Fatal("expected error from failing post hook")
+	}
+	var abort *AbortError
+	if errors.As(err, &abort) {
+		t.Error("post hook failure should not be an AbortError")
+	}
+}
+
+func TestRun_PayloadOnStdin(t *testing.T) {
+	skipOnWindows(t)
+	out := filepath.Join(t.TempDir(), "payload.json")
+	r := NewRunner(config.HooksConfig{
+		PostScan: []config.Hook{{Command: "cat > " + out}},
+	}, "clean", nil)
+
+	if err := r.Run(PostScan, map[string]int{"item_count": 2}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not write payload: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != `{"item_count":2}` {
+		t.Errorf("payload = %q", data)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	skipOnWindows(t)
+	r := NewRunner(config.HooksConfig{
+		PreDelete: []config.Hook{{Command: "sleep 5", Timeout: "100ms"}},
+	}, "clean", nil)
+
+	err := r.Run(PreDelete, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
+
+func TestRun_CommandFilter(t *testing.T) {
+	r := NewRunner(config.HooksConfig{
+		PreScan: []config.Hook{{Command: "exit 1", Commands: []string{"purge"}}},
+	}, "clean", nil)
+
+	if r.Has() {
+		t.Error("hook restricted to purge should not apply to clean")
+	}
+	if err := r.Run(PreScan, nil); err != nil {
+		t.Errorf("filtered hook should not run, got %v", err)
+	}
+}
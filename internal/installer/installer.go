@@ -78,6 +78,18 @@ func GetScanLocations() []scanLocation {
 	return locations
 }
 
+// SourceDirs returns the directories ScanInstallers searches.
+func SourceDirs() []string {
+	locations := GetScanLocations()
+	dirs := make([]string, 0, len(locations))
+	for _, loc := range locations {
+		if loc.Path != "" {
+			dirs = append(dirs, loc.Path)
+		}
+	}
+	return dirs
+}
+
 // ScanInstallers scans for installer files matching the criteria.
 // minAge is in days (0 = no age filter)
 // minSize is in bytes (0 = no size filter)
@@ -0,0 +1,35 @@
+package notify
+
+import "sync"
+
+// AlertState tracks which alerts are currently tripped so each one is sent
+// once when it trips, not on every reading while it stays tripped.
+type AlertState struct {
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+// NewAlertState creates an empty AlertState.
+func NewAlertState() *AlertState {
+	return &AlertState{active: make(map[string]bool)}
+}
+
+// Transition takes the alerts tripped by the latest reading and returns only
+// those that were not tripped by the previous reading. Alerts absent from
+// current are cleared so they fire again if they trip later.
+func (s *AlertState) Transition(current []Alert) []Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(current))
+	var fired []Alert
+	for _, a := range current {
+		key := a.Key()
+		seen[key] = true
+		if !s.active[key] {
+			fired = append(fired, a)
+		}
+	}
+	s.active = seen
+	return fired
+}
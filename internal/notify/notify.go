@@ -0,0 +1,387 @@
+// Package notify delivers session results and status alerts to webhooks,
+// with retries and a local spool for deliveries that could not be made.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+const (
+	// EventSession is sent after a clean, purge, installer, guard or
+	// uninstall session.
+	EventSession = "session"
+
+	// EventAlert is sent when a status threshold trips.
+	EventAlert = "alert"
+
+	// defaultTimeout bounds a delivery attempt when the webhook sets none.
+	defaultTimeout = 10 * time.Second
+
+	// defaultBackoff is the delay before the first retry; it doubles on
+	// each subsequent retry.
+	defaultBackoff = time.Second
+
+	// maxSpoolFiles caps the spool so an unreachable endpoint can't fill
+	// the disk; the oldest entries are dropped first.
+	maxSpoolFiles = 500
+)
+
+// ─── Events ──────────────────────────────────────────────────────────────────
+
+// Event is the document delivered to webhooks.
+type Event struct {
+	Type     string          `json:"type"`
+	Hostname string          `json:"hostname"`
+	Time     time.Time       `json:"time"`
+	Session  *report.Session `json:"session,omitempty"`
+	Alert    *Alert          `json:"alert,omitempty"`
+}
+
+// Alert describes a tripped status threshold.
+type Alert struct {
+	// Metric is the reading that tripped ("cpu", "memory", "disk").
+	Metric string `json:"metric"`
+
+	// Target identifies the instance for per-instance metrics (e.g. a drive).
+	Target string `json:"target,omitempty"`
+
+	// Value is the current reading.
+	Value float64 `json:"value"`
+
+	// Threshold is the configured limit.
+	Threshold float64 `json:"threshold"`
+
+	// Message is a human-readable summary.
+	Message string `json:"message"`
+}
+
+// Key identifies an alert for transition tracking.
+func (a Alert) Key() string {
+	return a.Metric + "|" + a.Target
+}
+
+// NewSessionEvent wraps a session result in an event.
+func NewSessionEvent(s report.Session) Event {
+	return Event{Type: EventSession, Hostname: s.Hostname, Time: s.Finished, Session: &s}
+}
+
+// NewAlertEvent wraps an alert in an event.
+func NewAlertEvent(a Alert) Event {
+	host, _ := os.Hostname()
+	return Event{Type: EventAlert, Hostname: host, Time: time.Now(), Alert: &a}
+}
+
+// ─── Notifier ────────────────────────────────────────────────────────────────
+
+// Notifier posts events to the configured webhooks.
+type Notifier struct {
+	webhooks []config.Webhook
+	spoolDir string
+	client   *http.Client
+	backoff  time.Duration
+	seq      atomic.Int64
+}
+
+// New creates a Notifier for the configured webhooks. Failed deliveries are
+// written to spoolDir.
+func New(cfg config.NotifyConfig, spoolDir string) *Notifier {
+	if cfg.SpoolDir != "" {
+		spoolDir = cfg.SpoolDir
+	}
+	return &Notifier{
+		webhooks: cfg.Webhooks,
+		spoolDir: spoolDir,
+		client:   &http.Client{},
+		backoff:  defaultBackoff,
+	}
+}
+
+// Enabled reports whether any webhook is configured.
+func (n *Notifier) Enabled() bool {
+	return len(n.webhooks) > 0
+}
+
+// Send delivers an event to every webhook that accepts its type. Spooled
+// deliveries from earlier failures are retried first. Deliveries that still
+// fail after all retries are spooled and reported in the returned error.
+func (n *Notifier) Send(ev Event) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	// Best effort: a still-unreachable endpoint stays spooled.
+	_, _ = n.Flush()
+
+	var errs []string
+	for _, wh := range n.webhooks {
+		if !accepts(wh, ev.Type) {
+			continue
+		}
+
+		d, err := newDelivery(wh, ev)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if err := n.deliver(d, wh.Retries); err != nil {
+			if spoolErr := n.spool(d); spoolErr != nil {
+				err = fmt.Errorf("%w (spool failed: %v)", err, spoolErr)
+			} else {
+				err = fmt.Errorf("%w (spooled for retry)", err)
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("webhook delivery failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// accepts reports whether the webhook subscribes to the event type.
+func accepts(wh config.Webhook, eventType string) bool {
+	if wh.URL == "" {
+		return false
+	}
+	if len(wh.Events) == 0 {
+		return true
+	}
+	for _, e := range wh.Events {
+		if strings.EqualFold(e, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ─── Delivery ────────────────────────────────────────────────────────────────
+
+// delivery is a fully rendered request, persisted as-is in the spool.
+type delivery struct {
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body"`
+	Timeout     string            `json:"timeout,omitempty"`
+	Created     time.Time         `json:"created"`
+}
+
+// newDelivery renders the request body for a webhook.
+func newDelivery(wh config.Webhook, ev Event) (delivery, error) {
+	body, err := renderBody(wh.Template, ev)
+	if err != nil {
+		return delivery{}, fmt.Errorf("%s: %w", wh.URL, err)
+	}
+	ct := wh.ContentType
+	if ct == "" {
+		ct = "application/json"
+	}
+	return delivery{
+		URL:         wh.URL,
+		ContentType: ct,
+		Headers:     wh.Headers,
+		Body:        body,
+		Timeout:     wh.Timeout,
+		Created:     time.Now(),
+	}, nil
+}
+
+// renderBody executes the body template, or marshals the event as JSON when
+// no template is set.
+func renderBody(tmpl string, ev Event) (string, error) {
+	if tmpl == "" {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return "", fmt.Errorf("cannot encode event: %w", err)
+		}
+		return string(data), nil
+	}
+
+	t, err := template.New("body").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, ev); err != nil {
+		return "", fmt.Errorf("template failed: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// templateFuncs are available to webhook body templates.
+var templateFuncs = template.FuncMap{
+	// size formats a byte count, e.g. {{size .Session.Freed}} → "1.5 GB".
+	"size": core.FormatSize,
+	// json encodes a value as JSON, for embedding strings safely in a JSON
+	// body, e.g. {"text": {{json .Alert.Message}}}.
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// deliver posts a delivery, retrying with exponential backoff.
+func (n *Notifier) deliver(d delivery, retries int) error {
+	var err error
+	wait := n.backoff
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		if err = n.post(d); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// post makes a single delivery attempt. Any 2xx response is success.
+func (n *Notifier) post(d delivery) error {
+	timeout := defaultTimeout
+	if d.Timeout != "" {
+		if t, err := time.ParseDuration(d.Timeout); err == nil {
+			timeout = t
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.URL, strings.NewReader(d.Body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", d.URL, err)
+	}
+	req.Header.Set("Content-Type", d.ContentType)
+	req.Header.Set("User-Agent", "purewin")
+	for k, v := range d.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := *n.client
+	client.Timeout = timeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", d.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: HTTP %d", d.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// ─── Spool ───────────────────────────────────────────────────────────────────
+
+// spool writes a failed delivery to the spool directory.
+func (n *Notifier) spool(d delivery) error {
+	if n.spoolDir == "" {
+		return fmt.Errorf("no spool directory configured")
+	}
+	if err := os.MkdirAll(n.spoolDir, 0o755); err != nil {
+		return fmt.Errorf("cannot create spool directory: %w", err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("cannot encode delivery: %w", err)
+	}
+	name := fmt.Sprintf("%d-%d.json", time.Now().UnixNano(), n.seq.Add(1))
+	if err := os.WriteFile(filepath.Join(n.spoolDir, name), data, 0o600); err != nil {
+		return fmt.Errorf("cannot write spool file: %w", err)
+	}
+
+	n.trimSpool()
+	return nil
+}
+
+// spoolFiles returns spooled delivery files, oldest first.
+func (n *Notifier) spoolFiles() []string {
+	entries, err := os.ReadDir(n.spoolDir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			files = append(files, filepath.Join(n.spoolDir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// trimSpool drops the oldest spooled deliveries beyond maxSpoolFiles.
+func (n *Notifier) trimSpool() {
+	files := n.spoolFiles()
+	for len(files) > maxSpoolFiles {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+}
+
+// Pending returns the number of spooled deliveries awaiting retry.
+func (n *Notifier) Pending() int {
+	if n.spoolDir == "" {
+		return 0
+	}
+	return len(n.spoolFiles())
+}
+
+// Flush retries every spooled delivery once, removing those that succeed.
+// After a delivery to a URL fails, the later ones to that URL stay spooled
+// untried, so each endpoint still gets its deliveries in order, while the
+// other endpoints are flushed as usual. The error names each URL that
+// failed.
+func (n *Notifier) Flush() (int, error) {
+	if n.spoolDir == "" {
+		return 0, nil
+	}
+
+	sent := 0
+	failed := make(map[string]bool)
+	var errs []string
+	for _, path := range n.spoolFiles() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var d delivery
+		if err := json.Unmarshal(data, &d); err != nil {
+			// Corrupt entry: drop it rather than retrying forever.
+			_ = os.Remove(path)
+			continue
+		}
+		if failed[d.URL] {
+			continue
+		}
+		if err := n.post(d); err != nil {
+			failed[d.URL] = true
+			errs = append(errs, err.Error())
+			continue
+		}
+		_ = os.Remove(path)
+		sent++
+	}
+
+	if len(errs) > 0 {
+		return sent, fmt.Errorf("spooled delivery failed: %s", strings.Join(errs, "; "))
+	}
+	return sent, nil
+}
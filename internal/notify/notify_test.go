@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+func testSession() report.Session {
+	return report.Session{
+		Command:  "clean",
+		Hostname: "build-01",
+		Started:  time.Now().Add(-time.Minute),
+		Finished: time.Now(),
+		Freed:    3 << 30,
+		Items:    42,
+	}
+}
+
+func newTestNotifier(t *testing.T, hooks ...config.Webhook) *Notifier {
+	t.Helper()
+	n := New(config.NotifyConfig{Webhooks: hooks}, t.TempDir())
+	n.backoff = time.Millisecond
+	return n
+}
+
+func TestSend_DefaultJSONBody(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{URL: srv.URL})
+	if err := n.Send(NewSessionEvent(testSession())); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if got.Type != EventSession || got.Session == nil || got.Session.Items != 42 {
+		t.Errorf("unexpected event: %+v", got)
+	}
+}
+
+func TestSend_Template(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{
+		URL:      srv.URL,
+		Template: `{"text": {{json (printf "%s freed %s" .Hostname (size .Session.Freed))}}}`,
+	})
+	if err := n.Send(NewSessionEvent(testSession())); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if body != `{"text": "build-01 freed 3.00 GB"}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestSend_RetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{URL: srv.URL, Retries: 2})
+	if err := n.Send(NewSessionEvent(testSession())); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+	if n.Pending() != 0 {
+		t.Errorf("Pending() = %d, want 0", n.Pending())
+	}
+}
+
+func TestSend_SpoolsAndFlushes(t *testing.T) {
+	var healthy atomic.Bool
+	var delivered atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		delivered.Add(1)
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{URL: srv.URL, Retries: 1})
+	err := n.Send(NewSessionEvent(testSession()))
+	if err == nil || !strings.Contains(err.Error(), "spooled") {
+		t.Fatalf("expected spooled error, got %v", err)
+	}
+	if n.Pending() != 1 {
+		t.Fatalf("Pending() = %d, want 1", n.Pending())
+	}
+
+	healthy.Store(true)
+	sent, err := n.Flush()
+	if err != nil || sent != 1 {
+		t.Fatalf("Flush() = %d, %v; want 1, nil", sent, err)
+	}
+	if n.Pending() != 0 || delivered.Load() != 1 {
+		t.Errorf("Pending() = %d, delivered = %d", n.Pending(), delivered.Load())
+	}
+}
+
+func TestFlush_ContinuesPastFailedURL(t *testing.T) {
+	var healthy atomic.Bool
+	var delivered atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		delivered.Add(1)
+	}))
+	defer up.Close()
+
+	// The unreachable endpoint's delivery is spooled first.
+	n := newTestNotifier(t, config.Webhook{URL: down.URL}, config.Webhook{URL: up.URL})
+	if err := n.Send(NewSessionEvent(testSession())); err == nil {
+		t.Fatal("expected Send() to fail")
+	}
+	if n.Pending() != 2 {
+		t.Fatalf("Pending() = %d, want 2", n.Pending())
+	}
+
+	healthy.Store(true)
+	sent, err := n.Flush()
+	if sent != 1 || err == nil || !strings.Contains(err.Error(), down.URL) {
+		t.Fatalf("Flush() = %d, %v; want 1 and an error naming %s", sent, err, down.URL)
+	}
+	if n.Pending() != 1 || delivered.Load() != 1 {
+		t.Errorf("Pending() = %d, delivered = %d; want 1, 1", n.Pending(), delivered.Load())
+	}
+}
+
+func TestSend_EventFilter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	n := newTestNotifier(t, config.Webhook{URL: srv.URL, Events: []string{EventAlert}})
+	if err := n.Send(NewSessionEvent(testSession())); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("alert-only webhook received a session event")
+	}
+}
+
+func TestAlertState_FiresOnTransition(t *testing.T) {
+	s := NewAlertState()
+	cpu := Alert{Metric: "cpu", Value: 95, Threshold: 90}
+
+	if fired := s.Transition([]Alert{cpu}); len(fired) != 1 {
+		t.Fatalf("first trip should fire, got %d", len(fired))
+	}
+	if fired := s.Transition([]Alert{cpu}); len(fired) != 0 {
+		t.Errorf("sustained alert should not fire again, got %d", len(fired))
+	}
+	s.Transition(nil)
+	if fired := s.Transition([]Alert{cpu}); len(fired) != 1 {
+		t.Errorf("alert should fire again after recovering, got %d", len(fired))
+	}
+}
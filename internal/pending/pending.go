@@ -0,0 +1,387 @@
+// Package pending tracks files PureWin could not delete because another
+// process held them open: it names the holders, schedules the files for
+// deletion at the next reboot, and remembers what it scheduled so the user
+// can list or cancel it later.
+//
+// The operating-system calls sit behind the System interface; the Windows
+// implementation uses the Restart Manager and MoveFileEx.
+package pending
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holder is a process that has a file open.
+type Holder struct {
+	PID  int    `json:"pid"`
+	Name string `json:"name"`
+}
+
+func (h Holder) String() string {
+	return fmt.Sprintf("%s (pid %d)", h.Name, h.PID)
+}
+
+// System is the platform layer behind a Manager.
+type System interface {
+	// Holders returns the processes that have path open.
+	Holders(path string) ([]Holder, error)
+
+	// ScheduleDelete marks path for deletion at the next reboot.
+	ScheduleDelete(path string) error
+
+	// CancelDelete removes path from the reboot deletion list.
+	CancelDelete(path string) error
+
+	// Scheduled returns every path currently marked for deletion at reboot,
+	// including ones other programs scheduled.
+	Scheduled() ([]string, error)
+}
+
+// LockedFile is a path that could not be deleted because it was in use.
+type LockedFile struct {
+	Path    string
+	Size    int64
+	Holders []Holder
+}
+
+// Entry is a reboot deletion PureWin scheduled.
+type Entry struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	Holders   []string  `json:"holders,omitempty"`
+	Command   string    `json:"command"`
+	Scheduled time.Time `json:"scheduled"`
+}
+
+// Manager schedules reboot deletions and keeps their record in a state file.
+type Manager struct {
+	// Sys performs the platform calls.
+	Sys System
+
+	// Path is the JSON state file.
+	Path string
+
+	// Now returns the current time; nil uses time.Now.
+	Now func() time.Time
+}
+
+// ─── Holders ─────────────────────────────────────────────────────────────────
+
+// Lookup fills in the holders of up to limit files (all of them when limit
+// is 0). Lookup failures leave a file's holders empty.
+func (m *Manager) Lookup(files []LockedFile, limit int) {
+	for i := range files {
+		if limit > 0 && i >= limit {
+			return
+		}
+		holders, err := m.Sys.Holders(files[i].Path)
+		if err == nil {
+			files[i].Holders = holders
+		}
+	}
+}
+
+// HolderGroup is the set of locked files one process holds.
+type HolderGroup struct {
+	// Holder is the process; its zero value groups files with no known
+	// holder.
+	Holder Holder
+	Paths  []string
+}
+
+// GroupByHolder groups files by the processes holding them, largest group
+// first. A file held by several processes appears in each group.
+func GroupByHolder(files []LockedFile) []HolderGroup {
+	index := make(map[Holder]int)
+	var groups []HolderGroup
+	add := func(h Holder, path string) {
+		i, ok := index[h]
+		if !ok {
+			i = len(groups)
+			index[h] = i
+			groups = append(groups, HolderGroup{Holder: h})
+		}
+		groups[i].Paths = append(groups[i].Paths, path)
+	}
+	for _, f := range files {
+		if len(f.Holders) == 0 {
+			add(Holder{}, f.Path)
+		}
+		for _, h := range f.Holders {
+			add(h, f.Path)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Holder == Holder{}) != (groups[j].Holder == Holder{}) {
+			return groups[j].Holder == Holder{}
+		}
+		return len(groups[i].Paths) > len(groups[j].Paths)
+	})
+	return groups
+}
+
+// ─── Resolution ──────────────────────────────────────────────────────────────
+
+// ResolveOptions configure Resolve.
+type ResolveOptions struct {
+	// Command is recorded with the scheduled entries.
+	Command string
+
+	// Limit caps the holder lookups, as for Lookup.
+	Limit int
+
+	// Elevated reports whether the process may schedule reboot deletions.
+	Elevated bool
+
+	// Report, if set, is shown the files grouped by holder before anything
+	// is asked.
+	Report func(groups []HolderGroup)
+
+	// Confirm asks whether to delete the files at the next reboot. Nil
+	// means nobody can be asked, so the holders are only reported.
+	Confirm func(files []LockedFile) bool
+}
+
+// Resolution is the outcome of Resolve.
+type Resolution struct {
+	// NeedsAdmin is set when the files could have been offered for
+	// deletion at reboot but the process is not elevated.
+	NeedsAdmin bool
+
+	// Scheduled are the entries added, and Err the scheduling failures.
+	Scheduled []Entry
+	Err       error
+}
+
+// Resolve names the processes holding files, then offers to delete the
+// files at the next reboot and schedules them if the user agrees. Nothing
+// is offered without elevation.
+func (m *Manager) Resolve(files []LockedFile, opts ResolveOptions) Resolution {
+	var res Resolution
+	if len(files) == 0 {
+		return res
+	}
+	m.Lookup(files, opts.Limit)
+	if opts.Report != nil {
+		opts.Report(GroupByHolder(files))
+	}
+	switch {
+	case opts.Confirm == nil:
+	case !opts.Elevated:
+		res.NeedsAdmin = true
+	case opts.Confirm(files):
+		res.Scheduled, res.Err = m.Schedule(files, opts.Command)
+	}
+	return res
+}
+
+// ─── Scheduling ──────────────────────────────────────────────────────────────
+
+// Schedule marks files for deletion at the next reboot and records them.
+// Windows only deletes empty directories at boot, so for a directory
+// everything still inside it is scheduled first, children before their
+// parents; only the directory itself is recorded. Files that could not be
+// scheduled are returned with the joined errors; the rest are still
+// recorded.
+func (m *Manager) Schedule(files []LockedFile, command string) ([]Entry, error) {
+	entries, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now
+	if m.Now != nil {
+		now = m.Now
+	}
+
+	var added []Entry
+	var errs []error
+	for _, f := range files {
+		for _, p := range beneath(f.Path) {
+			if err := m.Sys.ScheduleDelete(p); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			}
+		}
+		if err := m.Sys.ScheduleDelete(f.Path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.Path, err))
+			continue
+		}
+		e := Entry{Path: f.Path, Size: f.Size, Command: command, Scheduled: now()}
+		for _, h := range f.Holders {
+			e.Holders = append(e.Holders, h.String())
+		}
+		entries = replace(entries, e)
+		added = append(added, e)
+	}
+	if len(added) > 0 {
+		if err := m.save(entries); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return added, errors.Join(errs...)
+}
+
+// List returns the recorded entries that are still scheduled. Entries the
+// system no longer lists (deleted by a reboot, or canceled elsewhere) are
+// dropped from the state file.
+func (m *Manager) List() ([]Entry, error) {
+	entries, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := m.Sys.Scheduled()
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]bool, len(scheduled))
+	for _, p := range scheduled {
+		live[key(p)] = true
+	}
+
+	kept := entries[:0]
+	for _, e := range entries {
+		if live[key(e.Path)] {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) != len(entries) {
+		if err := m.save(kept); err != nil {
+			return nil, err
+		}
+	}
+	return kept, nil
+}
+
+// Cancel unschedules the recorded entries matching paths, or every
+// recorded entry when paths is empty, along with anything scheduled inside
+// a canceled directory. It returns the canceled entries; paths PureWin did
+// not schedule are reported as errors and left alone.
+func (m *Manager) Cancel(paths []string) ([]Entry, error) {
+	entries, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := m.Sys.Scheduled()
+	if err != nil {
+		return nil, err
+	}
+
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[key(p)] = true
+	}
+
+	var canceled []Entry
+	var errs []error
+	kept := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if len(paths) > 0 && !want[key(e.Path)] {
+			kept = append(kept, e)
+			continue
+		}
+		delete(want, key(e.Path))
+		if err := m.Sys.CancelDelete(e.Path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Path, err))
+			kept = append(kept, e)
+			continue
+		}
+		canceled = append(canceled, e)
+		for _, p := range scheduled {
+			if within(p, e.Path) {
+				_ = m.Sys.CancelDelete(p)
+			}
+		}
+	}
+	for _, p := range paths {
+		if want[key(p)] {
+			errs = append(errs, fmt.Errorf("%s: not scheduled by PureWin", p))
+			delete(want, key(p))
+		}
+	}
+	if len(canceled) > 0 {
+		if err := m.save(kept); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return canceled, errors.Join(errs...)
+}
+
+// beneath returns everything inside the directory at path, children before
+// their parents. Links are listed but not followed. A file, or a path that
+// cannot be read, has nothing beneath it.
+func beneath(path string) []string {
+	var paths []string
+	_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && p != path {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	slices.Reverse(paths)
+	return paths
+}
+
+// within reports whether path lies strictly inside dir.
+func within(path, dir string) bool {
+	return strings.HasPrefix(key(path), key(dir)+string(filepath.Separator))
+}
+
+// ─── State File ──────────────────────────────────────────────────────────────
+
+// load reads the state file; a missing file is an empty list.
+func (m *Manager) load() ([]Entry, error) {
+	data, err := os.ReadFile(m.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read pending deletions: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid pending deletions file %s: %w", m.Path, err)
+	}
+	return entries, nil
+}
+
+// save writes the state file.
+func (m *Manager) save(entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.Path), 0o755); err != nil {
+		return fmt.Errorf("cannot write pending deletions: %w", err)
+	}
+	if err := os.WriteFile(m.Path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write pending deletions: %w", err)
+	}
+	return nil
+}
+
+// replace puts e in entries, replacing any entry for the same path.
+func replace(entries []Entry, e Entry) []Entry {
+	for i := range entries {
+		if key(entries[i].Path) == key(e.Path) {
+			entries[i] = e
+			return entries
+		}
+	}
+	return append(entries, e)
+}
+
+// key normalizes a path for comparison; Windows paths are case-insensitive.
+func key(path string) string {
+	return strings.ToLower(filepath.Clean(path))
+}
@@ -0,0 +1,245 @@
+package pending
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSystem records reboot deletions in memory.
+type fakeSystem struct {
+	holders   map[string][]Holder
+	scheduled []string
+	refuse    map[string]bool
+}
+
+func (f *fakeSystem) Holders(path string) ([]Holder, error) {
+	return f.holders[path], nil
+}
+
+func (f *fakeSystem) ScheduleDelete(path string) error {
+	if f.refuse[path] {
+		return errors.New("access denied")
+	}
+	f.scheduled = append(f.scheduled, path)
+	return nil
+}
+
+func (f *fakeSystem) CancelDelete(path string) error {
+	for i, p := range f.scheduled {
+		if strings.EqualFold(p, path) {
+			f.scheduled = append(f.scheduled[:i], f.scheduled[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not scheduled")
+}
+
+func (f *fakeSystem) Scheduled() ([]string, error) {
+	return append([]string(nil), f.scheduled...), nil
+}
+
+func newManager(t *testing.T, sys *fakeSystem) *Manager {
+	t.Helper()
+	return &Manager{
+		Sys:  sys,
+		Path: filepath.Join(t.TempDir(), "pending.json"),
+		Now:  func() time.Time { return time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestGroupByHolder(t *testing.T) {
+	chrome := Holder{PID: 10, Name: "chrome.exe"}
+	teams := Holder{PID: 20, Name: "Teams.exe"}
+	files := []LockedFile{
+		{Path: "a", Holders: []Holder{chrome}},
+		{Path: "b", Holders: []Holder{chrome, teams}},
+		{Path: "c"},
+		{Path: "d", Holders: []Holder{chrome}},
+	}
+
+	groups := GroupByHolder(files)
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3", len(groups))
+	}
+	if groups[0].Holder != chrome || len(groups[0].Paths) != 3 {
+		t.Errorf("groups[0] = %+v, want chrome with 3 paths", groups[0])
+	}
+	if groups[1].Holder != teams {
+		t.Errorf("groups[1] = %+v, want Teams", groups[1])
+	}
+	if groups[2].Holder != (Holder{}) || groups[2].Paths[0] != "c" {
+		t.Errorf("unknown holders should sort last, got %+v", groups[2])
+	}
+}
+
+func TestLookupLimit(t *testing.T) {
+	sys := &fakeSystem{holders: map[string][]Holder{
+		"a": {{PID: 1, Name: "x.exe"}},
+		"b": {{PID: 2, Name: "y.exe"}},
+	}}
+	m := newManager(t, sys)
+	files := []LockedFile{{Path: "a"}, {Path: "b"}}
+	m.Lookup(files, 1)
+	if len(files[0].Holders) != 1 || len(files[1].Holders) != 0 {
+		t.Errorf("Lookup(limit 1) = %+v", files)
+	}
+}
+
+func TestScheduleListCancel(t *testing.T) {
+	sys := &fakeSystem{refuse: map[string]bool{`C:\locked\c.dat`: true}}
+	m := newManager(t, sys)
+
+	files := []LockedFile{
+		{Path: `C:\locked\a.dat`, Size: 10, Holders: []Holder{{PID: 7, Name: "app.exe"}}},
+		{Path: `C:\locked\b.dat`, Size: 20},
+		{Path: `C:\locked\c.dat`, Size: 30},
+	}
+	added, err := m.Schedule(files, "clean")
+	if err == nil || !strings.Contains(err.Error(), "c.dat") {
+		t.Errorf("Schedule() error = %v, want c.dat failure", err)
+	}
+	if len(added) != 2 || added[0].Holders[0] != "app.exe (pid 7)" || added[0].Command != "clean" {
+		t.Fatalf("Schedule() = %+v", added)
+	}
+
+	list, err := m.List()
+	if err != nil || len(list) != 2 {
+		t.Fatalf("List() = %+v, %v", list, err)
+	}
+
+	// A reboot (or another tool) clears b.dat from the system list.
+	sys.scheduled = sys.scheduled[:1]
+	list, err = m.List()
+	if err != nil || len(list) != 1 || list[0].Path != `C:\locked\a.dat` {
+		t.Fatalf("List() after reboot = %+v, %v", list, err)
+	}
+
+	if _, err := m.Cancel([]string{`C:\elsewhere\x`}); err == nil {
+		t.Error("Cancel() of an unknown path should fail")
+	}
+	canceled, err := m.Cancel([]string{`c:\LOCKED\a.dat`})
+	if err != nil || len(canceled) != 1 {
+		t.Fatalf("Cancel() = %+v, %v", canceled, err)
+	}
+	if len(sys.scheduled) != 0 {
+		t.Errorf("system still schedules %v", sys.scheduled)
+	}
+	if list, _ := m.List(); len(list) != 0 {
+		t.Errorf("List() after cancel = %+v", list)
+	}
+}
+
+func TestCancelAll(t *testing.T) {
+	sys := &fakeSystem{}
+	m := newManager(t, sys)
+	if _, err := m.Schedule([]LockedFile{{Path: "a"}, {Path: "b"}}, "purge"); err != nil {
+		t.Fatal(err)
+	}
+	sys.scheduled = append(sys.scheduled, "not-ours")
+
+	canceled, err := m.Cancel(nil)
+	if err != nil || len(canceled) != 2 {
+		t.Fatalf("Cancel(nil) = %+v, %v", canceled, err)
+	}
+	if len(sys.scheduled) != 1 || sys.scheduled[0] != "not-ours" {
+		t.Errorf("Cancel(nil) touched other programs' entries: %v", sys.scheduled)
+	}
+}
+
+func TestScheduleDirectory(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "cache")
+	for _, rel := range []string{"a/1.dat", "a/b/2.dat", "3.dat"} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sys := &fakeSystem{}
+	m := newManager(t, sys)
+
+	added, err := m.Schedule([]LockedFile{{Path: root}}, "clean")
+	if err != nil || len(added) != 1 || added[0].Path != root {
+		t.Fatalf("Schedule() = %+v, %v; want one entry for the directory", added, err)
+	}
+	if len(sys.scheduled) != 6 || sys.scheduled[len(sys.scheduled)-1] != root {
+		t.Fatalf("scheduled %v, want the tree then the directory last", sys.scheduled)
+	}
+	pos := make(map[string]int, len(sys.scheduled))
+	for i, p := range sys.scheduled {
+		pos[p] = i
+	}
+	for _, p := range sys.scheduled {
+		if p == root {
+			continue
+		}
+		if parent := filepath.Dir(p); pos[parent] < pos[p] {
+			t.Errorf("%s scheduled before its child %s", parent, p)
+		}
+	}
+
+	if _, err := m.Cancel(nil); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if len(sys.scheduled) != 0 {
+		t.Errorf("Cancel() left %v scheduled", sys.scheduled)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	chrome := Holder{PID: 10, Name: "chrome.exe"}
+	locked := func() []LockedFile {
+		return []LockedFile{{Path: "a", Size: 5}, {Path: "b", Size: 7}}
+	}
+	tests := []struct {
+		name          string
+		elevated      bool
+		interactive   bool
+		agree         bool
+		wantAsked     bool
+		wantNeedAdmin bool
+		wantScheduled int
+	}{
+		{name: "no terminal", elevated: true},
+		{name: "not elevated", interactive: true, agree: true, wantNeedAdmin: true},
+		{name: "declined", elevated: true, interactive: true, wantAsked: true},
+		{name: "agreed", elevated: true, interactive: true, agree: true, wantAsked: true, wantScheduled: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys := &fakeSystem{holders: map[string][]Holder{"a": {chrome}}}
+			m := newManager(t, sys)
+
+			var reported []HolderGroup
+			asked := false
+			opts := ResolveOptions{
+				Command:  "clean",
+				Elevated: tt.elevated,
+				Report:   func(groups []HolderGroup) { reported = groups },
+			}
+			if tt.interactive {
+				opts.Confirm = func([]LockedFile) bool {
+					asked = true
+					return tt.agree
+				}
+			}
+
+			res := m.Resolve(locked(), opts)
+			if len(reported) != 2 || reported[0].Holder != chrome {
+				t.Errorf("reported %+v, want chrome's group first", reported)
+			}
+			if asked != tt.wantAsked || res.NeedsAdmin != tt.wantNeedAdmin {
+				t.Errorf("asked = %v, NeedsAdmin = %v; want %v, %v", asked, res.NeedsAdmin, tt.wantAsked, tt.wantNeedAdmin)
+			}
+			if len(res.Scheduled) != tt.wantScheduled || len(sys.scheduled) != tt.wantScheduled || res.Err != nil {
+				t.Errorf("scheduled %d entries (%d in system), err %v; want %d", len(res.Scheduled), len(sys.scheduled), res.Err, tt.wantScheduled)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+//go:build windows
+
+package pending
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// ─── Restart Manager Syscalls ────────────────────────────────────────────────
+
+var (
+	modRstrtmgr             = syscall.NewLazyDLL("rstrtmgr.dll")
+	procRmStartSession      = modRstrtmgr.NewProc("RmStartSession")
+	procRmRegisterResources = modRstrtmgr.NewProc("RmRegisterResources")
+	procRmGetList           = modRstrtmgr.NewProc("RmGetList")
+	procRmEndSession        = modRstrtmgr.NewProc("RmEndSession")
+)
+
+const (
+	cchRmSessionKey = 32
+	cchRmMaxAppName = 255
+	cchRmMaxSvcName = 63
+	errorMoreData   = 234
+)
+
+// rmProcessInfo mirrors the Windows RM_PROCESS_INFO struct.
+type rmProcessInfo struct {
+	ProcessID        uint32
+	ProcessStartTime windows.Filetime
+	AppName          [cchRmMaxAppName + 1]uint16
+	ServiceShortName [cchRmMaxSvcName + 1]uint16
+	ApplicationType  uint32
+	AppStatus        uint32
+	TSSessionID      uint32
+	Restartable      int32
+}
+
+// sessionManagerKey holds PendingFileRenameOperations, the list Windows
+// processes at boot.
+const sessionManagerKey = `SYSTEM\CurrentControlSet\Control\Session Manager`
+
+// pendingRenamesValue is the REG_MULTI_SZ of (source, destination) pairs;
+// an empty destination means delete.
+const pendingRenamesValue = "PendingFileRenameOperations"
+
+// ntPrefix is the NT object-namespace prefix MoveFileEx writes on paths.
+const ntPrefix = `\??\`
+
+// windowsSystem implements System with the Restart Manager and MoveFileEx.
+type windowsSystem struct{}
+
+// NewSystem returns the Windows implementation of System.
+func NewSystem() System {
+	return windowsSystem{}
+}
+
+// Holders asks the Restart Manager which processes have path open.
+func (windowsSystem) Holders(path string) ([]Holder, error) {
+	var session uint32
+	var key [cchRmSessionKey + 1]uint16
+	if ret, _, _ := procRmStartSession.Call(
+		uintptr(unsafe.Pointer(&session)), 0, uintptr(unsafe.Pointer(&key[0])),
+	); ret != 0 {
+		return nil, fmt.Errorf("RmStartSession failed: %w", syscall.Errno(ret))
+	}
+	defer procRmEndSession.Call(uintptr(session))
+
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []*uint16{p}
+	if ret, _, _ := procRmRegisterResources.Call(
+		uintptr(session), 1, uintptr(unsafe.Pointer(&files[0])), 0, 0, 0, 0,
+	); ret != 0 {
+		return nil, fmt.Errorf("RmRegisterResources failed: %w", syscall.Errno(ret))
+	}
+
+	var needed, count uint32
+	var reasons uint32
+	var infos []rmProcessInfo
+	for {
+		var ptr uintptr
+		if count > 0 {
+			infos = make([]rmProcessInfo, count)
+			ptr = uintptr(unsafe.Pointer(&infos[0]))
+		}
+		ret, _, _ := procRmGetList.Call(
+			uintptr(session),
+			uintptr(unsafe.Pointer(&needed)),
+			uintptr(unsafe.Pointer(&count)),
+			ptr,
+			uintptr(unsafe.Pointer(&reasons)),
+		)
+		if ret == errorMoreData {
+			count = needed
+			continue
+		}
+		if ret != 0 {
+			return nil, fmt.Errorf("RmGetList failed: %w", syscall.Errno(ret))
+		}
+		break
+	}
+
+	holders := make([]Holder, 0, count)
+	for _, info := range infos[:count] {
+		holders = append(holders, Holder{
+			PID:  int(info.ProcessID),
+			Name: windows.UTF16ToString(info.AppName[:]),
+		})
+	}
+	return holders, nil
+}
+
+// ScheduleDelete marks path for deletion at the next reboot. It needs
+// administrator rights.
+func (windowsSystem) ScheduleDelete(path string) error {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	return windows.MoveFileEx(p, nil, windows.MOVEFILE_DELAY_UNTIL_REBOOT)
+}
+
+// CancelDelete removes path's delete entries from PendingFileRenameOperations,
+// leaving renames and other programs' entries in place.
+func (windowsSystem) CancelDelete(path string) error {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, sessionManagerKey,
+		registry.QUERY_VALUE|registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("cannot open session manager key: %w", err)
+	}
+	defer k.Close()
+
+	ops, _, err := k.GetStringsValue(pendingRenamesValue)
+	if errors.Is(err, registry.ErrNotExist) {
+		return fmt.Errorf("not scheduled for deletion")
+	}
+	if err != nil {
+		return err
+	}
+
+	kept := make([]string, 0, len(ops))
+	found := false
+	for i := 0; i+1 < len(ops); i += 2 {
+		if ops[i+1] == "" && samePath(ops[i], path) {
+			found = true
+			continue
+		}
+		kept = append(kept, ops[i], ops[i+1])
+	}
+	if !found {
+		return fmt.Errorf("not scheduled for deletion")
+	}
+	if len(kept) == 0 {
+		return k.DeleteValue(pendingRenamesValue)
+	}
+	return k.SetStringsValue(pendingRenamesValue, kept)
+}
+
+// Scheduled lists the paths PendingFileRenameOperations will delete.
+func (windowsSystem) Scheduled() ([]string, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, sessionManagerKey, registry.QUERY_VALUE)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open session manager key: %w", err)
+	}
+	defer k.Close()
+
+	ops, _, err := k.GetStringsValue(pendingRenamesValue)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for i := 0; i+1 < len(ops); i += 2 {
+		if ops[i+1] == "" {
+			paths = append(paths, strings.TrimPrefix(ops[i], ntPrefix))
+		}
+	}
+	return paths, nil
+}
+
+// samePath compares a PendingFileRenameOperations entry with a path.
+func samePath(entry, path string) bool {
+	return strings.EqualFold(filepath.Clean(strings.TrimPrefix(entry, ntPrefix)), filepath.Clean(path))
+}
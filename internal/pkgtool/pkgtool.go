@@ -0,0 +1,347 @@
+// Package pkgtool runs package managers' own cache cleanup commands
+// (npm cache clean, pip cache purge, …) so a dev cache is cleaned the way
+// its tool expects rather than file by file. It also asks the tools where
+// their caches live and how big they are, where they can tell.
+package pkgtool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timeouts for tool invocations. Queries should be quick; a cleanup may
+// have a lot to delete.
+const (
+	QueryTimeout = time.Minute
+	CleanTimeout = 30 * time.Minute
+)
+
+// ─── Runner ──────────────────────────────────────────────────────────────────
+
+// Runner finds and runs executables. Exec is the real one; tests put fake
+// executables on PATH or substitute their own.
+type Runner interface {
+	// LookPath resolves an executable name like exec.LookPath.
+	LookPath(name string) (string, error)
+
+	// Run runs the executable with args and returns its combined output.
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// Exec runs real processes with no stdin, so a tool that prompts fails
+// instead of hanging. They run in the user's home directory, never the
+// current one: project-local config (a repo's .npmrc or .yarnrc.yml)
+// would otherwise change which cache a cleanup wipes, and Yarn Berry would
+// clear a zero-install project's committed .yarn/cache.
+type Exec struct{}
+
+// LookPath implements Runner.
+func (Exec) LookPath(name string) (string, error) {
+	return exec.LookPath(name)
+}
+
+// Run implements Runner.
+func (Exec) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = neutralDir()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("%s %s: %w\n%s", name, strings.Join(args, " "), err,
+			strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// neutralDir returns the directory tools run in: the user's home, or the
+// temp directory if there is none.
+func neutralDir() string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		return home
+	}
+	return os.TempDir()
+}
+
+// ─── Tools ───────────────────────────────────────────────────────────────────
+
+// Tool is a package manager's cache cleanup command plus the optional
+// queries for its cache locations and size.
+type Tool struct {
+	// Exe is the executable that runs the commands.
+	Exe string
+
+	// Needs lists further executables that must be on PATH (e.g. the
+	// cargo-cache plugin behind "cargo cache").
+	Needs []string
+
+	// Clean is the cleanup command's arguments.
+	Clean []string
+
+	// Dirs, if set, asks the tool for its cache directories; ParseDirs
+	// reads them from the output (default: one path per line).
+	Dirs      []string
+	ParseDirs func(out []byte) []string
+
+	// Size, if set, asks the tool how much a cleanup frees; ParseSize
+	// reads the bytes from the output.
+	Size      []string
+	ParseSize func(out []byte) (int64, bool)
+}
+
+// tools maps clean target names to their package managers.
+var tools = map[string]Tool{
+	"NpmCache": {
+		Exe:   "npm",
+		Clean: []string{"cache", "clean", "--force"},
+		Dirs:  []string{"config", "get", "cache"},
+	},
+	"PnpmStore": {
+		Exe:   "pnpm",
+		Clean: []string{"store", "prune"},
+		Dirs:  []string{"store", "path"},
+	},
+	"YarnCache": {
+		Exe:   "yarn",
+		Clean: []string{"cache", "clean"},
+		Dirs:  []string{"cache", "dir"},
+	},
+	"PipCache": {
+		Exe:       "pip",
+		Clean:     []string{"cache", "purge"},
+		Dirs:      []string{"cache", "dir"},
+		Size:      []string{"cache", "info"},
+		ParseSize: parsePipCacheInfo,
+	},
+	"NuGetCache": {
+		Exe:       "dotnet",
+		Clean:     []string{"nuget", "locals", "all", "--clear"},
+		Dirs:      []string{"nuget", "locals", "all", "--list"},
+		ParseDirs: parseNuGetLocals,
+	},
+	"CargoCache": {
+		Exe:       "cargo",
+		Needs:     []string{"cargo-cache"},
+		Clean:     []string{"cache", "--autoclean"},
+		Size:      []string{"cache"},
+		ParseSize: parseCargoCacheTotal,
+	},
+	"BunCache": {
+		Exe:   "bun",
+		Clean: []string{"pm", "cache", "rm"},
+		Dirs:  []string{"pm", "cache"},
+	},
+	"UvCache": {
+		Exe:   "uv",
+		Clean: []string{"cache", "clean"},
+		Dirs:  []string{"cache", "dir"},
+	},
+	"PoetryCache": {
+		Exe:   "poetry",
+		Clean: []string{"cache", "clear", "pypi", "--all", "--no-interaction"},
+	},
+	"PipenvCache": {
+		Exe:   "pipenv",
+		Clean: []string{"--clear"},
+	},
+	"ComposerCache": {
+		Exe:   "composer",
+		Clean: []string{"clear-cache"},
+		Dirs:  []string{"config", "--global", "cache-dir"},
+	},
+	"GoBuildCache": {
+		Exe:   "go",
+		Clean: []string{"clean", "-cache"},
+		Dirs:  []string{"env", "GOCACHE"},
+	},
+	"CCache": {
+		Exe:   "ccache",
+		Clean: []string{"--clear"},
+		Dirs:  []string{"--get-config", "cache_dir"},
+	},
+	"CondaPkgs": {
+		Exe:       "conda",
+		Clean:     []string{"clean", "--all", "--yes"},
+		Size:      []string{"clean", "--all", "--dry-run", "--json"},
+		ParseSize: parseCondaDryRun,
+	},
+}
+
+// ForTarget returns the package manager that cleans a clean target, if
+// there is one.
+func ForTarget(target string) (Tool, bool) {
+	t, ok := tools[target]
+	return t, ok
+}
+
+// Command returns the cleanup command line, for display.
+func (t Tool) Command() string {
+	return strings.Join(append([]string{t.Exe}, t.Clean...), " ")
+}
+
+// Available reports whether the tool and everything it needs are on PATH.
+func (t Tool) Available(r Runner) bool {
+	for _, name := range append([]string{t.Exe}, t.Needs...) {
+		if _, err := r.LookPath(name); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CacheDirs asks the tool for its cache directories. It returns nil when
+// the tool cannot say.
+func (t Tool) CacheDirs(ctx context.Context, r Runner) []string {
+	if t.Dirs == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+	out, err := r.Run(ctx, t.Exe, t.Dirs...)
+	if err != nil {
+		return nil
+	}
+	parse := t.ParseDirs
+	if parse == nil {
+		parse = parseLines
+	}
+	return parse(out)
+}
+
+// EstimateSize asks the tool how many bytes a cleanup frees. ok is false
+// when the tool cannot say; callers then size the cache directories.
+func (t Tool) EstimateSize(ctx context.Context, r Runner) (size int64, ok bool) {
+	if t.Size == nil {
+		return 0, false
+	}
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+	out, err := r.Run(ctx, t.Exe, t.Size...)
+	if err != nil {
+		return 0, false
+	}
+	return t.ParseSize(out)
+}
+
+// Run runs the cleanup command.
+func (t Tool) Run(ctx context.Context, r Runner) error {
+	ctx, cancel := context.WithTimeout(ctx, CleanTimeout)
+	defer cancel()
+	_, err := r.Run(ctx, t.Exe, t.Clean...)
+	return err
+}
+
+// ─── Output Parsers ──────────────────────────────────────────────────────────
+
+// parseLines returns the non-empty lines of out, trimmed.
+func parseLines(out []byte) []string {
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseNuGetLocals reads "name: path" lines from dotnet nuget locals
+// --list.
+func parseNuGetLocals(out []byte) []string {
+	var dirs []string
+	for _, line := range parseLines(out) {
+		_, path, ok := strings.Cut(line, ": ")
+		if path = strings.TrimSpace(path); ok && path != "" {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs
+}
+
+// parsePipCacheInfo sums the "... size: 12.3 MB" lines of pip cache info.
+func parsePipCacheInfo(out []byte) (int64, bool) {
+	var (
+		total int64
+		found bool
+	)
+	for _, line := range parseLines(out) {
+		_, value, ok := strings.Cut(line, "size:")
+		if !ok {
+			continue
+		}
+		if n, ok := ParseHumanSize(value); ok {
+			total += n
+			found = true
+		}
+	}
+	return total, found
+}
+
+// parseCargoCacheTotal reads the "Total: 1.23 GB" line of cargo cache.
+func parseCargoCacheTotal(out []byte) (int64, bool) {
+	for _, line := range parseLines(out) {
+		if value, ok := strings.CutPrefix(line, "Total:"); ok {
+			return ParseHumanSize(value)
+		}
+	}
+	return 0, false
+}
+
+// parseCondaDryRun sums the total_size of every section of
+// conda clean --dry-run --json.
+func parseCondaDryRun(out []byte) (int64, bool) {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(out, &sections); err != nil {
+		return 0, false
+	}
+	var (
+		total int64
+		found bool
+	)
+	for _, raw := range sections {
+		var s struct {
+			TotalSize *int64 `json:"total_size"`
+		}
+		if json.Unmarshal(raw, &s) == nil && s.TotalSize != nil {
+			total += *s.TotalSize
+			found = true
+		}
+	}
+	return total, found
+}
+
+// ParseHumanSize parses sizes like "12.3 MB", "250MB", "4 kB" or "1.5 GiB"
+// as printed by package managers. Decimal and binary prefixes both count
+// as powers of 1024, matching how the tools round (see
+// container.ParseSISize for runtimes).
+func ParseHumanSize(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	unit := strings.ToUpper(strings.TrimSpace(s[i:]))
+	unit = strings.TrimSuffix(strings.Replace(unit, "IB", "B", 1), "YTES")
+	multipliers := map[string]float64{
+		"": 1, "B": 1,
+		"K": 1 << 10, "KB": 1 << 10,
+		"M": 1 << 20, "MB": 1 << 20,
+		"G": 1 << 30, "GB": 1 << 30,
+		"T": 1 << 40, "TB": 1 << 40,
+	}
+	m, ok := multipliers[unit]
+	if !ok {
+		return 0, false
+	}
+	return int64(value * m), true
+}
@@ -0,0 +1,147 @@
+package pkgtool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeTool puts an executable called name on PATH that records its
+// arguments to a file and prints output. It returns the record file.
+func fakeTool(t *testing.T, name, output string) string {
+	t.Helper()
+	dir := t.TempDir()
+	record := filepath.Join(dir, name+".args")
+	outFile := filepath.Join(dir, name+".out")
+	if err := os.WriteFile(outFile, []byte(output), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var path, script string
+	if runtime.GOOS == "windows" {
+		path = filepath.Join(dir, name+".bat")
+		script = "@echo off\r\necho %*>>\"" + record + "\"\r\ntype \"" + outFile + "\"\r\n"
+	} else {
+		path = filepath.Join(dir, name)
+		script = "#!/bin/sh\necho \"$*\" >> '" + record + "'\ncat '" + outFile + "'\n"
+	}
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return record
+}
+
+// calls returns the argument lines a fake tool recorded.
+func calls(t *testing.T, record string) []string {
+	t.Helper()
+	data, err := os.ReadFile(record)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseLines(data)
+}
+
+func TestTool_RunsNativeCleanup(t *testing.T) {
+	record := fakeTool(t, "npm", "")
+	tool, _ := ForTarget("NpmCache")
+
+	if !tool.Available(Exec{}) {
+		t.Fatal("fake npm not found on PATH")
+	}
+	if err := tool.Run(context.Background(), Exec{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := calls(t, record); len(got) != 1 || got[0] != "cache clean --force" {
+		t.Errorf("npm called with %q, want [cache clean --force]", got)
+	}
+}
+
+func TestExec_RunsInHomeDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	out, err := Exec{}.Run(context.Background(), "sh", "-c", "pwd -P")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(home)
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("tool ran in %q, want the home directory %q", got, want)
+	}
+}
+
+func TestTool_UnavailableWithoutPlugin(t *testing.T) {
+	record := fakeTool(t, "cargo", "")
+	t.Setenv("PATH", filepath.Dir(record)) // cargo, but no cargo-cache
+	tool, _ := ForTarget("CargoCache")
+	if tool.Available(Exec{}) {
+		t.Error("cargo cache reported available without the cargo-cache plugin")
+	}
+}
+
+func TestTool_CacheDirsAndSize(t *testing.T) {
+	fakeTool(t, "pip", "Package index page cache location: /tmp/pip/http\n"+
+		"Package index page cache size: 1.5 MB\n"+
+		"Number of HTTP files: 12\n"+
+		"Locally built wheels size: 512 kB\n")
+	tool, _ := ForTarget("PipCache")
+
+	size, ok := tool.EstimateSize(context.Background(), Exec{})
+	if want := int64(1.5*(1<<20)) + 512<<10; !ok || size != want {
+		t.Errorf("EstimateSize() = %d, %v, want %d", size, ok, want)
+	}
+	if dirs := tool.CacheDirs(context.Background(), Exec{}); len(dirs) == 0 {
+		t.Error("CacheDirs() returned nothing")
+	}
+}
+
+func TestParseNuGetLocals(t *testing.T) {
+	out := []byte("http-cache: C:\\Users\\me\\AppData\\Local\\NuGet\\v3-cache\r\n" +
+		"global-packages: C:\\Users\\me\\.nuget\\packages\\\r\n" +
+		"temp: C:\\Users\\me\\AppData\\Local\\Temp\\NuGetScratch\r\n")
+	dirs := parseNuGetLocals(out)
+	if len(dirs) != 3 || !strings.HasSuffix(dirs[1], `.nuget\packages\`) {
+		t.Errorf("parseNuGetLocals() = %q", dirs)
+	}
+}
+
+func TestParseCondaDryRun(t *testing.T) {
+	out := []byte(`{"packages": {"total_size": 1000, "pkgs_dirs": {}},
+		"tarballs": {"total_size": 234}, "success": true}`)
+	if size, ok := parseCondaDryRun(out); !ok || size != 1234 {
+		t.Errorf("parseCondaDryRun() = %d, %v, want 1234", size, ok)
+	}
+}
+
+func TestParseHumanSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"3.25 GB", int64(3.25 * (1 << 30)), true},
+		{"250MB", 250 << 20, true},
+		{"4 kB", 4 << 10, true},
+		{"1.5 GiB", int64(1.5 * (1 << 30)), true},
+		{"812 bytes", 812, true},
+		{"lots", 0, false},
+		{"3 parsecs", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseHumanSize(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseHumanSize(%q) = %d, %v, want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
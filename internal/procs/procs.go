@@ -0,0 +1,42 @@
+// Package procs tells which applications are running, so the caches they
+// hold open are left alone until they exit.
+package procs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// Set holds the image names of running processes (e.g. "chrome.exe"),
+// lower-cased.
+type Set map[string]bool
+
+// Running lists the running processes. Processes whose name cannot be read
+// (other users' system processes, ones that just exited) are left out.
+func Running(ctx context.Context) (Set, error) {
+	ps, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	set := make(Set, len(ps))
+	for _, p := range ps {
+		if name, err := p.NameWithContext(ctx); err == nil && name != "" {
+			set[strings.ToLower(name)] = true
+		}
+	}
+	return set, nil
+}
+
+// Owners returns the names that are running, in the order given. Names
+// match without regard to case.
+func (s Set) Owners(names []string) []string {
+	var running []string
+	for _, name := range names {
+		if s[strings.ToLower(name)] {
+			running = append(running, name)
+		}
+	}
+	return running
+}
@@ -0,0 +1,28 @@
+package procs
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestSet_Owners(t *testing.T) {
+	s := Set{"chrome.exe": true, "code.exe": true}
+	got := s.Owners([]string{"msedge.exe", "Code.exe", "chrome.exe"})
+	if want := []string{"Code.exe", "chrome.exe"}; !slices.Equal(got, want) {
+		t.Errorf("Owners() = %q, want %q", got, want)
+	}
+	if got := s.Owners(nil); got != nil {
+		t.Errorf("Owners(nil) = %q, want nil", got)
+	}
+}
+
+func TestRunning_ListsProcesses(t *testing.T) {
+	s, err := Running(context.Background())
+	if err != nil {
+		t.Fatalf("Running() error = %v", err)
+	}
+	if len(s) == 0 {
+		t.Error("Running() found no processes, not even this test")
+	}
+}
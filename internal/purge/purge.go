@@ -61,7 +61,8 @@ var artifactDirNames = func() map[string]bool {
 
 // ScanProjects walks the given paths and identifies project artifacts.
 // It will scan up to 3 levels deep and NOT recurse into artifact directories.
-func ScanProjects(paths []string) ([]ProjectArtifact, error) {
+// Sizing an artifact waits on pace, if set, before each file is statted.
+func ScanProjects(paths []string, pace core.Pacer) ([]ProjectArtifact, error) {
 	var artifacts []ProjectArtifact
 	seenProjects := make(map[string]bool)
 
@@ -71,7 +72,7 @@ func ScanProjects(paths []string) ([]ProjectArtifact, error) {
 			continue // Skip non-existent paths
 		}
 
-		err := scanDirectory(basePath, basePath, 0, 3, seenProjects, &artifacts)
+		err := scanDirectory(basePath, basePath, 0, 3, seenProjects, &artifacts, pace)
 		if err != nil {
 			// Non-fatal: log but continue scanning other paths
 			continue
@@ -107,7 +108,7 @@ func isReparsePoint(path string) bool {
 // scanDirectory recursively scans a directory for project artifacts.
 // depth starts at 0 and increases with each level.
 // maxDepth limits how deep we search (typically 3).
-func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjects map[string]bool, artifacts *[]ProjectArtifact) error {
+func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjects map[string]bool, artifacts *[]ProjectArtifact, pace core.Pacer) error {
 	if depth > maxDepth {
 		return nil
 	}
@@ -164,7 +165,7 @@ func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjec
 			continue
 		}
 
-		size, err := core.GetDirSize(artifactPath)
+		size, err := core.PacedDirSize(artifactPath, pace)
 		if err != nil {
 			// If we can't calculate size, use 0 but still track it
 			size = 0
@@ -218,7 +219,7 @@ func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjec
 			continue
 		}
 
-		_ = scanDirectory(basePath, subPath, depth+1, maxDepth, seenProjects, artifacts)
+		_ = scanDirectory(basePath, subPath, depth+1, maxDepth, seenProjects, artifacts, pace)
 	}
 
 	return nil
@@ -243,6 +244,28 @@ func hasAnyIndicator(dir string, indicators []string) bool {
 	return false
 }
 
+// ArtifactType returns the type of the build artifact directory at path,
+// checked the way ScanProjects recognises one: a known artifact name whose
+// project root holds one of that artifact's indicators. ok is false for
+// anything else.
+func ArtifactType(path string) (typ string, ok bool) {
+	info, err := os.Lstat(path)
+	if err != nil || !info.IsDir() {
+		return "", false
+	}
+	name := filepath.Base(path)
+	for _, def := range artifactDefinitions {
+		if def.DirName != name {
+			continue
+		}
+		if len(def.Indicators) > 0 && !hasAnyIndicator(filepath.Dir(path), def.Indicators) {
+			return "", false
+		}
+		return def.Type, true
+	}
+	return "", false
+}
+
 // PurgeArtifacts deletes the specified artifacts and returns total bytes freed and count.
 func PurgeArtifacts(artifacts []ProjectArtifact, dryRun bool) (int64, int, error) {
 	var totalBytes int64
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ─── Plan Files ──────────────────────────────────────────────────────────────
+
+// ErrVanished is returned by Verify when a planned path no longer exists.
+var ErrVanished = errors.New("no longer exists")
+
+// ErrChanged is returned by Verify when a planned path's size or
+// modification time differs from the plan.
+var ErrChanged = errors.New("changed since the plan was made")
+
+// WritePlan saves p to path as indented JSON, for review before it is run
+// with ReadPlan.
+func WritePlan(path string, p Plan) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("cannot create plan directory: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode plan: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot write plan %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadPlan loads a plan saved by WritePlan.
+func ReadPlan(path string) (Plan, error) {
+	var p Plan
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return p, fmt.Errorf("cannot read plan %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, fmt.Errorf("invalid plan %s: %w", path, err)
+	}
+	if p.Command == "" {
+		return p, fmt.Errorf("invalid plan %s: no command", path)
+	}
+	return p, nil
+}
+
+// StatPath measures path for a plan: its size (the total of all regular
+// files beneath it, for a directory) and the newest modification time of
+// the path or anything beneath it. Symlinks and junctions are not followed.
+func StatPath(path string) (int64, time.Time, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if !info.IsDir() {
+		return info.Size(), info.ModTime(), nil
+	}
+
+	var size int64
+	newest := info.ModTime()
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			size += fi.Size()
+		}
+		if fi.ModTime().After(newest) {
+			newest = fi.ModTime()
+		}
+		return nil
+	})
+	return size, newest, err
+}
+
+// Stamp measures every item with StatPath, replacing its size and setting
+// its modification time, so Verify later compares like with like. Items
+// that cannot be measured are dropped.
+func Stamp(items []Item) []Item {
+	out := make([]Item, 0, len(items))
+	for _, it := range items {
+		size, mtime, err := StatPath(it.Path)
+		if err != nil {
+			continue
+		}
+		it.Size = size
+		it.ModTime = mtime
+		out = append(out, it)
+	}
+	return out
+}
+
+// Verify checks that the item on disk still matches the plan. It returns an
+// error wrapping ErrVanished or ErrChanged when it does not.
+func (it Item) Verify() error {
+	size, mtime, err := StatPath(it.Path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrVanished
+		}
+		return fmt.Errorf("cannot check: %w", err)
+	}
+	if size != it.Size {
+		return fmt.Errorf("%w (size %d, planned %d)", ErrChanged, size, it.Size)
+	}
+	if !mtime.Equal(it.ModTime) {
+		return fmt.Errorf("%w (modified %s)", ErrChanged, mtime.Format(time.RFC3339))
+	}
+	return nil
+}
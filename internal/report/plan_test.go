@@ -0,0 +1,100 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPlanRoundTripAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "setup.exe")
+	cache := filepath.Join(dir, "cache")
+	if err := os.WriteFile(file, []byte("12345"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(cache, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cache, "sub", "a.bin"), []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	items := Stamp([]Item{
+		{Path: file, Category: "installer"},
+		{Path: cache, Category: "dev", Target: "NpmCache"},
+		{Path: filepath.Join(dir, "missing")},
+	})
+	if len(items) != 2 {
+		t.Fatalf("Stamp() kept %d items, want 2", len(items))
+	}
+	if items[0].Size != 5 || items[1].Size != 3 {
+		t.Errorf("sizes = %d, %d; want 5, 3", items[0].Size, items[1].Size)
+	}
+
+	planPath := filepath.Join(dir, "plans", "plan.json")
+	p := NewPlan("clean", true, items)
+	p.Created = time.Now()
+	if err := WritePlan(planPath, p); err != nil {
+		t.Fatalf("WritePlan() error = %v", err)
+	}
+	got, err := ReadPlan(planPath)
+	if err != nil {
+		t.Fatalf("ReadPlan() error = %v", err)
+	}
+	if got.Command != "clean" || len(got.Items) != 2 || got.Items[1].Target != "NpmCache" {
+		t.Fatalf("ReadPlan() = %+v", got)
+	}
+
+	for _, it := range got.Items {
+		if err := it.Verify(); err != nil {
+			t.Errorf("Verify(%s) = %v, want nil", it.Path, err)
+		}
+	}
+
+	// Grow a file inside the directory: the directory item drifts.
+	if err := os.WriteFile(filepath.Join(cache, "sub", "a.bin"), []byte("abcdef"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Items[1].Verify(); !errors.Is(err, ErrChanged) {
+		t.Errorf("Verify() after change = %v, want ErrChanged", err)
+	}
+
+	// Same size, newer mtime: still drift.
+	later := got.Items[0].ModTime.Add(time.Hour)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Items[0].Verify(); !errors.Is(err, ErrChanged) {
+		t.Errorf("Verify() after touch = %v, want ErrChanged", err)
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Items[0].Verify(); !errors.Is(err, ErrVanished) {
+		t.Errorf("Verify() after removal = %v, want ErrVanished", err)
+	}
+}
+
+func TestReadPlanRejectsGarbage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(path, []byte(`{"items": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPlan(path); err == nil {
+		t.Error("ReadPlan() of a plan without a command should fail")
+	}
+}
+
+func TestNewPlanLeavesOutPredictedFailures(t *testing.T) {
+	p := NewPlan("clean", true, []Item{
+		{Path: "a", Size: 100},
+		{Path: "b", Size: 50, Predicted: "locked"},
+	})
+	if p.ItemCount != 2 || p.TotalSize != 100 {
+		t.Errorf("NewPlan() = %d items, %d bytes; want 2, 100", p.ItemCount, p.TotalSize)
+	}
+}
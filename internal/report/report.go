@@ -0,0 +1,149 @@
+// Package report defines the JSON documents PureWin hands to external
+// consumers: the scan plan and the session result, plus the session
+// history file and saved plan files.
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Item is a single path found by a scan.
+type Item struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Category string `json:"category,omitempty"`
+
+	// Target is the clean target (or artifact type) that found the item.
+	Target string `json:"target,omitempty"`
+
+	// Root is the target directory the item was found under; deletion
+	// refuses the item if its real path escapes it.
+	Root string `json:"root,omitempty"`
+
+	// ModTime is the newest modification time under the path. It is only
+	// set on items in saved plan files; see Stamp.
+	ModTime time.Time `json:"mtime,omitzero"`
+
+	// Predicted is the reason a deep dry run expects deleting the item to
+	// fail, such as "locked"; such items count nothing toward the totals.
+	Predicted string `json:"predicted_failure,omitempty"`
+
+	// ReadOnly marks items a deep dry run found read-only entries in.
+	ReadOnly bool `json:"read_only,omitempty"`
+}
+
+// Plan describes what a session found and intends to delete.
+type Plan struct {
+	Command   string `json:"command"`
+	DryRun    bool   `json:"dry_run"`
+	Items     []Item `json:"items"`
+	TotalSize int64  `json:"total_size"`
+	ItemCount int    `json:"item_count"`
+
+	// Hostname and Created are set on saved plan files.
+	Hostname string    `json:"hostname,omitempty"`
+	Created  time.Time `json:"created,omitzero"`
+}
+
+// NewPlan builds a Plan from items, computing the totals. Items with a
+// predicted failure are listed but not counted in TotalSize.
+func NewPlan(command string, dryRun bool, items []Item) Plan {
+	p := Plan{Command: command, DryRun: dryRun, Items: items, ItemCount: len(items)}
+	if p.Items == nil {
+		p.Items = []Item{}
+	}
+	for _, it := range items {
+		if it.Predicted == "" {
+			p.TotalSize += it.Size
+		}
+	}
+	return p
+}
+
+// Session is the outcome of a single cleanup session.
+type Session struct {
+	Command  string    `json:"command"`
+	Hostname string    `json:"hostname,omitempty"`
+	DryRun   bool      `json:"dry_run"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Freed    int64     `json:"freed"`
+	Items    int       `json:"items"`
+	Errors   int       `json:"errors"`
+
+	// Categories breaks Freed and Items down by clean category, when the
+	// command tracks categories.
+	Categories map[string]CategoryTotal `json:"categories,omitempty"`
+}
+
+// CategoryTotal is the amount freed in one category.
+type CategoryTotal struct {
+	Freed int64 `json:"freed"`
+	Items int   `json:"items"`
+}
+
+// ─── History ─────────────────────────────────────────────────────────────────
+
+// AppendHistory appends a session to a JSON-lines history file, creating it
+// (and its directory) if needed.
+func AppendHistory(path string, s Session) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("cannot create history directory: %w", err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("cannot encode session: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open history %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write history %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadHistory returns up to limit of the most recent sessions, newest first.
+// A limit of zero or less returns every session. A missing file yields no
+// sessions and no error; malformed lines are skipped.
+func ReadHistory(path string, limit int) ([]Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot open history %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var sessions []Session
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var s Session
+		if json.Unmarshal(sc.Bytes(), &s) == nil {
+			sessions = append(sessions, s)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read history %s: %w", path, err)
+	}
+
+	// Newest first.
+	for i, j := 0, len(sessions)-1; i < j; i, j = i+1, j-1 {
+		sessions[i], sessions[j] = sessions[j], sessions[i]
+	}
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxJobs is how many jobs are kept for polling; the oldest finished jobs
+// are evicted first.
+const maxJobs = 100
+
+// JobState is the lifecycle state of a job.
+type JobState string
+
+const (
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCanceled  JobState = "canceled"
+)
+
+// JobEvent is a single progress entry, streamed as one NDJSON line.
+type JobEvent struct {
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"` // "progress", "done"
+	Message string    `json:"message,omitempty"`
+	State   JobState  `json:"state,omitempty"`
+}
+
+// JobSnapshot is the pollable view of a job.
+type JobSnapshot struct {
+	ID       string     `json:"id"`
+	Kind     string     `json:"kind"`
+	State    JobState   `json:"state"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Message  string     `json:"message,omitempty"`
+	Result   any        `json:"result,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// job is a long-running operation executed in the background.
+type job struct {
+	mu       sync.Mutex
+	id       string
+	kind     string
+	state    JobState
+	created  time.Time
+	finished time.Time
+	result   any
+	err      string
+	events   []JobEvent
+	changed  chan struct{} // closed and replaced on every update
+	cancel   context.CancelFunc
+}
+
+// progress appends a progress event and wakes stream readers.
+func (j *job) progress(msg string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.appendLocked(JobEvent{Type: "progress", Message: msg})
+}
+
+// finish records the job outcome.
+func (j *job) finish(result any, err error, canceled bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.finished = time.Now()
+	switch {
+	case canceled:
+		j.state = JobCanceled
+		j.err = "canceled"
+	case err != nil:
+		j.state = JobFailed
+		j.err = err.Error()
+	default:
+		j.state = JobSucceeded
+		j.result = result
+	}
+	j.appendLocked(JobEvent{Type: "done", State: j.state, Message: j.err})
+}
+
+// appendLocked adds an event; the caller holds j.mu.
+func (j *job) appendLocked(ev JobEvent) {
+	ev.Seq = len(j.events) + 1
+	ev.Time = time.Now()
+	j.events = append(j.events, ev)
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// eventsSince returns events after seq, whether the job is done, and a
+// channel closed on the next update.
+func (j *job) eventsSince(seq int) ([]JobEvent, bool, <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var evs []JobEvent
+	if seq < len(j.events) {
+		evs = append(evs, j.events[seq:]...)
+	}
+	return evs, j.state != JobRunning, j.changed
+}
+
+// snapshot returns the pollable view of the job.
+func (j *job) snapshot() JobSnapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := JobSnapshot{
+		ID:      j.id,
+		Kind:    j.kind,
+		State:   j.state,
+		Created: j.created,
+		Result:  j.result,
+		Error:   j.err,
+	}
+	if !j.finished.IsZero() {
+		t := j.finished
+		s.Finished = &t
+	}
+	if n := len(j.events); n > 0 && j.state == JobRunning {
+		s.Message = j.events[n-1].Message
+	}
+	return s
+}
+
+// ─── Job Registry ────────────────────────────────────────────────────────────
+
+// jobRegistry holds running and recently finished jobs.
+type jobRegistry struct {
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{jobs: make(map[string]*job)}
+}
+
+// start runs fn in the background as a new job and returns it.
+func (r *jobRegistry) start(kind string, fn func(ctx context.Context, j *job) (any, error)) *job {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		id:      newID(),
+		kind:    kind,
+		state:   JobRunning,
+		created: time.Now(),
+		changed: make(chan struct{}),
+		cancel:  cancel,
+	}
+
+	r.mu.Lock()
+	r.jobs[j.id] = j
+	r.evictLocked()
+	r.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		result, err := fn(ctx, j)
+		j.finish(result, err, ctx.Err() != nil)
+	}()
+	return j
+}
+
+// get returns the job with the given ID.
+func (r *jobRegistry) get(id string) (*job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j, ok := r.jobs[id]
+	return j, ok
+}
+
+// list returns snapshots of all jobs, newest first.
+func (r *jobRegistry) list() []JobSnapshot {
+	r.mu.Lock()
+	jobs := make([]*job, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		jobs = append(jobs, j)
+	}
+	r.mu.Unlock()
+
+	out := make([]JobSnapshot, 0, len(jobs))
+	for _, j := range jobs {
+		out = append(out, j.snapshot())
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Created.After(out[b].Created) })
+	for i := range out {
+		out[i].Result = nil // keep listings small; poll a job for its result
+	}
+	return out
+}
+
+// cancelAll cancels every running job.
+func (r *jobRegistry) cancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, j := range r.jobs {
+		j.cancel()
+	}
+}
+
+// evictLocked drops the oldest finished jobs beyond maxJobs; the caller
+// holds r.mu.
+func (r *jobRegistry) evictLocked() {
+	for len(r.jobs) > maxJobs {
+		var oldest *job
+		for _, j := range r.jobs {
+			j.mu.Lock()
+			done := j.state != JobRunning
+			j.mu.Unlock()
+			if done && (oldest == nil || j.created.Before(oldest.created)) {
+				oldest = j
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		delete(r.jobs, oldest.id)
+	}
+}
+
+// newID returns a random identifier for jobs and plans.
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
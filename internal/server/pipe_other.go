@@ -0,0 +1,13 @@
+//go:build !windows
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+// ListenPipe is only supported on Windows.
+func ListenPipe(name string) (net.Listener, error) {
+	return nil, errors.New("named pipes are only supported on Windows")
+}
@@ -0,0 +1,196 @@
+//go:build windows
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// pipePrefix is the namespace every local named pipe lives in.
+const pipePrefix = `\\.\pipe\`
+
+// pipeBufferSize is the in/out buffer size of each pipe instance.
+const pipeBufferSize = 64 * 1024
+
+// ListenPipe listens on a Windows named pipe. A bare name such as "purewin"
+// becomes \\.\pipe\purewin. The pipe accepts local clients only and is
+// restricted to the current user and SYSTEM.
+func ListenPipe(name string) (net.Listener, error) {
+	if !strings.HasPrefix(name, pipePrefix) {
+		name = pipePrefix + name
+	}
+
+	sa, err := currentUserSecurity()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &pipeListener{name: name, sa: sa}
+
+	// Create the first instance now so a name clash fails immediately.
+	h, err := l.createInstance(true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create pipe %s: %w", name, err)
+	}
+	l.pending = h
+	return l, nil
+}
+
+// pipeListener accepts connections on a named pipe, one instance per client.
+type pipeListener struct {
+	name string
+	sa   *windows.SecurityAttributes
+
+	mu      sync.Mutex
+	closed  bool
+	pending windows.Handle // instance created but not yet waiting
+	waiting windows.Handle // instance blocked in ConnectNamedPipe
+	ov      *windows.Overlapped
+}
+
+// createInstance creates a new overlapped pipe instance.
+func (l *pipeListener) createInstance(first bool) (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(l.name)
+	if err != nil {
+		return windows.InvalidHandle, err
+	}
+	flags := uint32(windows.PIPE_ACCESS_DUPLEX | windows.FILE_FLAG_OVERLAPPED)
+	if first {
+		flags |= windows.FILE_FLAG_FIRST_PIPE_INSTANCE
+	}
+	mode := uint32(windows.PIPE_TYPE_BYTE | windows.PIPE_READMODE_BYTE |
+		windows.PIPE_WAIT | windows.PIPE_REJECT_REMOTE_CLIENTS)
+	return windows.CreateNamedPipe(name, flags, mode, windows.PIPE_UNLIMITED_INSTANCES,
+		pipeBufferSize, pipeBufferSize, 0, l.sa)
+}
+
+// Accept waits for a client to connect to a fresh pipe instance.
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, net.ErrClosed
+	}
+	h := l.pending
+	l.pending = 0
+	l.mu.Unlock()
+
+	if h == 0 {
+		var err error
+		if h, err = l.createInstance(false); err != nil {
+			return nil, fmt.Errorf("cannot create pipe instance: %w", err)
+		}
+	}
+
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	defer windows.CloseHandle(event)
+	ov := &windows.Overlapped{HEvent: event}
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		windows.CloseHandle(h)
+		return nil, net.ErrClosed
+	}
+	l.waiting, l.ov = h, ov
+	l.mu.Unlock()
+
+	err = windows.ConnectNamedPipe(h, ov)
+	switch {
+	case err == nil, errors.Is(err, windows.ERROR_PIPE_CONNECTED):
+		err = nil
+	case errors.Is(err, windows.ERROR_IO_PENDING):
+		var n uint32
+		err = windows.GetOverlappedResult(h, ov, &n, true)
+	}
+
+	l.mu.Lock()
+	l.waiting, l.ov = 0, nil
+	closed := l.closed
+	l.mu.Unlock()
+
+	if err != nil {
+		windows.CloseHandle(h)
+		if closed {
+			return nil, net.ErrClosed
+		}
+		return nil, fmt.Errorf("pipe connect failed: %w", err)
+	}
+
+	// The handle is overlapped, so os.File binds it to the runtime poller
+	// and supports deadlines, which net/http relies on.
+	return &pipeConn{File: os.NewFile(uintptr(h), l.name), handle: h, addr: pipeAddr(l.name)}, nil
+}
+
+// Close stops accepting connections and unblocks a pending Accept.
+func (l *pipeListener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.waiting != 0 {
+		_ = windows.CancelIoEx(l.waiting, l.ov)
+	}
+	if l.pending != 0 {
+		windows.CloseHandle(l.pending)
+		l.pending = 0
+	}
+	return nil
+}
+
+// Addr returns the pipe name.
+func (l *pipeListener) Addr() net.Addr { return pipeAddr(l.name) }
+
+// pipeConn is a connected pipe instance.
+type pipeConn struct {
+	*os.File
+	handle windows.Handle
+	addr   pipeAddr
+}
+
+// Close flushes unread response data to the client before closing.
+func (c *pipeConn) Close() error {
+	_ = windows.FlushFileBuffers(c.handle)
+	return c.File.Close()
+}
+
+func (c *pipeConn) LocalAddr() net.Addr  { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr { return c.addr }
+
+// pipeAddr is the net.Addr of a named pipe.
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+// currentUserSecurity builds security attributes granting access to the
+// current user and SYSTEM only.
+func currentUserSecurity() (*windows.SecurityAttributes, error) {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read current user: %w", err)
+	}
+	sd, err := windows.SecurityDescriptorFromString(
+		"D:P(A;;GA;;;SY)(A;;GA;;;" + user.User.Sid.String() + ")")
+	if err != nil {
+		return nil, fmt.Errorf("cannot build pipe security descriptor: %w", err)
+	}
+	return &windows.SecurityAttributes{
+		Length:             uint32(unsafe.Sizeof(windows.SecurityAttributes{})),
+		SecurityDescriptor: sd,
+	}, nil
+}
@@ -0,0 +1,541 @@
+// Package server exposes PureWin's scan and clean engine over a local
+// JSON-RPC 2.0 API so other tools can drive it without scraping the TUI.
+//
+// All requests require "Authorization: Bearer <token>". Methods are called
+// with POST /rpc; long-running methods return a job ID that can be polled
+// with job.get (or GET /jobs/{id}) or streamed as NDJSON from
+// GET /jobs/{id}/stream.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+// maxRequestBody caps RPC request bodies.
+const maxRequestBody = 1 << 20
+
+// ─── Engine ──────────────────────────────────────────────────────────────────
+
+// ScanParams selects what a scan covers.
+type ScanParams struct {
+	// Profile is a clean profile name (e.g. "safe").
+	Profile string `json:"profile,omitempty"`
+
+	// Categories lists high-level categories (user, browser, electron, dev, system).
+	// Combined with Profile; both empty means every category.
+	Categories []string `json:"categories,omitempty"`
+}
+
+// Engine performs the actual work. The pw serve command implements it on
+// top of the clean, status and analyze packages.
+type Engine interface {
+	// Scan finds cleanable items.
+	Scan(ctx context.Context, p ScanParams, progress func(string)) ([]report.Item, error)
+
+	// Execute deletes the given items (or simulates it when dryRun is set).
+	Execute(ctx context.Context, items []report.Item, dryRun bool, progress func(string)) (report.Session, error)
+
+	// Metrics returns a system metrics snapshot.
+	Metrics(ctx context.Context) (any, error)
+
+	// AnalyzeTree scans a directory and returns its size tree to depth levels.
+	AnalyzeTree(ctx context.Context, path string, depth int, progress func(string)) (any, error)
+}
+
+// Options configures a Server.
+type Options struct {
+	// Token is the bearer token every request must present.
+	Token string
+
+	// Engine does the work behind each method.
+	Engine Engine
+
+	// HistoryPath is the session history file served by the history method.
+	HistoryPath string
+
+	// Version is reported by the version method.
+	Version string
+}
+
+// ─── Server ──────────────────────────────────────────────────────────────────
+
+// Server is the PureWin API server.
+type Server struct {
+	opts    Options
+	jobs    *jobRegistry
+	methods map[string]methodFunc
+
+	plansMu sync.Mutex
+	plans   map[string]report.Plan
+
+	// execMu serializes execute jobs so two clients can't delete at once.
+	execMu sync.Mutex
+
+	httpMu sync.Mutex
+	https  []*http.Server
+}
+
+// methodFunc handles one RPC method.
+type methodFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// New creates a Server.
+func New(opts Options) (*Server, error) {
+	if opts.Token == "" {
+		return nil, errors.New("server token must not be empty")
+	}
+	if opts.Engine == nil {
+		return nil, errors.New("server engine must not be nil")
+	}
+
+	s := &Server{
+		opts:  opts,
+		jobs:  newJobRegistry(),
+		plans: make(map[string]report.Plan),
+	}
+	s.methods = map[string]methodFunc{
+		"version":        s.rpcVersion,
+		"scan":           s.rpcScan,
+		"plan":           s.rpcPlan,
+		"execute":        s.rpcExecute,
+		"status.metrics": s.rpcMetrics,
+		"analyze.tree":   s.rpcAnalyzeTree,
+		"history":        s.rpcHistory,
+		"job.get":        s.rpcJobGet,
+		"job.cancel":     s.rpcJobCancel,
+		"job.list":       s.rpcJobList,
+	}
+	return s, nil
+}
+
+// Handler returns the HTTP handler for the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rpc", s.handleRPC)
+	mux.HandleFunc("GET /jobs", s.handleJobList)
+	mux.HandleFunc("GET /jobs/{id}", s.handleJobGet)
+	mux.HandleFunc("GET /jobs/{id}/stream", s.handleJobStream)
+	return s.authenticate(mux)
+}
+
+// Serve accepts connections on l until Shutdown is called.
+func (s *Server) Serve(l net.Listener) error {
+	hs := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.httpMu.Lock()
+	s.https = append(s.https, hs)
+	s.httpMu.Unlock()
+
+	err := hs.Serve(l)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown cancels running jobs and stops every listener.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.jobs.cancelAll()
+
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+	var firstErr error
+	for _, hs := range s.https {
+		if err := hs.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// authenticate rejects requests without the bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	want := []byte("Bearer " + s.opts.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ─── JSON-RPC ────────────────────────────────────────────────────────────────
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// paramsError marks an error caused by bad request parameters.
+type paramsError struct{ msg string }
+
+func (e *paramsError) Error() string { return e.msg }
+
+func invalidParams(format string, args ...any) error {
+	return &paramsError{msg: fmt.Sprintf(format, args...)}
+}
+
+// handleRPC dispatches a single JSON-RPC 2.0 request.
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err := dec.Decode(&req); err != nil {
+		writeRPC(w, nil, nil, &rpcError{Code: codeParseError, Message: err.Error()})
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeRPC(w, req.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "expected a JSON-RPC 2.0 request"})
+		return
+	}
+
+	method, ok := s.methods[req.Method]
+	if !ok {
+		writeRPC(w, req.ID, nil, &rpcError{Code: codeMethodNotFound, Message: "unknown method " + req.Method})
+		return
+	}
+
+	result, err := method(r.Context(), req.Params)
+	if err != nil {
+		code := codeServerError
+		var pe *paramsError
+		if errors.As(err, &pe) {
+			code = codeInvalidParams
+		}
+		writeRPC(w, req.ID, nil, &rpcError{Code: code, Message: err.Error()})
+		return
+	}
+	writeRPC(w, req.ID, result, nil)
+}
+
+// decodeParams unmarshals params into v; empty params leave v unchanged.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
+
+// jobStarted is the result of every method that starts a job.
+type jobStarted struct {
+	JobID string `json:"job_id"`
+}
+
+func (s *Server) rpcVersion(ctx context.Context, params json.RawMessage) (any, error) {
+	return map[string]string{"version": s.opts.Version}, nil
+}
+
+// scanSummary is the result of a scan job.
+type scanSummary struct {
+	TotalSize  int64                      `json:"total_size"`
+	ItemCount  int                        `json:"item_count"`
+	Categories map[string]categorySummary `json:"categories"`
+}
+
+type categorySummary struct {
+	Size  int64 `json:"size"`
+	Count int   `json:"count"`
+}
+
+func (s *Server) rpcScan(ctx context.Context, params json.RawMessage) (any, error) {
+	var p ScanParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	j := s.jobs.start("scan", func(ctx context.Context, j *job) (any, error) {
+		items, err := s.opts.Engine.Scan(ctx, p, j.progress)
+		if err != nil {
+			return nil, err
+		}
+		sum := scanSummary{Categories: make(map[string]categorySummary)}
+		for _, it := range items {
+			c := sum.Categories[it.Category]
+			c.Size += it.Size
+			c.Count++
+			sum.Categories[it.Category] = c
+			sum.TotalSize += it.Size
+			sum.ItemCount++
+		}
+		return sum, nil
+	})
+	return jobStarted{JobID: j.id}, nil
+}
+
+// planResult is the result of a plan job.
+type planResult struct {
+	PlanID string      `json:"plan_id"`
+	Plan   report.Plan `json:"plan"`
+}
+
+func (s *Server) rpcPlan(ctx context.Context, params json.RawMessage) (any, error) {
+	var p ScanParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	j := s.jobs.start("plan", func(ctx context.Context, j *job) (any, error) {
+		items, err := s.opts.Engine.Scan(ctx, p, j.progress)
+		if err != nil {
+			return nil, err
+		}
+		plan := report.NewPlan("serve", false, items)
+		id := newID()
+		s.plansMu.Lock()
+		s.plans[id] = plan
+		s.plansMu.Unlock()
+		return planResult{PlanID: id, Plan: plan}, nil
+	})
+	return jobStarted{JobID: j.id}, nil
+}
+
+// executeParams selects a stored plan to execute.
+type executeParams struct {
+	PlanID string `json:"plan_id"`
+	DryRun bool   `json:"dry_run"`
+
+	// Paths optionally restricts execution to a subset of the plan.
+	Paths []string `json:"paths,omitempty"`
+}
+
+func (s *Server) rpcExecute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p executeParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.PlanID == "" {
+		return nil, invalidParams("plan_id is required")
+	}
+
+	s.plansMu.Lock()
+	plan, ok := s.plans[p.PlanID]
+	if ok && !p.DryRun {
+		// A plan is executed at most once.
+		delete(s.plans, p.PlanID)
+	}
+	s.plansMu.Unlock()
+	if !ok {
+		return nil, invalidParams("unknown or already executed plan %q", p.PlanID)
+	}
+
+	items := plan.Items
+	if len(p.Paths) > 0 {
+		items = selectPaths(plan.Items, p.Paths)
+		if len(items) == 0 {
+			return nil, invalidParams("none of the given paths are in plan %q", p.PlanID)
+		}
+	}
+
+	j := s.jobs.start("execute", func(ctx context.Context, j *job) (any, error) {
+		s.execMu.Lock()
+		defer s.execMu.Unlock()
+		return s.opts.Engine.Execute(ctx, items, p.DryRun, j.progress)
+	})
+	return jobStarted{JobID: j.id}, nil
+}
+
+// selectPaths returns the plan items whose path is in paths.
+func selectPaths(items []report.Item, paths []string) []report.Item {
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[strings.ToLower(p)] = true
+	}
+	var out []report.Item
+	for _, it := range items {
+		if want[strings.ToLower(it.Path)] {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func (s *Server) rpcMetrics(ctx context.Context, params json.RawMessage) (any, error) {
+	return s.opts.Engine.Metrics(ctx)
+}
+
+// analyzeParams selects the directory to analyze.
+type analyzeParams struct {
+	Path  string `json:"path"`
+	Depth int    `json:"depth,omitempty"`
+}
+
+func (s *Server) rpcAnalyzeTree(ctx context.Context, params json.RawMessage) (any, error) {
+	var p analyzeParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Path == "" {
+		return nil, invalidParams("path is required")
+	}
+	if p.Depth <= 0 {
+		p.Depth = 2
+	}
+	j := s.jobs.start("analyze.tree", func(ctx context.Context, j *job) (any, error) {
+		return s.opts.Engine.AnalyzeTree(ctx, p.Path, p.Depth, j.progress)
+	})
+	return jobStarted{JobID: j.id}, nil
+}
+
+func (s *Server) rpcHistory(ctx context.Context, params json.RawMessage) (any, error) {
+	p := struct {
+		Limit int `json:"limit"`
+	}{Limit: 50}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if s.opts.HistoryPath == "" {
+		return []report.Session{}, nil
+	}
+	sessions, err := report.ReadHistory(s.opts.HistoryPath, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []report.Session{}
+	}
+	return sessions, nil
+}
+
+// jobParams identifies a job.
+type jobParams struct {
+	ID string `json:"id"`
+}
+
+func (s *Server) rpcJobGet(ctx context.Context, params json.RawMessage) (any, error) {
+	var p jobParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	j, ok := s.jobs.get(p.ID)
+	if !ok {
+		return nil, invalidParams("unknown job %q", p.ID)
+	}
+	return j.snapshot(), nil
+}
+
+func (s *Server) rpcJobCancel(ctx context.Context, params json.RawMessage) (any, error) {
+	var p jobParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	j, ok := s.jobs.get(p.ID)
+	if !ok {
+		return nil, invalidParams("unknown job %q", p.ID)
+	}
+	j.cancel()
+	return map[string]bool{"canceled": true}, nil
+}
+
+func (s *Server) rpcJobList(ctx context.Context, params json.RawMessage) (any, error) {
+	return s.jobs.list(), nil
+}
+
+// ─── Job Endpoints ───────────────────────────────────────────────────────────
+
+func (s *Server) handleJobList(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.list())
+}
+
+func (s *Server) handleJobGet(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobs.get(r.PathValue("id"))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown job"})
+		return
+	}
+	writeJSON(w, http.StatusOK, j.snapshot())
+}
+
+// handleJobStream writes job events as NDJSON until the job finishes or
+// the client disconnects. The final line is the job snapshot.
+func (s *Server) handleJobStream(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobs.get(r.PathValue("id"))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown job"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	seq := 0
+	for {
+		events, done, changed := j.eventsSince(seq)
+		for _, ev := range events {
+			if err := enc.Encode(ev); err != nil {
+				return
+			}
+			seq = ev.Seq
+		}
+		if done {
+			_ = enc.Encode(j.snapshot())
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// ─── Helpers ─────────────────────────────────────────────────────────────────
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeRPC(w http.ResponseWriter, id json.RawMessage, result any, rerr *rpcError) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", ID: id, Result: result, Error: rerr})
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/report"
+)
+
+const testToken = "secret"
+
+// fakeEngine returns canned results and records executed items.
+type fakeEngine struct {
+	items    []report.Item
+	executed []report.Item
+	block    chan struct{} // if set, Scan waits on it or ctx
+}
+
+func (f *fakeEngine) Scan(ctx context.Context, p ScanParams, progress func(string)) ([]report.Item, error) {
+	progress("scanning")
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return f.items, nil
+}
+
+func (f *fakeEngine) Execute(ctx context.Context, items []report.Item, dryRun bool, progress func(string)) (report.Session, error) {
+	var freed int64
+	for _, it := range items {
+		progress("deleting " + it.Path)
+		freed += it.Size
+	}
+	if !dryRun {
+		f.executed = append(f.executed, items...)
+	}
+	return report.Session{Command: "serve", DryRun: dryRun, Freed: freed, Items: len(items)}, nil
+}
+
+func (f *fakeEngine) Metrics(ctx context.Context) (any, error) {
+	return map[string]float64{"cpu": 12.5}, nil
+}
+
+func (f *fakeEngine) AnalyzeTree(ctx context.Context, path string, depth int, progress func(string)) (any, error) {
+	return map[string]any{"path": path, "depth": depth}, nil
+}
+
+func newTestServer(t *testing.T, eng Engine, historyPath string) *httptest.Server {
+	t.Helper()
+	s, err := New(Options{Token: testToken, Engine: eng, HistoryPath: historyPath, Version: "test"})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		ts.Close()
+		_ = s.Shutdown(context.Background())
+	})
+	return ts
+}
+
+// call makes an RPC call and decodes the result into out.
+func call(t *testing.T, ts *httptest.Server, method string, params any, out any) *rpcError {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/rpc", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		t.Fatalf("%s: decode: %v", method, err)
+	}
+	if r.Error == nil && out != nil {
+		if err := json.Unmarshal(r.Result, out); err != nil {
+			t.Fatalf("%s: decode result: %v", method, err)
+		}
+	}
+	return r.Error
+}
+
+// waitJob polls a job until it finishes.
+func waitJob(t *testing.T, ts *httptest.Server, id string, result any) JobSnapshot {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var snap struct {
+			JobSnapshot
+			Result json.RawMessage `json:"result"`
+		}
+		if e := call(t, ts, "job.get", map[string]string{"id": id}, &snap); e != nil {
+			t.Fatalf("job.get: %s", e.Message)
+		}
+		if snap.State != JobRunning {
+			if result != nil && len(snap.Result) > 0 {
+				_ = json.Unmarshal(snap.Result, result)
+			}
+			return snap.JobSnapshot
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("job did not finish")
+	return JobSnapshot{}
+}
+
+func TestAuthRequired(t *testing.T) {
+	ts := newTestServer(t, &fakeEngine{}, "")
+
+	resp, err := http.Post(ts.URL+"/rpc", "application/json",
+		bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"method":"version"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestUnknownMethod(t *testing.T) {
+	ts := newTestServer(t, &fakeEngine{}, "")
+	if e := call(t, ts, "nope", nil, nil); e == nil || e.Code != codeMethodNotFound {
+		t.Errorf("expected method-not-found, got %+v", e)
+	}
+}
+
+func TestScanJob(t *testing.T) {
+	eng := &fakeEngine{items: []report.Item{
+		{Path: `C:\a`, Size: 10, Category: "user"},
+		{Path: `C:\b`, Size: 5, Category: "dev"},
+	}}
+	ts := newTestServer(t, eng, "")
+
+	var started jobStarted
+	if e := call(t, ts, "scan", ScanParams{Profile: "safe"}, &started); e != nil {
+		t.Fatalf("scan: %s", e.Message)
+	}
+	var sum scanSummary
+	snap := waitJob(t, ts, started.JobID, &sum)
+	if snap.State != JobSucceeded {
+		t.Fatalf("state = %s (%s)", snap.State, snap.Error)
+	}
+	if sum.TotalSize != 15 || sum.ItemCount != 2 || sum.Categories["user"].Size != 10 {
+		t.Errorf("unexpected summary: %+v", sum)
+	}
+}
+
+func TestPlanThenExecute(t *testing.T) {
+	eng := &fakeEngine{items: []report.Item{{Path: `C:\a`, Size: 10}, {Path: `C:\b`, Size: 5}}}
+	ts := newTestServer(t, eng, "")
+
+	var started jobStarted
+	call(t, ts, "plan", nil, &started)
+	var plan planResult
+	waitJob(t, ts, started.JobID, &plan)
+	if plan.PlanID == "" || plan.Plan.ItemCount != 2 {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	call(t, ts, "execute", executeParams{PlanID: plan.PlanID, Paths: []string{`c:\A`}}, &started)
+	var session report.Session
+	waitJob(t, ts, started.JobID, &session)
+	if session.Items != 1 || len(eng.executed) != 1 || eng.executed[0].Path != `C:\a` {
+		t.Errorf("expected only C:\\a executed, got %+v", eng.executed)
+	}
+
+	// A plan can only be executed once.
+	if e := call(t, ts, "execute", executeParams{PlanID: plan.PlanID}, nil); e == nil || e.Code != codeInvalidParams {
+		t.Errorf("expected second execute to be rejected, got %+v", e)
+	}
+}
+
+func TestJobStreamAndCancel(t *testing.T) {
+	eng := &fakeEngine{block: make(chan struct{})}
+	ts := newTestServer(t, eng, "")
+
+	var started jobStarted
+	call(t, ts, "scan", nil, &started)
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/jobs/"+started.JobID+"/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	sc := bufio.NewScanner(resp.Body)
+	if !sc.Scan() {
+		t.Fatal("expected a progress line")
+	}
+	var ev JobEvent
+	if err := json.Unmarshal(sc.Bytes(), &ev); err != nil || ev.Message != "scanning" {
+		t.Fatalf("first event = %s (%v)", sc.Bytes(), err)
+	}
+
+	call(t, ts, "job.cancel", map[string]string{"id": started.JobID}, nil)
+
+	var last []byte
+	for sc.Scan() {
+		last = append(last[:0], sc.Bytes()...)
+	}
+	var snap JobSnapshot
+	if err := json.Unmarshal(last, &snap); err != nil || snap.State != JobCanceled {
+		t.Errorf("final line = %s, want canceled snapshot", last)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	for i := 1; i <= 3; i++ {
+		if err := report.AppendHistory(path, report.Session{Command: "clean", Items: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ts := newTestServer(t, &fakeEngine{}, path)
+
+	var sessions []report.Session
+	call(t, ts, "history", map[string]int{"limit": 2}, &sessions)
+	if len(sessions) != 2 || sessions[0].Items != 3 {
+		t.Errorf("expected 2 newest-first sessions, got %+v", sessions)
+	}
+}
@@ -34,7 +34,7 @@ func AllCommands() []CmdDef {
 		{
 			Name:        "clean",
 			Description: "Deep clean system caches and temp files",
-			Usage:       "/clean [--dry-run] [--all|--user|--browser|--dev|--system]",
+			Usage:       "/clean [--dry-run|--deep] [--shred] [--profile name] [--all|--user|--browser|--dev|--system]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
@@ -67,13 +67,37 @@ func AllCommands() []CmdDef {
 		{
 			Name:        "purge",
 			Description: "Clean project build artifacts",
-			Usage:       "/purge [--dry-run] [--min-age days] [--min-size bytes]",
+			Usage:       "/purge [--dry-run|--deep] [--min-age days] [--min-size bytes]",
 			Mode:        ExecCobra,
 		},
 		{
 			Name:        "installer",
 			Description: "Find and remove old installer files",
-			Usage:       "/installer [--dry-run] [--min-age days]",
+			Usage:       "/installer [--dry-run|--deep] [--min-age days]",
+			Mode:        ExecCobra,
+		},
+		{
+			Name:        "guard",
+			Description: "Clean automatically when free space runs low",
+			Usage:       "/guard --threshold size [--profile safe] [--once]",
+			Mode:        ExecCobra,
+		},
+		{
+			Name:        "serve",
+			Description: "Run a local API server for other tools",
+			Usage:       "/serve [--listen addr] [--pipe name]",
+			Mode:        ExecCobra,
+		},
+		{
+			Name:        "fleet",
+			Description: "Aggregate fleet policy reports",
+			Usage:       "/fleet summarize <dir> [--key-file path] [--json]",
+			Mode:        ExecCobra,
+		},
+		{
+			Name:        "pending",
+			Description: "List or cancel deletions scheduled for reboot",
+			Usage:       "/pending list | cancel [path...] [--all]",
 			Mode:        ExecCobra,
 		},
 		{
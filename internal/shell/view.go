@@ -109,6 +109,10 @@ var cmdIcons = map[string]string{
 	"status":    ui.IconDot,
 	"purge":     ui.IconTrash,
 	"installer": ui.IconFolder,
+	"guard":     ui.IconWarning,
+	"serve":     ui.IconArrow,
+	"fleet":     ui.IconDiamond,
+	"pending":   ui.IconReload,
 	"update":    ui.IconReload,
 	"version":   ui.IconDiamond,
 	"help":      ui.IconHelp,
@@ -0,0 +1,57 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/notify"
+)
+
+// CheckAlerts compares a metrics snapshot against the configured thresholds
+// and returns every alert currently tripped. Disabled (zero) thresholds are
+// skipped.
+func CheckAlerts(m *SystemMetrics, th config.AlertThresholds) []notify.Alert {
+	if m == nil {
+		return nil
+	}
+
+	var alerts []notify.Alert
+
+	if th.CPUPercent > 0 && m.CPU.TotalPercent >= th.CPUPercent {
+		alerts = append(alerts, notify.Alert{
+			Metric:    "cpu",
+			Value:     m.CPU.TotalPercent,
+			Threshold: th.CPUPercent,
+			Message: fmt.Sprintf("CPU usage %.0f%% is at or above %.0f%%",
+				m.CPU.TotalPercent, th.CPUPercent),
+		})
+	}
+
+	if th.MemoryPercent > 0 && m.Memory.UsedPercent >= th.MemoryPercent {
+		alerts = append(alerts, notify.Alert{
+			Metric:    "memory",
+			Value:     m.Memory.UsedPercent,
+			Threshold: th.MemoryPercent,
+			Message: fmt.Sprintf("Memory usage %.0f%% is at or above %.0f%%",
+				m.Memory.UsedPercent, th.MemoryPercent),
+		})
+	}
+
+	if th.DiskPercent > 0 {
+		for _, p := range m.Disk.Partitions {
+			if p.UsedPercent < th.DiskPercent {
+				continue
+			}
+			alerts = append(alerts, notify.Alert{
+				Metric:    "disk",
+				Target:    p.Path,
+				Value:     p.UsedPercent,
+				Threshold: th.DiskPercent,
+				Message: fmt.Sprintf("Disk %s usage %.0f%% is at or above %.0f%%",
+					p.Path, p.UsedPercent, th.DiskPercent),
+			})
+		}
+	}
+
+	return alerts
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/notify"
 )
 
 // ─── Tab enumeration ─────────────────────────────────────────────────────────
@@ -50,6 +53,11 @@ type StatusModel struct {
 	NetRecvHistory []uint64
 	CPUHistory     []float64
 	MemHistory     []float64
+
+	// Alerting (optional; see WithAlerts).
+	alertThresholds config.AlertThresholds
+	alertState      *notify.AlertState
+	notifier        *notify.Notifier
 }
 
 // NewStatusModel creates a StatusModel with the given refresh cadence.
@@ -64,6 +72,27 @@ func NewStatusModel(refreshInterval time.Duration) StatusModel {
 	}
 }
 
+// WithAlerts enables alert notifications: each time a reading trips one of
+// the thresholds (after previously being clear), an alert event is sent.
+func (m StatusModel) WithAlerts(th config.AlertThresholds, n *notify.Notifier) StatusModel {
+	m.alertThresholds = th
+	m.alertState = notify.NewAlertState()
+	m.notifier = n
+	return m
+}
+
+// sendAlerts delivers newly tripped alerts in the background so the
+// dashboard never waits on a webhook.
+func (m StatusModel) sendAlerts(alerts []notify.Alert) tea.Cmd {
+	n := m.notifier
+	return func() tea.Msg {
+		for _, a := range alerts {
+			_ = n.Send(notify.NewAlertEvent(a))
+		}
+		return nil
+	}
+}
+
 func (m StatusModel) doTick() tea.Cmd {
 	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
@@ -140,6 +169,13 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.NetSendHistory = appendU64(m.NetSendHistory, msg.metrics.Network.SendSpeed, 60)
 		m.NetRecvHistory = appendU64(m.NetRecvHistory, msg.metrics.Network.RecvSpeed, 60)
 
+		if m.alertState != nil {
+			fired := m.alertState.Transition(CheckAlerts(msg.metrics, m.alertThresholds))
+			if len(fired) > 0 {
+				return m, tea.Batch(m.doTick(), m.sendAlerts(fired))
+			}
+		}
+
 		return m, m.doTick()
 	}
 
@@ -0,0 +1,38 @@
+//go:build linux
+
+package throttle
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// ioprio_set arguments for the idle IO class, as set by ionice -c 3.
+const (
+	ioprioWhoProcess = 1
+	ioprioClassIdle  = 3
+	ioprioClassShift = 13
+)
+
+// Background lowers the process to nice 10 and the idle IO class. The
+// returned function restores the previous CPU and IO priority.
+func Background() (restore func(), err error) {
+	oldIO, _, errno := unix.Syscall(unix.SYS_IOPRIO_GET, ioprioWhoProcess, 0, 0)
+	oldNice, niceErr := unix.Getpriority(unix.PRIO_PROCESS, 0)
+
+	if err := unix.Setpriority(unix.PRIO_PROCESS, 0, 10); err != nil {
+		return func() {}, err
+	}
+	if _, _, e := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0,
+		ioprioClassIdle<<ioprioClassShift); e != 0 {
+		err = e
+	}
+	return func() {
+		if errno == 0 {
+			_, _, _ = unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, oldIO)
+		}
+		if niceErr == nil {
+			// Getpriority returns 20 - nice on Linux.
+			_ = unix.Setpriority(unix.PRIO_PROCESS, 0, 20-oldNice)
+		}
+	}, err
+}
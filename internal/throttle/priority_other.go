@@ -0,0 +1,16 @@
+//go:build !windows && !linux
+
+package throttle
+
+import "syscall"
+
+// Background lowers the process to nice 10. The returned function restores
+// normal priority.
+func Background() (restore func(), err error) {
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, 0, 10); err != nil {
+		return func() {}, err
+	}
+	return func() {
+		_ = syscall.Setpriority(syscall.PRIO_PROCESS, 0, 0)
+	}, nil
+}
@@ -0,0 +1,21 @@
+//go:build windows
+
+package throttle
+
+import "golang.org/x/sys/windows"
+
+// Background puts the process in background processing mode, which lowers
+// both its CPU and its disk and memory priority. The returned function
+// restores normal priority.
+func Background() (restore func(), err error) {
+	proc, err := windows.GetCurrentProcess()
+	if err != nil {
+		return func() {}, err
+	}
+	if err := windows.SetPriorityClass(proc, windows.PROCESS_MODE_BACKGROUND_BEGIN); err != nil {
+		return func() {}, err
+	}
+	return func() {
+		_ = windows.SetPriorityClass(proc, windows.PROCESS_MODE_BACKGROUND_END)
+	}, nil
+}
@@ -0,0 +1,94 @@
+// Package throttle keeps background cleanups from hogging the machine: it
+// lowers the process's CPU and IO priority and paces file operations to a
+// files-per-second and bytes-per-second budget.
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// Defaults used by gentle mode when config leaves a cap unset.
+const (
+	DefaultFilesPerSec = 200
+	DefaultBytesPerSec = 100 * 1024 * 1024
+)
+
+// Limits caps the rate of file operations. Zero values disable a cap.
+type Limits struct {
+	// FilesPerSec caps deletes and stats per second.
+	FilesPerSec int
+
+	// BytesPerSec caps bytes deleted per second.
+	BytesPerSec int64
+}
+
+// Limiter paces file operations to Limits. A nil *Limiter never waits, so
+// callers can use one unconditionally.
+type Limiter struct {
+	lim   Limits
+	mu    sync.Mutex
+	start time.Time
+	files int64
+	bytes int64
+
+	// now and sleep are the clock; tests replace them.
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// New returns a Limiter for lim, or nil when lim sets no caps.
+func New(lim Limits) *Limiter {
+	if lim.FilesPerSec <= 0 && lim.BytesPerSec <= 0 {
+		return nil
+	}
+	return &Limiter{lim: lim, now: time.Now, sleep: time.Sleep}
+}
+
+// Limits returns the caps l enforces; zero for a nil Limiter.
+func (l *Limiter) Limits() Limits {
+	if l == nil {
+		return Limits{}
+	}
+	return l.lim
+}
+
+// maxBurst is how much unused budget carries over from idle time: after a
+// pause (say, for a confirmation prompt) at most a second's worth of
+// operations goes through without waiting.
+const maxBurst = time.Second
+
+// Wait blocks until files more operations touching bytes more bytes fit in
+// the budget, then counts them.
+func (l *Limiter) Wait(files int, bytes int64) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if l.start.IsZero() {
+		l.start = now
+	}
+	if idle := now.Sub(l.start) - l.due(); idle > maxBurst {
+		l.start = l.start.Add(idle - maxBurst)
+	}
+	l.files += int64(files)
+	l.bytes += bytes
+	if wait := l.due() - now.Sub(l.start); wait > 0 {
+		l.sleep(wait)
+	}
+}
+
+// due returns how long after start the work counted so far may finish.
+func (l *Limiter) due() time.Duration {
+	var due time.Duration
+	if l.lim.FilesPerSec > 0 {
+		due = max(due, time.Duration(float64(l.files)/float64(l.lim.FilesPerSec)*float64(time.Second)))
+	}
+	if l.lim.BytesPerSec > 0 {
+		due = max(due, time.Duration(float64(l.bytes)/float64(l.lim.BytesPerSec)*float64(time.Second)))
+	}
+	return due
+}
@@ -0,0 +1,67 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the limiter sleeps or the test says so.
+type fakeClock struct {
+	t     time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.t = c.t.Add(d)
+	c.slept += d
+}
+
+func newTestLimiter(lim Limits) (*Limiter, *fakeClock) {
+	c := &fakeClock{t: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)}
+	l := New(lim)
+	l.now, l.sleep = c.now, c.sleep
+	return l, c
+}
+
+func TestNilLimiter(t *testing.T) {
+	if l := New(Limits{}); l != nil {
+		t.Fatalf("New(no caps) = %v, want nil", l)
+	}
+	var l *Limiter
+	l.Wait(1000, 1<<30) // must not block or panic
+}
+
+func TestFilesPerSec(t *testing.T) {
+	l, c := newTestLimiter(Limits{FilesPerSec: 10})
+	for i := 0; i < 30; i++ {
+		l.Wait(1, 0)
+	}
+	if c.slept != 3*time.Second {
+		t.Errorf("slept %v for 30 files at 10/s, want 3s", c.slept)
+	}
+}
+
+func TestBytesPerSecDominates(t *testing.T) {
+	l, c := newTestLimiter(Limits{FilesPerSec: 1000, BytesPerSec: 100})
+	l.Wait(1, 500)
+	if c.slept != 5*time.Second {
+		t.Errorf("slept %v for 500 bytes at 100/s, want 5s", c.slept)
+	}
+}
+
+func TestIdleCreditIsCapped(t *testing.T) {
+	l, c := newTestLimiter(Limits{FilesPerSec: 10})
+	l.Wait(1, 0)
+	c.t = c.t.Add(time.Minute) // a long confirmation prompt
+	c.slept = 0
+
+	for i := 0; i < 20; i++ {
+		l.Wait(1, 0)
+	}
+	// One second of credit covers 10 files; the other 10 wait a second.
+	if c.slept < 900*time.Millisecond || c.slept > 1100*time.Millisecond {
+		t.Errorf("slept %v after idling, want about 1s", c.slept)
+	}
+}
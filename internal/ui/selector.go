@@ -26,6 +26,10 @@ type SelectorItem struct {
 	// Size is a human-readable size string shown on the right.
 	Size string
 
+	// Risk is an optional risk level (low, medium, high) shown as a tag
+	// after the size.
+	Risk string
+
 	// Selected indicates whether this item is currently checked.
 	Selected bool
 
@@ -358,6 +362,11 @@ func (m SelectorModel) View() string {
 			line.WriteString(sizeStyle.Render(item.Size))
 		}
 
+		// Risk tag.
+		if item.Risk != "" {
+			line.WriteString("  " + RiskTag(item.Risk))
+		}
+
 		b.WriteString(line.String())
 		b.WriteByte('\n')
 
@@ -273,6 +273,19 @@ func TagWarningStyle() lipgloss.Style {
 		Bold(true)
 }
 
+// RiskTag renders a target risk level (low, medium, high) as a tag of
+// fixed width, colored by severity.
+func RiskTag(level string) string {
+	style := TagStyle()
+	switch strings.ToLower(level) {
+	case "medium":
+		style = TagWarningStyle()
+	case "high":
+		style = TagErrorStyle()
+	}
+	return style.Render(fmt.Sprintf("%-6s", strings.ToLower(level)))
+}
+
 // SectionHeader renders: "── Label ──────────" at the given width.
 func SectionHeader(label string, width int) string {
 	styled := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true).Render(label)
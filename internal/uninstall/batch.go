@@ -7,13 +7,30 @@ import (
 	"github.com/lakshaymaurya-felt/purewin/internal/ui"
 )
 
+// BatchResult summarizes a batch uninstall.
+type BatchResult struct {
+	// Uninstalled is the number of applications removed successfully.
+	Uninstalled int
+
+	// Failed is the number of applications whose uninstaller failed.
+	Failed int
+
+	// Freed is the estimated size of the removed applications in bytes.
+	Freed int64
+
+	// Removed lists the applications uninstalled successfully.
+	Removed []InstalledApp
+}
+
 // RunBatchUninstall presents a multi-select UI for the given applications,
 // confirms the selection, and executes uninstalls with progress feedback.
 // In dryRun mode, operations are listed but not executed.
-func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
+func RunBatchUninstall(apps []InstalledApp, dryRun bool) (BatchResult, error) {
+	var result BatchResult
+
 	if len(apps) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications found."))
-		return nil
+		return result, nil
 	}
 
 	// 1. Convert to selector items.
@@ -37,11 +54,11 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 	// 2. Run the selector.
 	selected, err := ui.RunSelector(items, "Select applications to uninstall")
 	if err != nil {
-		return fmt.Errorf("selector error: %w", err)
+		return result, fmt.Errorf("selector error: %w", err)
 	}
 	if len(selected) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications selected."))
-		return nil
+		return result, nil
 	}
 
 	// 3. Map selected items back to apps.
@@ -64,17 +81,17 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 	if dryRun {
 		fmt.Println(ui.WarningStyle().Render(
 			"  DRY RUN — no applications will be uninstalled."))
-		return nil
+		return result, nil
 	}
 
 	// 6. Confirm before executing.
 	confirmed, err := ui.DangerConfirm("This will uninstall the selected applications")
 	if err != nil {
-		return fmt.Errorf("confirmation error: %w", err)
+		return result, fmt.Errorf("confirmation error: %w", err)
 	}
 	if !confirmed {
 		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
-		return nil
+		return result, nil
 	}
 
 	// 7. Execute uninstalls with progress.
@@ -92,6 +109,8 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 		} else {
 			spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
 			successes++
+			result.Freed += app.EstimatedSize
+			result.Removed = append(result.Removed, app)
 		}
 	}
 
@@ -107,7 +126,9 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 			fmt.Sprintf("  %s %d application(s) failed to uninstall", ui.IconError, failures)))
 	}
 
-	return nil
+	result.Uninstalled = successes
+	result.Failed = failures
+	return result, nil
 }
 
 // mapSelectedApps maps selected SelectorItems back to InstalledApp entries
@@ -45,7 +45,8 @@ const (
 
 // UninstallApp executes the uninstall command for the given application.
 // If quiet is true and a QuietUninstallString is available, it is preferred.
-// The process is given a 120-second timeout.
+// It returns once the uninstaller and every process it started have exited,
+// waiting 120 seconds at most.
 func UninstallApp(app InstalledApp, quiet bool) error {
 	cmdStr := chooseUninstallCommand(app, quiet)
 	if cmdStr == "" {
@@ -281,7 +282,7 @@ func runMSIUninstall(cmdStr string, quiet bool) error {
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "msiexec.exe", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runTree(ctx, cmd)
 	if err != nil {
 		return handleExitError(err, output)
 	}
@@ -412,7 +413,7 @@ func runUninstallCommand(cmdStr string, installerType InstallerType, quiet bool)
 
 	// Execute the command directly (NOT via cmd.exe /C).
 	cmd := exec.CommandContext(ctx, exe, args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runTree(ctx, cmd)
 	if err != nil {
 		return handleExitError(err, output)
 	}
@@ -0,0 +1,87 @@
+package uninstall
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// treePollInterval is how often runTree checks whether the processes an
+// uninstaller started have exited.
+const treePollInterval = 500 * time.Millisecond
+
+// jobAccounting mirrors JOBOBJECT_BASIC_ACCOUNTING_INFORMATION.
+type jobAccounting struct {
+	TotalUserTime             int64
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+}
+
+// runTree runs cmd and returns its combined output once it and every
+// process it started have exited. Many NSIS and Inno Setup uninstallers
+// copy themselves to the temp folder, start the copy and return at once,
+// so the uninstall is only over when the copy exits. The processes are
+// tracked in a job object; if one cannot be set up, only cmd is waited
+// for. If ctx ends first, the error says the uninstaller is still running.
+func runTree(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	job := trackTree(cmd.Process.Pid)
+	if job != 0 {
+		defer windows.CloseHandle(job)
+	}
+	if err := cmd.Wait(); err != nil {
+		return out.Bytes(), err
+	}
+	if job == 0 {
+		return out.Bytes(), nil
+	}
+
+	for {
+		var info jobAccounting
+		err := windows.QueryInformationJobObject(job, windows.JobObjectBasicAccountingInformation,
+			uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)), nil)
+		if err != nil || info.ActiveProcesses == 0 {
+			return out.Bytes(), nil
+		}
+		select {
+		case <-ctx.Done():
+			return out.Bytes(), fmt.Errorf("uninstaller still running after %s", uninstallTimeout)
+		case <-time.After(treePollInterval):
+		}
+	}
+}
+
+// trackTree puts the process pid in a new job object, so the processes it
+// starts are tracked with it, and returns the job, or 0 if that fails.
+func trackTree(pid int) windows.Handle {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0
+	}
+	proc, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		windows.CloseHandle(job)
+		return 0
+	}
+	defer windows.CloseHandle(proc)
+	if err := windows.AssignProcessToJobObject(job, proc); err != nil {
+		windows.CloseHandle(job)
+		return 0
+	}
+	return job
+}
@@ -0,0 +1,86 @@
+package purewin
+
+import (
+	"context"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/analyze"
+)
+
+// AnalyzeOptions controls Analyze.
+type AnalyzeOptions struct {
+	// Depth limits how many levels of children are returned. Sizes always
+	// include the whole subtree. Zero returns only the root.
+	Depth int
+
+	// Concurrency bounds parallel directory reads. Zero uses the default.
+	Concurrency int
+
+	// Exclude lists directory names (case-insensitive) to skip.
+	Exclude []string
+
+	// Progress, if set, is called about once a second with the number of
+	// entries scanned so far.
+	Progress func(scanned int64)
+}
+
+// Entry is a file or directory in a size tree.
+type Entry struct {
+	Path     string    `json:"path"`
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	IsDir    bool      `json:"is_dir"`
+	ModTime  time.Time `json:"mod_time"`
+	Children []*Entry  `json:"children,omitempty"`
+}
+
+// Analyze computes the size tree of path. If ctx is canceled first,
+// Analyze returns ctx.Err() without waiting for the walk to finish.
+func Analyze(ctx context.Context, path string, opts AnalyzeOptions) (*Entry, error) {
+	scanner := analyze.NewScanner(opts.Concurrency, opts.Exclude)
+
+	type scanOutcome struct {
+		root *analyze.DirEntry
+		err  error
+	}
+	done := make(chan scanOutcome, 1)
+	go func() {
+		root, err := scanner.Scan(path)
+		done <- scanOutcome{root, err}
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			if opts.Progress != nil {
+				opts.Progress(scanner.ScannedCount())
+			}
+		case out := <-done:
+			if out.err != nil {
+				return nil, out.err
+			}
+			return newEntry(out.root, opts.Depth), nil
+		}
+	}
+}
+
+// newEntry copies a scan tree down to depth levels of children.
+func newEntry(d *analyze.DirEntry, depth int) *Entry {
+	e := &Entry{
+		Path:    d.Path,
+		Name:    d.Name,
+		Size:    d.Size,
+		IsDir:   d.IsDir,
+		ModTime: d.ModTime,
+	}
+	if depth > 0 {
+		for _, child := range d.Children {
+			e.Children = append(e.Children, newEntry(child, depth-1))
+		}
+	}
+	return e
+}
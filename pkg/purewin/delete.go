@@ -0,0 +1,164 @@
+package purewin
+
+import (
+	"context"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+)
+
+// Errors reported in ItemResult.Err. Match them with errors.Is.
+var (
+	ErrInvalidPath  = core.ErrInvalidPath
+	ErrProtected    = core.ErrProtected
+	ErrWhitelisted  = core.ErrWhitelisted
+	ErrLocked       = core.ErrLocked
+	ErrAccessDenied = core.ErrAccessDenied
+	ErrVanished     = core.ErrVanished
+
+	// ErrOutsideScope marks items DeleteItems refused because their real
+	// path escapes their Root.
+	ErrOutsideScope = core.ErrOutsideScope
+)
+
+// ScopeError is the error reported for an item that escapes its Root,
+// naming where it actually resolved to.
+type ScopeError = core.ScopeError
+
+// DeleteOptions controls how Delete removes paths.
+type DeleteOptions struct {
+	// DryRun sizes each path without deleting anything.
+	DryRun bool
+
+	// DeepDryRun makes a dry run also predict failures: each file is
+	// opened for exclusive delete access and closed again, so locked and
+	// access-denied items fail in the result as they would for real.
+	// Ignored unless DryRun is set.
+	DeepDryRun bool
+
+	// OnItem, if set, is called after each path is processed.
+	OnItem func(ItemResult)
+
+	// Before, if set, is called before each path is deleted. A non-nil
+	// error stops the run: Delete returns the partial result and the error.
+	Before func(path string) error
+
+	// Shred, if set, overwrites files before deleting them. Ignored in a
+	// dry run.
+	Shred *ShredOptions
+
+	// Pace, if set, is waited on before each file, directory and link is
+	// removed, to cap the deletion rate.
+	Pace Pacer
+}
+
+// Pacer rate-limits deletion and scanning; see DeleteOptions.Pace and
+// ScanOptions.Pace.
+type Pacer = core.Pacer
+
+// ShredOptions controls secure overwrite deletion. Shredded files are
+// overwritten with random data, truncated and renamed to a random name
+// before being unlinked. The same safety checks apply as for a plain
+// delete. On SSDs the overwrite may not reach every copy of the data; see
+// VolumeHasTrim.
+type ShredOptions struct {
+	// Passes is the number of overwrite passes. Zero means one.
+	Passes int
+
+	// Only, if set, limits shredding to the paths it returns true for; the
+	// rest are deleted plainly.
+	Only func(path string) bool
+}
+
+// remover returns the deletion strategy for path, nil meaning plain.
+func (o *ShredOptions) remover(path string) core.Remover {
+	if o == nil || (o.Only != nil && !o.Only(path)) {
+		return nil
+	}
+	return core.Shredder{Passes: o.Passes}
+}
+
+// VolumeHasTrim reports whether path's volume is on a device with TRIM
+// enabled, typically an SSD, where shredding cannot guarantee every copy
+// of a file's data is overwritten.
+func VolumeHasTrim(path string) (bool, error) {
+	return core.VolumeHasTrim(path)
+}
+
+// ItemResult is the outcome for one deleted path.
+type ItemResult struct {
+	// Path is the path that was processed.
+	Path string `json:"path"`
+
+	// Freed is the number of bytes freed (or that would be, in a dry run).
+	Freed int64 `json:"freed"`
+
+	// Err is non-nil when the path was skipped.
+	Err error `json:"-"`
+
+	// ReadOnly reports that a deep dry run found read-only entries. They
+	// are still deleted, after the attribute is cleared.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// DeleteResult summarizes a Delete call.
+type DeleteResult struct {
+	// Freed is the total number of bytes freed.
+	Freed int64 `json:"freed"`
+
+	// Deleted is the number of paths removed.
+	Deleted int `json:"deleted"`
+
+	// Failed lists paths that could not be removed.
+	Failed []ItemResult `json:"failed,omitempty"`
+}
+
+// Delete removes paths through the engine's safe-delete checks. It stops
+// early when ctx is canceled or Before refuses a path, returning the
+// partial result with that error. Per-path failures are reported in the
+// result, not as an error.
+func Delete(ctx context.Context, paths []string, opts DeleteOptions) (*DeleteResult, error) {
+	items := make([]Item, len(paths))
+	for i, path := range paths {
+		items[i] = Item{Path: path}
+	}
+	return DeleteItems(ctx, items, opts)
+}
+
+// DeleteItems is Delete for scanned items: each item with a Root is
+// confined to it, and one whose real path escapes it through a symlink or
+// junction fails with a *ScopeError matching ErrOutsideScope. Links are
+// always removed as links, never followed.
+func DeleteItems(ctx context.Context, items []Item, opts DeleteOptions) (*DeleteResult, error) {
+	res := &DeleteResult{}
+	for _, it := range items {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		if opts.Before != nil {
+			if err := opts.Before(it.Path); err != nil {
+				return res, err
+			}
+		}
+
+		var item ItemResult
+		if opts.DryRun && opts.DeepDryRun {
+			p := core.ProbeDelete(it.Path, it.Root)
+			item = ItemResult{Path: it.Path, Freed: p.Size, Err: p.Err, ReadOnly: p.ReadOnly}
+		} else {
+			r := core.Paced(opts.Shred.remover(it.Path), opts.Pace)
+			freed, err := core.SafeDeleteWith(it.Path, it.Root, opts.DryRun, r)
+			item = ItemResult{Path: it.Path, Freed: freed, Err: err}
+		}
+		if item.Err != nil {
+			item.Freed = 0
+			res.Failed = append(res.Failed, item)
+		} else {
+			res.Freed += item.Freed
+			res.Deleted++
+		}
+		if opts.OnItem != nil {
+			opts.OnItem(item)
+		}
+	}
+	return res, nil
+}
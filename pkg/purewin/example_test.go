@@ -0,0 +1,104 @@
+package purewin_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/pkg/purewin"
+)
+
+func ExampleScan() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	res, err := purewin.Scan(ctx, purewin.ScanOptions{Profile: "safe"})
+	if err != nil {
+		fmt.Println("scan failed:", err)
+		return
+	}
+	for _, t := range res.Targets {
+		fmt.Printf("%-8s %-24s %d bytes\n", t.Category, t.Name, t.TotalSize)
+	}
+}
+
+func ExampleDeleteItems() {
+	ctx := context.Background()
+
+	res, err := purewin.Scan(ctx, purewin.ScanOptions{
+		Categories: []string{purewin.CategoryBrowser},
+	})
+	if err != nil {
+		fmt.Println("scan failed:", err)
+		return
+	}
+
+	out, err := purewin.DeleteItems(ctx, res.Items(), purewin.DeleteOptions{
+		DryRun: true,
+		OnItem: func(r purewin.ItemResult) {
+			if r.Err != nil {
+				fmt.Println("skipped", r.Path, r.Err)
+			}
+		},
+	})
+	if err != nil {
+		fmt.Println("delete interrupted:", err)
+	}
+	fmt.Printf("would free %d bytes from %d items\n", out.Freed, out.Deleted)
+}
+
+func ExampleCleanExtra() {
+	ctx := context.Background()
+
+	res, err := purewin.Scan(ctx, purewin.ScanOptions{
+		Categories: []string{purewin.CategoryUser, purewin.CategoryDev},
+		Extras:     true,
+	})
+	if err != nil {
+		fmt.Println("scan failed:", err)
+		return
+	}
+	for _, e := range res.Extras {
+		freed, err := purewin.CleanExtra(ctx, e, true, nil)
+		if err != nil {
+			fmt.Println("skipped", e.Name, err)
+			continue
+		}
+		fmt.Printf("%s would free %d bytes\n", e.Name, freed)
+	}
+}
+
+func ExampleScanArtifacts() {
+	artifacts, err := purewin.ScanArtifacts(context.Background(), purewin.ArtifactScanOptions{
+		MinSize: 50 << 20,
+	})
+	if err != nil {
+		fmt.Println("scan failed:", err)
+		return
+	}
+	for _, a := range artifacts {
+		if !a.Recent {
+			fmt.Println(a.Type, a.Path)
+		}
+	}
+}
+
+func ExampleAnalyze() {
+	root, err := purewin.Analyze(context.Background(), `C:\Users`, purewin.AnalyzeOptions{Depth: 1})
+	if err != nil {
+		fmt.Println("analyze failed:", err)
+		return
+	}
+	for _, child := range root.Children {
+		fmt.Println(child.Name, child.Size)
+	}
+}
+
+func ExampleProfiles() {
+	for _, p := range purewin.Profiles() {
+		fmt.Printf("%s: %v\n", p.Name, p.Categories)
+	}
+	// Output:
+	// safe: [user browser dev]
+	// standard: [user browser dev system]
+}
@@ -0,0 +1,282 @@
+package purewin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+	"github.com/lakshaymaurya-felt/purewin/internal/goal"
+)
+
+// ─── Free-Space Goals ────────────────────────────────────────────────────────
+
+// Pseudo-targets naming the project artifacts and old installers PlanGoal
+// proposes alongside clean targets' items. Both are medium risk.
+const (
+	ArtifactsTarget  = "ProjectArtifacts"
+	InstallersTarget = "OldInstallers"
+)
+
+// DefaultInstallerMinAge is how old an installer must be before PlanGoal
+// proposes deleting it, unless GoalOptions.InstallerMinAge says otherwise.
+const DefaultInstallerMinAge = 30 * 24 * time.Hour
+
+// GoalOptions controls PlanGoal.
+type GoalOptions struct {
+	// Scan selects the clean targets considered, as for Scan. Its
+	// categories also decide whether project artifacts (dev) and old
+	// installers (user) are considered, its MaxRisk caps them like any
+	// target, and its ExcludeTargets may name ArtifactsTarget or
+	// InstallersTarget. Scan.Extras is ignored.
+	Scan ScanOptions
+
+	// Drive is the drive to free space on (e.g. "D:"). Only items on it
+	// are considered. Extras, whose data has no single location, are only
+	// considered for the system drive.
+	Drive string
+
+	// Need is the number of bytes to free.
+	Need int64
+
+	// ProjectPaths are searched for build artifacts. Empty uses
+	// DefaultProjectPaths.
+	ProjectPaths []string
+
+	// InstallerMinAge skips installers modified more recently than this.
+	// Zero means DefaultInstallerMinAge.
+	InstallerMinAge time.Duration
+}
+
+// GoalResult is the proposal PlanGoal makes.
+type GoalResult struct {
+	// Proposal holds the chosen items and extras, shaped like a Scan
+	// result so it can be shown, saved as a plan or deleted like one.
+	// Its Busy lists the targets left out because their application is
+	// running.
+	Proposal *ScanResult
+
+	// Need is the number of bytes the goal asked for.
+	Need int64
+
+	// Reached reports whether the proposal frees Need. When it does not,
+	// the proposal holds everything that was allowed.
+	Reached bool
+}
+
+// Size returns the bytes the proposal frees, extras included.
+func (g *GoalResult) Size() int64 {
+	return g.Proposal.TotalSize + g.Proposal.ExtrasSize()
+}
+
+// PlanGoal proposes what to delete on a drive to free opts.Need bytes. It
+// gathers the clean targets' items, the extras, stale project artifacts
+// and old installers, ranks them by risk level, regrowth cost (temp files
+// cost nothing to lose, package caches must be downloaded again) and age,
+// and picks the fewest, lowest-risk ones that reach the goal. Nothing is
+// deleted.
+func PlanGoal(ctx context.Context, opts GoalOptions) (*GoalResult, error) {
+	want, err := opts.Scan.categories()
+	if err != nil {
+		return nil, err
+	}
+	scanOpts := opts.Scan
+	scanOpts.Extras = onSystemDrive(opts.Drive)
+	scan, err := Scan(ctx, scanOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		candidates []goal.Candidate
+		items      = make(map[string]Item)
+		extras     = make(map[string]Extra)
+	)
+	addItem := func(item Item, risk string, modTime time.Time) {
+		items[item.Path] = item
+		candidates = append(candidates, goal.Candidate{
+			ID:       item.Path,
+			Size:     item.Size,
+			Risk:     goalRisk(risk),
+			Regrowth: regrowth(item.Category, item.Target),
+			ModTime:  modTime,
+		})
+	}
+
+	for _, t := range scan.Targets {
+		for _, item := range t.Items {
+			if !onDrive(item.Path, opts.Drive) {
+				continue
+			}
+			var modTime time.Time
+			if info, err := os.Lstat(item.Path); err == nil {
+				modTime = info.ModTime()
+			}
+			addItem(item, t.Risk, modTime)
+		}
+	}
+	for _, e := range scan.Extras {
+		extras[e.Name] = e
+		candidates = append(candidates, goal.Candidate{
+			ID:       "extra:" + e.Name,
+			Size:     e.Size,
+			Risk:     goalRisk(e.Risk),
+			Regrowth: regrowth(e.Category, e.Name),
+		})
+	}
+
+	aboveRisk := scan.AboveRisk
+	allowed := func(category, target string) bool {
+		if !want[category] {
+			return false
+		}
+		for _, name := range opts.Scan.ExcludeTargets {
+			if strings.EqualFold(name, target) {
+				return false
+			}
+		}
+		if !RiskWithin(TargetRisk(target), opts.Scan.MaxRisk) {
+			aboveRisk = append(aboveRisk, target)
+			return false
+		}
+		return true
+	}
+	kept := func(path string) bool {
+		return onDrive(path, opts.Drive) &&
+			(opts.Scan.Whitelist == nil || !opts.Scan.Whitelist.IsWhitelisted(path))
+	}
+
+	if allowed(CategoryDev, ArtifactsTarget) {
+		artifacts, err := ScanArtifacts(ctx, ArtifactScanOptions{Paths: opts.ProjectPaths, Pace: opts.Scan.Pace})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range artifacts {
+			// Recent artifacts belong to projects in active use.
+			if a.Recent || !kept(a.Path) {
+				continue
+			}
+			addItem(Item{
+				Path:     a.Path,
+				Size:     a.Size,
+				Category: CategoryDev,
+				Target:   ArtifactsTarget,
+				Root:     a.ProjectPath,
+			}, RiskMedium, a.ModTime)
+		}
+	}
+	if allowed(CategoryUser, InstallersTarget) {
+		minAge := opts.InstallerMinAge
+		if minAge == 0 {
+			minAge = DefaultInstallerMinAge
+		}
+		installers, err := ScanInstallers(ctx, InstallerScanOptions{MinAge: minAge})
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range installers {
+			if !kept(f.Path) {
+				continue
+			}
+			addItem(Item{
+				Path:     f.Path,
+				Size:     f.Size,
+				Files:    1,
+				Category: CategoryUser,
+				Target:   InstallersTarget,
+				Root:     filepath.Dir(f.Path),
+			}, RiskMedium, f.ModTime)
+		}
+	}
+
+	picked := goal.Select(candidates, opts.Need, time.Now())
+	prop := proposal(picked.Chosen, items, extras, scan.Busy)
+	prop.AboveRisk = aboveRisk
+	sort.Strings(prop.AboveRisk)
+	return &GoalResult{
+		Proposal: prop,
+		Need:     opts.Need,
+		Reached:  picked.Reached,
+	}, nil
+}
+
+// proposal builds the ScanResult holding the chosen candidates.
+func proposal(chosen []goal.Candidate, items map[string]Item, extras map[string]Extra, busy []Busy) *ScanResult {
+	out := &ScanResult{Busy: busy}
+	targets := make(map[string]*Target)
+	for _, c := range chosen {
+		if name, ok := strings.CutPrefix(c.ID, "extra:"); ok {
+			out.Extras = append(out.Extras, extras[name])
+			continue
+		}
+		item := items[c.ID]
+		t := targets[item.Target]
+		if t == nil {
+			t = &Target{Name: item.Target, Category: item.Category, Risk: TargetRisk(item.Target)}
+			targets[item.Target] = t
+		}
+		t.Items = append(t.Items, item)
+		t.TotalSize += item.Size
+		t.FileCount += item.Files
+	}
+	for _, t := range targets {
+		out.Targets = append(out.Targets, *t)
+		out.TotalSize += t.TotalSize
+		out.ItemCount += len(t.Items)
+		out.FileCount += t.FileCount
+	}
+	sort.Slice(out.Targets, func(i, j int) bool {
+		if out.Targets[i].Category != out.Targets[j].Category {
+			return out.Targets[i].Category < out.Targets[j].Category
+		}
+		return out.Targets[i].Name < out.Targets[j].Name
+	})
+	sort.Slice(out.Extras, func(i, j int) bool {
+		if out.Extras[i].Category != out.Extras[j].Category {
+			return out.Extras[i].Category < out.Extras[j].Category
+		}
+		return out.Extras[i].Name < out.Extras[j].Name
+	})
+	return out
+}
+
+// goalRisk returns the rank of a risk level for goal.Candidate, counting an
+// unknown level as high.
+func goalRisk(risk string) int {
+	if rank := config.RiskRank(risk); rank >= 0 {
+		return rank
+	}
+	return config.RiskRank(RiskHigh)
+}
+
+// regrowth returns what losing a target's data costs: nothing for temp
+// files, logs and installers, a refill in normal use for browser and app
+// caches, and a download or rebuild for developer caches and artifacts.
+func regrowth(category, target string) goal.Regrowth {
+	switch {
+	case target == InstallersTarget:
+		return goal.RegrowNone
+	case target == ArtifactsTarget, category == CategoryDev:
+		return goal.RegrowCostly
+	case category == CategoryBrowser, category == CategoryElectron:
+		return goal.RegrowCheap
+	}
+	return goal.RegrowNone
+}
+
+// onDrive reports whether path lies on drive.
+func onDrive(path, drive string) bool {
+	return strings.EqualFold(filepath.VolumeName(path), filepath.VolumeName(drive))
+}
+
+// onSystemDrive reports whether drive is the Windows system drive.
+func onSystemDrive(drive string) bool {
+	system := os.Getenv("SystemDrive")
+	if system == "" {
+		system = "C:"
+	}
+	return onDrive(drive, system)
+}
@@ -0,0 +1,156 @@
+package purewin
+
+import (
+	"context"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/installer"
+	"github.com/lakshaymaurya-felt/purewin/internal/purge"
+)
+
+// ─── Project Artifacts ───────────────────────────────────────────────────────
+
+// ArtifactScanOptions controls ScanArtifacts.
+type ArtifactScanOptions struct {
+	// Paths are the directories to search for projects. Empty uses the
+	// default project locations.
+	Paths []string
+
+	// MinSize skips artifacts smaller than this many bytes.
+	MinSize int64
+
+	// Pace, if set, is waited on before each file is statted while
+	// sizing artifacts, to cap the scan's IO.
+	Pace Pacer
+}
+
+// Artifact is a build artifact directory inside a project.
+type Artifact struct {
+	// ProjectPath is the project root.
+	ProjectPath string `json:"project_path"`
+
+	// Path is the artifact directory (node_modules, target, dist, ...).
+	Path string `json:"path"`
+
+	// Type is the artifact kind, e.g. "node_modules".
+	Type string `json:"type"`
+
+	// Size is the size in bytes.
+	Size int64 `json:"size"`
+
+	// ModTime is the last modification time.
+	ModTime time.Time `json:"mod_time"`
+
+	// Recent is true when the artifact changed within the last 7 days.
+	Recent bool `json:"recent"`
+}
+
+// Risk returns the artifact's risk level: medium, or high for a recent
+// artifact, whose project is likely in active use.
+func (a Artifact) Risk() string {
+	if a.Recent {
+		return RiskHigh
+	}
+	return RiskMedium
+}
+
+// DefaultProjectPaths returns the directories ScanArtifacts searches when
+// no paths are given.
+func DefaultProjectPaths() []string {
+	return purge.GetDefaultScanPaths()
+}
+
+// ScanArtifacts finds build artifacts in project directories.
+func ScanArtifacts(ctx context.Context, opts ArtifactScanOptions) ([]Artifact, error) {
+	paths := opts.Paths
+	if len(paths) == 0 {
+		paths = DefaultProjectPaths()
+	}
+
+	found, err := purge.ScanProjects(paths, opts.Pace)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]Artifact, 0, len(found))
+	for _, a := range found {
+		if a.Size < opts.MinSize {
+			continue
+		}
+		artifacts = append(artifacts, Artifact{
+			ProjectPath: a.ProjectPath,
+			Path:        a.ArtifactPath,
+			Type:        a.ArtifactType,
+			Size:        a.Size,
+			ModTime:     a.ModTime,
+			Recent:      a.IsRecent,
+		})
+	}
+	return artifacts, nil
+}
+
+// ─── Installers ──────────────────────────────────────────────────────────────
+
+// InstallerScanOptions controls ScanInstallers.
+type InstallerScanOptions struct {
+	// MinAge skips installers modified more recently than this.
+	MinAge time.Duration
+
+	// MinSize skips installers smaller than this many bytes.
+	MinSize int64
+}
+
+// Installer is a leftover installer file (.exe, .msi, .msix, ...).
+type Installer struct {
+	// Path is the full file path.
+	Path string `json:"path"`
+
+	// Name is the file name.
+	Name string `json:"name"`
+
+	// Size is the size in bytes.
+	Size int64 `json:"size"`
+
+	// Source is the location label, e.g. "Downloads".
+	Source string `json:"source"`
+
+	// ModTime is the last modification time.
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Risk returns the installer's risk level, which is always medium: the
+// file may be the only copy of an installer that is hard to get again.
+func (Installer) Risk() string {
+	return RiskMedium
+}
+
+// ScanInstallers finds installer files in Downloads, Desktop and package
+// manager caches.
+func ScanInstallers(ctx context.Context, opts InstallerScanOptions) ([]Installer, error) {
+	found, err := installer.ScanInstallers(0, opts.MinSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-opts.MinAge)
+	files := make([]Installer, 0, len(found))
+	for _, f := range found {
+		if opts.MinAge > 0 && f.ModTime.After(cutoff) {
+			continue
+		}
+		files = append(files, Installer{
+			Path:    f.Path,
+			Name:    f.Name,
+			Size:    f.Size,
+			Source:  f.Source,
+			ModTime: f.ModTime,
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,79 @@
+// Package purewin is the public Go API for PureWin's scan and clean engine.
+//
+// It wraps the same scanners the pw command uses, without printing, prompting
+// or rendering anything: every function takes a context and an options
+// struct, and returns plain result types. Deletion always goes through the
+// engine's safety checks (protected paths, whitelist-aware scanning, never
+// following junctions, and confining scanned items to their target roots).
+//
+// A typical caller scans, inspects or filters the result, and then deletes:
+//
+//	res, err := purewin.Scan(ctx, purewin.ScanOptions{Profile: "safe"})
+//	if err != nil {
+//		return err
+//	}
+//	out, err := purewin.DeleteItems(ctx, res.Items(), purewin.DeleteOptions{})
+package purewin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/clean"
+)
+
+// ─── Categories ──────────────────────────────────────────────────────────────
+
+// High-level clean categories accepted by ScanOptions.Categories.
+const (
+	CategoryUser     = "user"
+	CategoryBrowser  = "browser"
+	CategoryElectron = "electron"
+	CategoryDev      = "dev"
+	CategorySystem   = "system"
+)
+
+// AllCategories returns every clean category in display order.
+func AllCategories() []string {
+	return []string{CategoryUser, CategoryBrowser, CategoryElectron, CategoryDev, CategorySystem}
+}
+
+// ─── Profiles ────────────────────────────────────────────────────────────────
+
+// Profile is a named set of clean categories.
+type Profile struct {
+	// Name is the profile identifier (e.g. "safe").
+	Name string
+
+	// Description is a human-readable summary of what the profile cleans.
+	Description string
+
+	// Categories lists the high-level categories the profile covers.
+	Categories []string
+}
+
+// Profiles returns the built-in profiles, sorted by name.
+func Profiles() []Profile {
+	names := clean.ProfileNames()
+	out := make([]Profile, 0, len(names))
+	for _, name := range names {
+		p, _ := GetProfile(name)
+		out = append(out, p)
+	}
+	return out
+}
+
+// GetProfile returns the built-in profile with the given name. The error
+// lists the available profiles when the name is unknown.
+func GetProfile(name string) (Profile, error) {
+	p, ok := clean.GetProfile(name)
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q (available: %s)",
+			name, strings.Join(clean.ProfileNames(), ", "))
+	}
+	return Profile{
+		Name:        p.Name,
+		Description: p.Description,
+		Categories:  append([]string(nil), p.Categories...),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package purewin
+
+import (
+	"github.com/lakshaymaurya-felt/purewin/internal/clean"
+	"github.com/lakshaymaurya-felt/purewin/internal/config"
+)
+
+// Risk levels, lowest first.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// ValidRisk reports whether level is a known risk level, ignoring case.
+func ValidRisk(level string) bool {
+	return config.RiskRank(level) >= 0
+}
+
+// RiskWithin reports whether risk is at or below max. An empty max allows
+// everything; an unknown risk is treated as high.
+func RiskWithin(risk, max string) bool {
+	return config.RiskWithin(risk, max)
+}
+
+// TargetRisk returns the risk level of a clean target or extra by name.
+// ArtifactsTarget and InstallersTarget are medium risk; unknown targets
+// and targets without a level are high risk, as RiskWithin treats them.
+func TargetRisk(name string) string {
+	if name == ArtifactsTarget || name == InstallersTarget {
+		return RiskMedium
+	}
+	if p, ok := clean.Lookup(name); ok && p.Risk() != "" {
+		return p.Risk()
+	}
+	return RiskHigh
+}
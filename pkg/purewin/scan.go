@@ -0,0 +1,533 @@
+package purewin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lakshaymaurya-felt/purewin/internal/clean"
+	"github.com/lakshaymaurya-felt/purewin/internal/core"
+	"github.com/lakshaymaurya-felt/purewin/internal/procs"
+	"github.com/lakshaymaurya-felt/purewin/pkg/whitelist"
+)
+
+// ─── Options & Results ───────────────────────────────────────────────────────
+
+// ScanOptions controls which cleanable files Scan looks for.
+type ScanOptions struct {
+	// Categories lists the high-level categories to scan. Profile categories
+	// are added to these; when both are empty, every category is scanned.
+	Categories []string
+
+	// Profile names a built-in profile whose categories are scanned.
+	Profile string
+
+	// Whitelist excludes matching paths from the results. Nil disables it.
+	Whitelist *whitelist.Whitelist
+
+	// SkipAdminTargets skips targets that require elevation even when the
+	// process is elevated. Without elevation they are always skipped.
+	SkipAdminTargets bool
+
+	// MaxRisk drops targets above this risk level ("low", "medium",
+	// "high"). Empty means no limit.
+	MaxRisk string
+
+	// ExcludeTargets drops targets by name (e.g. "TempFiles").
+	ExcludeTargets []string
+
+	// Extras also sizes the targets cleaned through a tool or system API
+	// rather than by deleting listed files (see Extra). Sizing Windows.old
+	// can take a while.
+	Extras bool
+
+	// SkipRunning leaves out targets whose owning application is running
+	// (Chrome's cache while Chrome is open), listing them in
+	// ScanResult.Busy instead. Deleting files an application holds open
+	// fails at best and corrupts its state at worst.
+	SkipRunning bool
+
+	// Progress, if set, is called about every tenth of a second while
+	// scanning with the files found so far and their combined size.
+	Progress func(files, bytes int64)
+
+	// Pace, if set, is waited on before each file and directory is
+	// statted, to cap the scan's IO.
+	Pace Pacer
+}
+
+// Item is a single cleanable file or directory.
+type Item struct {
+	// Path is the absolute filesystem path.
+	Path string `json:"path"`
+
+	// Size is the size in bytes, for a directory of its whole tree.
+	Size int64 `json:"size"`
+
+	// Files is the number of files the item covers. Scan returns a
+	// directory as one item when it can be removed whole.
+	Files int `json:"files"`
+
+	// Category is the high-level category (user, browser, electron, dev, system).
+	Category string `json:"category"`
+
+	// Target is the name of the clean target that found the item.
+	Target string `json:"target"`
+
+	// Root is the target directory the item was found under. DeleteItems
+	// refuses the item if its real path escapes Root.
+	Root string `json:"root,omitempty"`
+}
+
+// Target groups the items found by one clean target (e.g. "ChromeCache").
+type Target struct {
+	// Name is the target name.
+	Name string `json:"name"`
+
+	// Category is the high-level category of the target's items.
+	Category string `json:"category"`
+
+	// Risk is the target's risk level (low, medium, high).
+	Risk string `json:"risk"`
+
+	// Items are the cleanable files and directories found.
+	Items []Item `json:"items"`
+
+	// TotalSize is the sum of all item sizes in bytes.
+	TotalSize int64 `json:"total_size"`
+
+	// FileCount is the number of files the items cover.
+	FileCount int `json:"file_count"`
+}
+
+// Extra is a target cleaned through a tool or system API rather than by
+// deleting listed files: the Recycle Bin, the Go module cache, Docker and
+// Podman images, containers, volumes and build cache, and Windows.old.
+// Clean one with CleanExtra.
+type Extra struct {
+	// Name is the target name (e.g. "RecycleBin").
+	Name string `json:"name"`
+
+	// Description is a human-readable label.
+	Description string `json:"description"`
+
+	// Category is the high-level category of the target.
+	Category string `json:"category"`
+
+	// Risk is the target's risk level (low, medium, high).
+	Risk string `json:"risk"`
+
+	// Size is the estimated size in bytes.
+	Size int64 `json:"size"`
+}
+
+// Busy is a target a scan left out because an application that owns it
+// is running.
+type Busy struct {
+	// Name is the target name (e.g. "ChromeCache").
+	Name string `json:"name"`
+
+	// Category is the high-level category of the target.
+	Category string `json:"category"`
+
+	// Processes are the owning executables found running (e.g.
+	// "chrome.exe").
+	Processes []string `json:"processes"`
+}
+
+// Reason explains why the target was left out, e.g. "chrome.exe is
+// running".
+func (b Busy) Reason() string {
+	verb := "is"
+	if len(b.Processes) > 1 {
+		verb = "are"
+	}
+	return strings.Join(b.Processes, ", ") + " " + verb + " running"
+}
+
+// ScanResult is the outcome of Scan.
+type ScanResult struct {
+	// Targets are the non-empty targets, sorted by category then name.
+	Targets []Target `json:"targets"`
+
+	// TotalSize is the combined size of all items in bytes. It does not
+	// include Extras.
+	TotalSize int64 `json:"total_size"`
+
+	// ItemCount is the number of items across all targets.
+	ItemCount int `json:"item_count"`
+
+	// FileCount is the number of files the items cover.
+	FileCount int `json:"file_count"`
+
+	// Extras are the non-empty extra targets, sorted like Targets. Only
+	// set when ScanOptions.Extras is.
+	Extras []Extra `json:"extras,omitempty"`
+
+	// Busy are the targets left out because their application is running,
+	// sorted like Targets. Only set when ScanOptions.SkipRunning is.
+	Busy []Busy `json:"busy,omitempty"`
+
+	// AboveRisk names the targets in scope that were left out because
+	// their risk level is above ScanOptions.MaxRisk, sorted.
+	AboveRisk []string `json:"above_risk,omitempty"`
+}
+
+// ExtrasSize returns the combined estimated size of all extras in bytes.
+func (r *ScanResult) ExtrasSize() int64 {
+	var total int64
+	for _, e := range r.Extras {
+		total += e.Size
+	}
+	return total
+}
+
+// Items returns every item across all targets.
+func (r *ScanResult) Items() []Item {
+	items := make([]Item, 0, r.ItemCount)
+	for _, t := range r.Targets {
+		items = append(items, t.Items...)
+	}
+	return items
+}
+
+// Paths returns the path of every item across all targets.
+func (r *ScanResult) Paths() []string {
+	paths := make([]string, 0, r.ItemCount)
+	for _, t := range r.Targets {
+		for _, item := range t.Items {
+			paths = append(paths, item.Path)
+		}
+	}
+	return paths
+}
+
+// Filter returns a copy of r holding only the items for which keep returns
+// true. Targets left empty are dropped and totals are recomputed. Extras,
+// Busy and AboveRisk are kept as they are.
+func (r *ScanResult) Filter(keep func(Item) bool) *ScanResult {
+	out := &ScanResult{Extras: r.Extras, Busy: r.Busy, AboveRisk: r.AboveRisk}
+	for _, t := range r.Targets {
+		ft := t
+		ft.Items = nil
+		ft.TotalSize = 0
+		ft.FileCount = 0
+		for _, item := range t.Items {
+			if keep(item) {
+				ft.Items = append(ft.Items, item)
+				ft.TotalSize += item.Size
+				ft.FileCount += item.Files
+			}
+		}
+		if len(ft.Items) == 0 {
+			continue
+		}
+		out.Targets = append(out.Targets, ft)
+		out.TotalSize += ft.TotalSize
+		out.ItemCount += len(ft.Items)
+		out.FileCount += ft.FileCount
+	}
+	return out
+}
+
+// ─── Scan ────────────────────────────────────────────────────────────────────
+
+// Scan finds cleanable files for the requested categories. Directories
+// that can be removed whole come back as one item each, so results stay
+// small however many files a cache holds. A canceled scan returns
+// ctx.Err() without waiting for the scan to wind down.
+//
+// Targets that are not plain files (the Recycle Bin, the Go module cache,
+// container runtime data, Windows.old) are only sized, into Extras, when
+// opts.Extras is set.
+//
+// Each Scan looks for the clean targets afresh; CleanExtra, TargetRisk
+// and the like reuse what the last Scan found.
+func Scan(ctx context.Context, opts ScanOptions) (*ScanResult, error) {
+	clean.Reload()
+	byCategory, aboveRisk, isAdmin, err := opts.providers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Running applications' targets are set aside before scanning. If the
+	// processes cannot be listed, nothing is set aside.
+	var busy []Busy
+	if opts.SkipRunning {
+		if running, err := procs.Running(ctx); err == nil {
+			busy = busyTargets(byCategory, running)
+			skip := make(map[string]bool, len(busy))
+			for _, b := range busy {
+				skip[b.Name] = true
+			}
+			for category, providers := range byCategory {
+				byCategory[category] = slices.DeleteFunc(providers, func(p clean.Provider) bool {
+					return skip[p.Name()]
+				})
+			}
+		}
+	}
+
+	var all []clean.Provider
+	for _, category := range AllCategories() {
+		all = append(all, byCategory[category]...)
+	}
+	var tally clean.Tally
+	results, err := collect(ctx, clean.Stream(ctx, all, opts.Whitelist, isAdmin, &tally, opts.Pace), &tally, opts.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	var extras []Extra
+	if opts.Extras {
+		for _, p := range all {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if p.Itemized(opts.Whitelist) {
+				continue
+			}
+			if size := p.EstimateSize(); size > 0 {
+				extras = append(extras, Extra{
+					Name:        p.Name(),
+					Description: p.Description(),
+					Category:    p.Category(),
+					Risk:        p.Risk(),
+					Size:        size,
+				})
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res := newScanResult(results)
+	sort.Slice(extras, func(i, j int) bool {
+		if extras[i].Category != extras[j].Category {
+			return extras[i].Category < extras[j].Category
+		}
+		return extras[i].Name < extras[j].Name
+	})
+	res.Extras = extras
+	res.Busy = busy
+	res.AboveRisk = aboveRisk
+	return res, nil
+}
+
+// collect gathers the results of a clean.Stream, reporting tally to
+// progress as they arrive. If ctx is canceled it returns at once; the
+// stream's walks stop on their own.
+func collect(ctx context.Context, stream <-chan clean.ScanResult, tally *clean.Tally, progress func(files, bytes int64)) ([]clean.ScanResult, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var results []clean.ScanResult
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			if progress != nil {
+				progress(tally.Files(), tally.Bytes())
+			}
+		case r, ok := <-stream:
+			if !ok {
+				if progress != nil {
+					progress(tally.Files(), tally.Bytes())
+				}
+				return results, nil
+			}
+			results = append(results, r)
+		}
+	}
+}
+
+// RunningTargets returns the targets Scan would cover for opts whose
+// owning application is running now, whether or not opts.SkipRunning is
+// set. Callers use it to ask for the applications to be closed before
+// scanning.
+func RunningTargets(ctx context.Context, opts ScanOptions) ([]Busy, error) {
+	byCategory, _, _, err := opts.providers()
+	if err != nil {
+		return nil, err
+	}
+	running, err := procs.Running(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing running applications: %w", err)
+	}
+	return busyTargets(byCategory, running), nil
+}
+
+// BusyTargets returns those of the named targets whose owning application
+// is running now. Names that are not clean targets (artifact types,
+// installers) have no owners and are never busy. Plans use it to re-check
+// their items' targets before deleting.
+func BusyTargets(ctx context.Context, names []string) ([]Busy, error) {
+	byCategory := make(map[string][]clean.Provider)
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		p, ok := clean.Lookup(name)
+		if !ok || seen[p.Name()] {
+			continue
+		}
+		seen[p.Name()] = true
+		byCategory[p.Category()] = append(byCategory[p.Category()], p)
+	}
+	if len(byCategory) == 0 {
+		return nil, nil
+	}
+	running, err := procs.Running(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing running applications: %w", err)
+	}
+	return busyTargets(byCategory, running), nil
+}
+
+// providers returns the providers in scope for opts, by category, the
+// sorted names of those left out only for being above opts.MaxRisk, and
+// whether admin targets are included.
+func (opts ScanOptions) providers() (map[string][]clean.Provider, []string, bool, error) {
+	if opts.MaxRisk != "" && !ValidRisk(opts.MaxRisk) {
+		return nil, nil, false, fmt.Errorf("invalid risk level %q (want low, medium or high)", opts.MaxRisk)
+	}
+
+	want, err := opts.categories()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	exclude := make(map[string]bool, len(opts.ExcludeTargets))
+	for _, name := range opts.ExcludeTargets {
+		exclude[strings.ToLower(name)] = true
+	}
+
+	isAdmin := core.IsElevated() && !opts.SkipAdminTargets
+
+	byCategory := make(map[string][]clean.Provider)
+	var aboveRisk []string
+	for _, p := range clean.Providers() {
+		switch {
+		case !want[p.Category()],
+			p.RequiresAdmin() && !isAdmin,
+			exclude[strings.ToLower(p.Name())]:
+			continue
+		case !RiskWithin(p.Risk(), opts.MaxRisk):
+			aboveRisk = append(aboveRisk, p.Name())
+			continue
+		}
+		byCategory[p.Category()] = append(byCategory[p.Category()], p)
+	}
+	sort.Strings(aboveRisk)
+	return byCategory, aboveRisk, isAdmin, nil
+}
+
+// categories returns the set of categories in scope for opts: its own and
+// its profile's, or every category when both are empty.
+func (opts ScanOptions) categories() (map[string]bool, error) {
+	categories := append([]string(nil), opts.Categories...)
+	if opts.Profile != "" {
+		p, err := GetProfile(opts.Profile)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, p.Categories...)
+	}
+	if len(categories) == 0 {
+		categories = AllCategories()
+	}
+	want := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		want[c] = true
+	}
+	return want, nil
+}
+
+// busyTargets returns the providers with a running owner, sorted by
+// category then name.
+func busyTargets(byCategory map[string][]clean.Provider, running procs.Set) []Busy {
+	var busy []Busy
+	for _, category := range AllCategories() {
+		for _, p := range byCategory[category] {
+			if owners := running.Owners(p.Processes()); len(owners) > 0 {
+				busy = append(busy, Busy{Name: p.Name(), Category: category, Processes: owners})
+			}
+		}
+	}
+	sort.SliceStable(busy, func(i, j int) bool {
+		if busy[i].Category != busy[j].Category {
+			return busy[i].Category < busy[j].Category
+		}
+		return busy[i].Name < busy[j].Name
+	})
+	return busy
+}
+
+// CleanExtra cleans an extra target found by Scan and returns the bytes
+// freed. Extras whose data cannot be recovered (Windows.old, container
+// volumes) are only cleaned if confirm, given the question to ask, returns
+// true; with a nil confirm they are skipped. Declining frees nothing and
+// is not an error. In dryRun mode nothing is removed and confirm is not
+// called.
+func CleanExtra(ctx context.Context, e Extra, dryRun bool, confirm func(question string) bool) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	p, ok := clean.Lookup(e.Name)
+	if !ok || p.Itemized(nil) {
+		return 0, fmt.Errorf("unknown extra target %q", e.Name)
+	}
+	if q := p.Confirmation(e.Size); q != "" && !dryRun {
+		if confirm == nil || !confirm(q) {
+			return 0, nil
+		}
+	}
+	return p.Clean(nil, dryRun)
+}
+
+// NeedsConfirm reports whether cleaning e asks for confirmation first.
+func (e Extra) NeedsConfirm() bool {
+	p, ok := clean.Lookup(e.Name)
+	return ok && p.Confirmation(e.Size) != ""
+}
+
+// newScanResult converts engine results into the public result type.
+func newScanResult(results []clean.ScanResult) *ScanResult {
+	out := &ScanResult{}
+	for _, r := range results {
+		if len(r.Items) == 0 {
+			continue
+		}
+		t := Target{
+			Name:      r.Category,
+			Category:  r.Items[0].Category,
+			Risk:      TargetRisk(r.Category),
+			Items:     make([]Item, 0, len(r.Items)),
+			TotalSize: r.TotalSize,
+			FileCount: r.FileCount,
+		}
+		for _, item := range r.Items {
+			t.Items = append(t.Items, Item{
+				Path:     item.Path,
+				Size:     item.Size,
+				Files:    item.Files,
+				Category: item.Category,
+				Target:   r.Category,
+				Root:     item.Root,
+			})
+		}
+		out.Targets = append(out.Targets, t)
+		out.TotalSize += t.TotalSize
+		out.ItemCount += len(t.Items)
+		out.FileCount += t.FileCount
+	}
+	sort.Slice(out.Targets, func(i, j int) bool {
+		if out.Targets[i].Category != out.Targets[j].Category {
+			return out.Targets[i].Category < out.Targets[j].Category
+		}
+		return out.Targets[i].Name < out.Targets[j].Name
+	})
+	return out
+}
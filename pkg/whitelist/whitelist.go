@@ -27,6 +27,12 @@ type Whitelist struct {
 	mu       sync.RWMutex
 }
 
+// New returns an in-memory whitelist holding the given patterns. It has no
+// backing file, so Save fails; use Load for a persistent whitelist.
+func New(patterns ...string) *Whitelist {
+	return &Whitelist{patterns: append([]string(nil), patterns...)}
+}
+
 // Load reads whitelist patterns from the given file path.
 // If the file does not exist, a default whitelist is created and saved.
 func Load(path string) (*Whitelist, error) {